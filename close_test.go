@@ -0,0 +1,146 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+// recordingTransport stores all written messages and never receives anything.
+type recordingTransport struct {
+	mu      sync.Mutex
+	written []messages.Common
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newRecordingTransport() *recordingTransport {
+	return &recordingTransport{closed: make(chan struct{})}
+}
+
+func (t *recordingTransport) Close() error {
+	t.once.Do(func() { close(t.closed) })
+	return nil
+}
+
+func (t *recordingTransport) WriteMsg(msg messages.Common, _ bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.written = append(t.written, msg)
+	return nil
+}
+
+func (t *recordingTransport) ReadMsg() (messages.Common, error) {
+	<-t.closed
+	return nil, context.Canceled
+}
+
+func (t *recordingTransport) decoded(tb testing.TB) []tl.Object {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := make([]tl.Object, 0, len(t.written))
+	for _, msg := range t.written {
+		res = append(res, decodeMessage(tb, msg.GetMsg())...)
+	}
+	return res
+}
+
+// decodeMessage decodes message, unwrapping containers and compressed objects.
+func decodeMessage(tb testing.TB, data []byte) []tl.Object {
+	obj, err := tl.DecodeUnknownObject(data)
+	require.NoError(tb, err)
+
+	if packed, ok := obj.(*objects.GzipPacked); ok {
+		return []tl.Object{packed.Obj}
+	}
+
+	container, ok := obj.(*objects.MessageContainer)
+	if !ok {
+		return []tl.Object{obj}
+	}
+
+	var res []tl.Object
+	for _, msg := range *container {
+		res = append(res, decodeMessage(tb, msg.Msg)...)
+	}
+	return res
+}
+
+func TestClose(t *testing.T) {
+	m, err := NewMTProto(Config{})
+	require.NoError(t, err)
+	m.Warnings = make(chan error, 1)
+
+	tr := newRecordingTransport()
+	m.transport = tr
+	ctx, cancel := context.WithCancel(context.Background())
+	m.stopRoutines = cancel
+	CloseOnCancel(ctx, tr)
+	m.startReadingResponses(ctx)
+
+	m.queueAck(4*1 + 1)
+	m.queueAck(4*2 + 1)
+
+	pending := m.getRespChannel()
+	m.responseChannels.Add(42, pending)
+
+	require.NoError(t, m.Close(context.Background()))
+
+	// pending request is resolved
+	select {
+	case resp := <-pending:
+		assert.True(t, errors.Is(resp.(error), ErrClosed))
+	case <-time.After(time.Second):
+		t.Fatal("pending request is not resolved")
+	}
+
+	// final ack is sent, not waiting for timer
+	assert.Equal(t, []tl.Object{&objects.MsgsAck{MsgIDs: []int64{5, 9}}}, tr.decoded(t))
+
+	// warnings are closed
+	_, ok := <-m.Warnings
+	assert.False(t, ok)
+
+	_, err = m.MakeRequest(&objects.PingParams{PingID: 1})
+	assert.True(t, errors.Is(err, ErrClosed))
+	assert.True(t, errors.Is(m.Close(context.Background()), ErrClosed))
+}
+
+func TestRequestIsNotStuckAfterClose(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	result := make(chan error)
+	go func() {
+		_, err := m.MakeRequest(&objects.PingParams{PingID: 1})
+		result <- err
+	}()
+	waitWritten(t, tr, 1)
+
+	// client is closed, but request wasn't resolved, as it was registered right after resolving
+	atomic.StoreInt32(&m.closed, 1)
+	close(m.closing)
+
+	select {
+	case err := <-result:
+		assert.True(t, errors.Is(err, ErrClosed))
+	case <-time.After(time.Second):
+		t.Fatal("request is still waiting for response")
+	}
+	assert.Empty(t, m.responseChannels.Keys())
+}
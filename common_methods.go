@@ -15,15 +15,15 @@ import (
 )
 
 func (m *MTProto) reqPQ(nonce *tl.Int128) (*objects.ResPQ, error) {
-	return objects.ReqPQ(m, nonce)
+	return objects.ReqPQ(keyExchangeRequester{m}, nonce)
 }
 
 func (m *MTProto) reqDHParams(nonce, serverNonce *tl.Int128, p, q []byte, publicKeyFingerprint int64, encryptedData []byte) (objects.ServerDHParams, error) {
-	return objects.ReqDHParams(m, nonce, serverNonce, p, q, publicKeyFingerprint, encryptedData)
+	return objects.ReqDHParams(keyExchangeRequester{m}, nonce, serverNonce, p, q, publicKeyFingerprint, encryptedData)
 }
 
 func (m *MTProto) setClientDHParams(nonce, serverNonce *tl.Int128, encryptedData []byte) (objects.SetClientDHParamsAnswer, error) {
-	return objects.SetClientDHParams(m, nonce, serverNonce, encryptedData)
+	return objects.SetClientDHParams(keyExchangeRequester{m}, nonce, serverNonce, encryptedData)
 }
 
 func (m *MTProto) ping(pingID int64) (*objects.Pong, error) {
@@ -552,3 +552,16 @@ func (*errorSessionConfigsChanged) Error() string {
 func (*errorSessionConfigsChanged) CRC() uint32 {
 	panic("makes no sense")
 }
+
+type errorClosed null
+
+func (*errorClosed) Error() string {
+	return "connection is closed"
+}
+
+func (*errorClosed) CRC() uint32 {
+	panic("makes no sense")
+}
+
+// ErrClosed is returned to all pending and new requests after Close was called.
+var ErrClosed error = &errorClosed{}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// FloodWaitError is returned, when server asks to wait before repeating request (FLOOD_WAIT_X,
+// SLOWMODE_WAIT_X, FLOOD_TEST_PHONE_WAIT_X) and FloodWaitPolicy decided not to wait.
+type FloodWaitError struct {
+	*ErrResponseCode
+	Wait time.Duration
+}
+
+// FloodWaitErrorFromNative returns nil, if e is not a flood wait error.
+func FloodWaitErrorFromNative(e *ErrResponseCode) *FloodWaitError {
+	switch e.Message {
+	case "FLOOD_WAIT_X", "SLOWMODE_WAIT_X", "FLOOD_TEST_PHONE_WAIT_X":
+	default:
+		return nil
+	}
+
+	seconds, ok := e.AdditionalInfo.(int)
+	if !ok {
+		return nil
+	}
+
+	return &FloodWaitError{
+		ErrResponseCode: e,
+		Wait:            time.Duration(seconds) * time.Second,
+	}
+}
+
+func (e *FloodWaitError) Unwrap() error {
+	return e.ErrResponseCode
+}
+
+// FloodWaitPolicy decides, must client wait and repeat request, which failed with flood wait error, or
+// return error to caller.
+type FloodWaitPolicy interface {
+	// ShouldRetry is called every time, when request failed with flood wait. attempt is count of previous
+	// retries of this request.
+	ShouldRetry(err *FloodWaitError, attempt int) bool
+}
+
+// ThresholdFloodWaitPolicy repeats requests, if server asks to wait no longer than MaxWait. if MaxRetries
+// is more than zero, request will be repeated no more than MaxRetries times.
+type ThresholdFloodWaitPolicy struct {
+	MaxWait    time.Duration
+	MaxRetries int
+}
+
+var _ FloodWaitPolicy = (*ThresholdFloodWaitPolicy)(nil)
+
+func (p *ThresholdFloodWaitPolicy) ShouldRetry(err *FloodWaitError, attempt int) bool {
+	if p.MaxRetries > 0 && attempt >= p.MaxRetries {
+		return false
+	}
+	return err.Wait <= p.MaxWait
+}
+
+// FloodWaitStats shows, how flood wait errors were processed by client.
+type FloodWaitStats struct {
+	Retries   uint64        // how many times requests were repeated after waiting
+	Failures  uint64        // how many flood wait errors were returned to caller
+	TotalWait time.Duration // summary time, which requests spent on waiting
+}
+
+type floodWaitCounters struct {
+	retries   uint64
+	failures  uint64
+	totalWait int64
+}
+
+// FloodWaitStats returns counters of processed flood wait errors since client was created.
+func (m *MTProto) FloodWaitStats() FloodWaitStats {
+	return FloodWaitStats{
+		Retries:   atomic.LoadUint64(&m.floodWaitStats.retries),
+		Failures:  atomic.LoadUint64(&m.floodWaitStats.failures),
+		TotalWait: time.Duration(atomic.LoadInt64(&m.floodWaitStats.totalWait)),
+	}
+}
+
+// waitFlood sleeps required time, if policy allows to repeat request. returns error, if request must
+// not be repeated.
+func (m *MTProto) waitFlood(ctx context.Context, e *FloodWaitError, attempt int) error {
+	if m.floodWaitPolicy == nil || !m.floodWaitPolicy.ShouldRetry(e, attempt) {
+		atomic.AddUint64(&m.floodWaitStats.failures, 1)
+		return e
+	}
+
+	timer := time.NewTimer(e.Wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	atomic.AddUint64(&m.floodWaitStats.retries, 1)
+	atomic.AddInt64(&m.floodWaitStats.totalWait, int64(e.Wait))
+	return nil
+}
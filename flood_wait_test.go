@@ -0,0 +1,72 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+func TestFloodWaitErrorFromNative(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		msg  string
+		want time.Duration
+		isFW bool
+	}{
+		{name: "flood wait", msg: "FLOOD_WAIT_42", want: 42 * time.Second, isFW: true},
+		{name: "slowmode", msg: "SLOWMODE_WAIT_3", want: 3 * time.Second, isFW: true},
+		{name: "test phone", msg: "FLOOD_TEST_PHONE_WAIT_7", want: 7 * time.Second, isFW: true},
+		{name: "other error", msg: "PHONE_MIGRATE_2"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			native := RpcErrorToNative(&objects.RpcError{ErrorCode: 420, ErrorMessage: tt.msg}).(*ErrResponseCode)
+			got := FloodWaitErrorFromNative(native)
+			if !tt.isFW {
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Equal(t, tt.want, got.Wait)
+
+			var asResponse *ErrResponseCode
+			assert.True(t, errors.As(got, &asResponse))
+		})
+	}
+}
+
+func TestThresholdFloodWaitPolicy(t *testing.T) {
+	p := &ThresholdFloodWaitPolicy{MaxWait: 10 * time.Second, MaxRetries: 2}
+
+	assert.True(t, p.ShouldRetry(&FloodWaitError{Wait: 5 * time.Second}, 0))
+	assert.False(t, p.ShouldRetry(&FloodWaitError{Wait: 11 * time.Second}, 0))
+	assert.False(t, p.ShouldRetry(&FloodWaitError{Wait: 5 * time.Second}, 2))
+}
+
+func TestMTProto_waitFlood(t *testing.T) {
+	m := &MTProto{
+		floodWaitPolicy: &ThresholdFloodWaitPolicy{MaxWait: time.Second},
+		floodWaitStats:  new(floodWaitCounters),
+	}
+
+	short := &FloodWaitError{ErrResponseCode: &ErrResponseCode{}, Wait: time.Millisecond}
+	assert.NoError(t, m.waitFlood(context.Background(), short, 0))
+
+	long := &FloodWaitError{ErrResponseCode: &ErrResponseCode{}, Wait: time.Hour}
+	assert.Equal(t, long, m.waitFlood(context.Background(), long, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, m.waitFlood(ctx, &FloodWaitError{Wait: time.Second}, 0))
+
+	assert.Equal(t, FloodWaitStats{Retries: 1, Failures: 1, TotalWait: time.Millisecond}, m.FloodWaitStats())
+}
@@ -7,6 +7,8 @@ package mtproto
 
 import (
 	"bytes"
+	"context"
+	"crypto/rsa"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -24,8 +26,14 @@ import (
 
 // https://tlgrm.ru/docs/mtproto/auth_key
 // https://core.telegram.org/mtproto/auth_key
-func (m *MTProto) makeAuthKey() error { // nolint don't know how to make method smaller
-	m.serviceModeActivated = true
+// expiresIn is 0 for permanent key, otherwise temporary key is created, which expires after expiresIn
+// seconds.
+func (m *MTProto) makeAuthKey(expiresIn int32) error { // nolint don't know how to make method smaller
+	m.serviceModeActivated.Set(true)
+	// service mode must be turned off even if handshake failed, otherwise all next responses go to
+	// serviceChannel
+	defer m.serviceModeActivated.Set(false)
+
 	nonceFirst := tl.RandomInt128()
 	res, err := m.reqPQ(nonceFirst)
 	if err != nil {
@@ -35,15 +43,9 @@ func (m *MTProto) makeAuthKey() error { // nolint don't know how to make method
 	if nonceFirst.Cmp(res.Nonce.Int) != 0 {
 		return errors.New("handshake: Wrong nonce")
 	}
-	found := false
-	for _, b := range res.Fingerprints {
-		if uint64(b) == binary.LittleEndian.Uint64(keys.RSAFingerprint(m.publicKey)) {
-			found = true
-			break
-		}
-	}
-	if !found {
-		return errors.New("handshake: Can't find fingerprint")
+	publicKey, keyFingerprint, err := m.choosePublicKey(res.Fingerprints)
+	if err != nil {
+		return err
 	}
 
 	// (encoding) p_q_inner_data
@@ -52,26 +54,56 @@ func (m *MTProto) makeAuthKey() error { // nolint don't know how to make method
 	nonceSecond := tl.RandomInt256()
 	nonceServer := res.ServerNonce
 
-	message, err := tl.Marshal(&objects.PQInnerData{
+	var innerData tl.Object = &objects.PQInnerData{
 		Pq:          res.Pq,
 		P:           p.Bytes(),
 		Q:           q.Bytes(),
 		Nonce:       nonceFirst,
 		ServerNonce: nonceServer,
 		NewNonce:    nonceSecond,
-	})
-	check(err) // well, I don’t know what will happen in the universe so that there will panic
+	}
+	if !m.legacyRSA {
+		innerData = &objects.PQInnerDataDC{
+			Pq:          res.Pq,
+			P:           p.Bytes(),
+			Q:           q.Bytes(),
+			Nonce:       nonceFirst,
+			ServerNonce: nonceServer,
+			NewNonce:    nonceSecond,
+			DC:          int32(m.encodedDC()),
+		}
+	}
+	if expiresIn > 0 {
+		innerData = &objects.PQInnerDataTempDC{
+			Pq:          res.Pq,
+			P:           p.Bytes(),
+			Q:           q.Bytes(),
+			Nonce:       nonceFirst,
+			ServerNonce: nonceServer,
+			NewNonce:    nonceSecond,
+			DC:          int32(m.encodedDC()),
+			ExpiresIn:   expiresIn,
+		}
+	}
 
-	hashAndMsg := make([]byte, 255)
-	copy(hashAndMsg, append(dry.Sha1(string(message)), message...))
+	message, err := tl.Marshal(innerData)
+	check(err) // well, I don’t know what will happen in the universe so that there will panic
 
-	encryptedMessage := math.DoRSAencrypt(hashAndMsg, m.publicKey)
+	encryptedMessage, err := m.encryptInnerData(message, publicKey)
+	if err != nil {
+		return errors.Wrap(err, "encrypting p_q_inner_data")
+	}
 
-	keyFingerprint := int64(binary.LittleEndian.Uint64(keys.RSAFingerprint(m.publicKey)))
 	dhResponse, err := m.reqDHParams(nonceFirst, nonceServer, p.Bytes(), q.Bytes(), keyFingerprint, encryptedMessage)
 	if err != nil {
 		return errors.Wrap(err, "sending ReqDHParams")
 	}
+	if fail, ok := dhResponse.(*objects.ServerDHParamsFail); ok {
+		if !bytes.Equal(dry.BigIntBytes(fail.NewNonceHash.Int, tl.Int128Len*8), dry.Sha1Byte(int256Bytes(nonceSecond))[4:20]) { //nolint:gomnd bits in byte
+			return errors.New("handshake: Wrong new_nonce_hash in server_DH_params_fail")
+		}
+		return errors.New("handshake: server refused to create auth key")
+	}
 	dhParams, ok := dhResponse.(*objects.ServerDHParamsOk)
 	if !ok {
 		return errors.New("handshake: Need ServerDHParamsOk")
@@ -102,63 +134,184 @@ func (m *MTProto) makeAuthKey() error { // nolint don't know how to make method
 		return errors.New("handshake: Wrong server_nonce")
 	}
 
-	// this apparently is just part of diffie hellman, so just leave it as it is, hope that it will just work
-	_, gB, gAB := math.MakeGAB(dhi.G, big.NewInt(0).SetBytes(dhi.GA), big.NewInt(0).SetBytes(dhi.DhPrime))
-
-	authKey := gAB.Bytes()
-	if authKey[0] == 0 {
-		authKey = authKey[1:]
+	dhPrime := big.NewInt(0).SetBytes(dhi.DhPrime)
+	gA := big.NewInt(0).SetBytes(dhi.GA)
+	if err := math.CheckDHParams(dhi.G, dhPrime); err != nil {
+		return errors.Wrap(err, "handshake: invalid dh_prime or g")
+	}
+	if err := math.CheckDHValue(gA, dhPrime); err != nil {
+		return errors.Wrap(err, "handshake: invalid g_a")
+	}
+	if dhi.ServerTime <= 0 {
+		return fmt.Errorf("handshake: invalid server_time: %v", dhi.ServerTime)
 	}
 
-	m.SetAuthKey(authKey)
+	// server sends it's time, so all next messages will have correct msg_id even if local clock is wrong
+	m.msgIDs.SetServerTime(int64(dhi.ServerTime))
 
-	// I don't know what it is, apparently some very specific way to generate keys
-	t4 := make([]byte, 32+1+8) // nolint:gomnd ALL PROTOCOL IS A MAGIC
-	copy(t4[0:], nonceSecond.Bytes())
-	t4[32] = 1
-	copy(t4[33:], dry.Sha1Byte(m.GetAuthKey())[0:8])
-	nonceHash1 := dry.Sha1Byte(t4)[4:20]
 	salt := make([]byte, tl.LongLen)
 	copy(salt, nonceSecond.Bytes()[:8])
 	math.Xor(salt, nonceServer.Bytes()[:8])
-	m.serverSalt = int64(binary.LittleEndian.Uint64(salt))
+	m.setServerSalt(int64(binary.LittleEndian.Uint64(salt)))
 
-	// (encoding) client_DH_inner_data
-	clientDHData, err := tl.Marshal(&objects.ClientDHInnerData{
-		Nonce:       nonceFirst,
-		ServerNonce: nonceServer,
-		Retry:       0,
-		GB:          gB.Bytes(),
-	})
-	check(err) // well, I don’t know what will happen in the universe so that there will panic
+	// server could ask to generate g_b again with dh_gen_retry, then retry_id is auth_key_aux_hash of
+	// previous attempt
+	var retryID int64
+	for attempt := 0; ; attempt++ {
+		_, gB, gAB, err := math.MakeGAB(dhi.G, gA, dhPrime)
+		if err != nil {
+			return errors.Wrap(err, "generating g_b")
+		}
 
-	encryptedMessage = ige.EncryptMessageWithTempKeys(clientDHData, nonceSecond.Int, nonceServer.Int)
+		authKey := make([]byte, authKeySize)
+		gABBytes := gAB.Bytes()
+		copy(authKey[authKeySize-len(gABBytes):], gABBytes)
+		authKeyAuxHash := dry.Sha1Byte(authKey)[0:8]
 
-	dhGenStatus, err := m.setClientDHParams(nonceFirst, nonceServer, encryptedMessage)
-	if err != nil {
-		return errors.Wrap(err, "sending clientDHParams")
+		// (encoding) client_DH_inner_data
+		clientDHData, err := tl.Marshal(&objects.ClientDHInnerData{
+			Nonce:       nonceFirst,
+			ServerNonce: nonceServer,
+			Retry:       retryID,
+			GB:          gB.Bytes(),
+		})
+		check(err) // well, I don’t know what will happen in the universe so that there will panic
+
+		encryptedMessage = ige.EncryptMessageWithTempKeys(clientDHData, nonceSecond.Int, nonceServer.Int)
+
+		dhGenStatus, err := m.setClientDHParams(nonceFirst, nonceServer, encryptedMessage)
+		if err != nil {
+			return errors.Wrap(err, "sending clientDHParams")
+		}
+
+		switch dhg := dhGenStatus.(type) {
+		case *objects.DHGenOk:
+			if err := checkDHGenAnswer(nonceFirst, nonceServer, dhg.Nonce, dhg.ServerNonce); err != nil {
+				return err
+			}
+			if err := checkNewNonceHash(nonceSecond, 1, authKeyAuxHash, dhg.NewNonceHash1); err != nil {
+				return err
+			}
+
+			// (all ok)
+			m.SetAuthKey(authKey)
+			m.encrypted.Set(true)
+			return nil
+
+		case *objects.DHGenRetry:
+			if err := checkDHGenAnswer(nonceFirst, nonceServer, dhg.Nonce, dhg.ServerNonce); err != nil {
+				return err
+			}
+			if err := checkNewNonceHash(nonceSecond, 2, authKeyAuxHash, dhg.NewNonceHash2); err != nil {
+				return err
+			}
+			if attempt+1 >= maxDHGenRetries {
+				return fmt.Errorf("handshake: server asked to retry %d times", attempt+1)
+			}
+
+			retryID = int64(binary.LittleEndian.Uint64(authKeyAuxHash))
+
+		case *objects.DHGenFail:
+			if err := checkDHGenAnswer(nonceFirst, nonceServer, dhg.Nonce, dhg.ServerNonce); err != nil {
+				return err
+			}
+			if err := checkNewNonceHash(nonceSecond, 3, authKeyAuxHash, dhg.NewNonceHash3); err != nil {
+				return err
+			}
+			return errors.New("handshake: server refused to create auth key (dh_gen_fail)")
+
+		default:
+			return errors.New("handshake: Need DHGenOk")
+		}
 	}
+}
 
-	dhg, ok := dhGenStatus.(*objects.DHGenOk)
-	if !ok {
-		return errors.New("handshake: Need DHGenOk")
+type keyExchangeKey struct{}
+
+// withKeyExchange marks requests, which are sent during key exchange. Key exchange holds rekeyMutex, so
+// they are sent without it, and without outbox: other messages are not sent until exchange is finished.
+func withKeyExchange(ctx context.Context) context.Context {
+	return context.WithValue(ctx, keyExchangeKey{}, true)
+}
+
+func isKeyExchange(ctx context.Context) bool {
+	v, _ := ctx.Value(keyExchangeKey{}).(bool)
+	return v
+}
+
+// keyExchangeRequester sends requests of handshake, which are made by objects package.
+type keyExchangeRequester struct {
+	m *MTProto
+}
+
+func (r keyExchangeRequester) MakeRequest(msg tl.Object) (any, error) {
+	return r.m.makeRequest(withKeyExchange(context.Background()), msg)
+}
+
+const (
+	authKeySize = 256
+	// how many times server can ask to generate g_b again
+	maxDHGenRetries = 5
+)
+
+// choosePublicKey returns the first of known public keys, which fingerprint is presented by server
+func (m *MTProto) choosePublicKey(fingerprints []int64) (*rsa.PublicKey, int64, error) {
+	for _, key := range m.publicKeys {
+		keyFingerprint := int64(binary.LittleEndian.Uint64(keys.RSAFingerprint(key)))
+		for _, f := range fingerprints {
+			if f == keyFingerprint {
+				return key, keyFingerprint, nil
+			}
+		}
+	}
+
+	return nil, 0, fmt.Errorf("handshake: Can't find fingerprint: server offers %x", fingerprints)
+}
+
+// encryptInnerData encrypts p_q_inner_data for req_DH_params by RSA_PAD or by old scheme, if it's
+// required in config
+func (m *MTProto) encryptInnerData(message []byte, key *rsa.PublicKey) ([]byte, error) {
+	if !m.legacyRSA {
+		return math.DoRSAPadEncrypt(message, key)
 	}
-	if nonceFirst.Cmp(dhg.Nonce.Int) != 0 {
-		return fmt.Errorf("handshake: Wrong nonce: %v, %v", nonceFirst, dhg.Nonce)
+
+	hashAndMsg := make([]byte, 255)
+	copy(hashAndMsg, append(dry.Sha1(string(message)), message...))
+
+	return math.DoRSAencrypt(hashAndMsg, key), nil
+}
+
+func checkDHGenAnswer(nonce, serverNonce, gotNonce, gotServerNonce *tl.Int128) error {
+	if nonce.Cmp(gotNonce.Int) != 0 {
+		return fmt.Errorf("handshake: Wrong nonce: %v, %v", nonce, gotNonce)
 	}
-	if nonceServer.Cmp(dhg.ServerNonce.Int) != 0 {
-		return fmt.Errorf("handshake: Wrong server_nonce: %v, %v", nonceServer, dhg.ServerNonce)
+	if serverNonce.Cmp(gotServerNonce.Int) != 0 {
+		return fmt.Errorf("handshake: Wrong server_nonce: %v, %v", serverNonce, gotServerNonce)
 	}
-	if !bytes.Equal(nonceHash1, dhg.NewNonceHash1.Bytes()) {
+	return nil
+}
+
+// checkNewNonceHash checks new_nonce_hash1, 2 or 3 (number is set by n): 128 lower bits of
+// SHA1(new_nonce + n + auth_key_aux_hash)
+func checkNewNonceHash(newNonce *tl.Int256, n byte, authKeyAuxHash []byte, got *tl.Int128) error {
+	t4 := make([]byte, 32+1+8) // nolint:gomnd ALL PROTOCOL IS A MAGIC
+	copy(t4[0:], int256Bytes(newNonce))
+	t4[32] = n
+	copy(t4[33:], authKeyAuxHash)
+	want := dry.Sha1Byte(t4)[4:20]
+
+	if !bytes.Equal(want, dry.BigIntBytes(got.Int, tl.Int128Len*8)) { //nolint:gomnd bits in byte
 		return fmt.Errorf(
-			"handshake: Wrong new_nonce_hash1: %v, %v",
-			hex.EncodeToString(nonceHash1),
-			hex.EncodeToString(dhg.NewNonceHash1.Bytes()),
+			"handshake: Wrong new_nonce_hash%d: %v, %v",
+			n,
+			hex.EncodeToString(want),
+			hex.EncodeToString(got.Bytes()),
 		)
 	}
+	return nil
+}
 
-	// (all ok)
-	m.serviceModeActivated = false
-	m.encrypted = true
-	return errors.Wrap(err, "saving session")
+// int256Bytes returns bytes of nonce with leading zeros
+func int256Bytes(i *tl.Int256) []byte {
+	return dry.BigIntBytes(i.Int, tl.Int256Len*8) //nolint:gomnd bits in byte
 }
@@ -0,0 +1,119 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/keys"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+	"github.com/umesproject/mtproto/mtprototest"
+)
+
+func TestChoosePublicKey(t *testing.T) {
+	publicKeys, err := keys.Read()
+	require.NoError(t, err)
+	require.True(t, len(publicKeys) > 2)
+
+	fingerprint := func(k *rsa.PublicKey) int64 {
+		return int64(binary.LittleEndian.Uint64(keys.RSAFingerprint(k)))
+	}
+
+	m, err := NewMTProto(Config{PublicKeys: publicKeys})
+	require.NoError(t, err)
+
+	// server offers only one of known keys and some unknown one
+	key, f, err := m.choosePublicKey([]int64{0x1234, fingerprint(publicKeys[2])})
+	require.NoError(t, err)
+	assert.Equal(t, publicKeys[2], key)
+	assert.Equal(t, fingerprint(publicKeys[2]), f)
+
+	_, _, err = m.choosePublicKey([]int64{0x1234})
+	assert.Error(t, err)
+
+	// deprecated single key is also used
+	m, err = NewMTProto(Config{PublicKey: publicKeys[1]})
+	require.NoError(t, err)
+	key, _, err = m.choosePublicKey([]int64{fingerprint(publicKeys[1])})
+	require.NoError(t, err)
+	assert.Equal(t, publicKeys[1], key)
+}
+
+func TestFailedHandshakeTurnsServiceModeOff(t *testing.T) {
+	m, err := NewMTProto(Config{})
+	require.NoError(t, err)
+	tr := newRecordingTransport()
+	m.transport = tr
+	defer tr.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- m.makeAuthKey(0) }()
+
+	waitWritten(t, tr, 1)
+	m.serviceChannel <- &objects.ResPQ{Nonce: tl.RandomInt128(), ServerNonce: tl.RandomInt128()}
+
+	require.EqualError(t, <-done, "handshake: Wrong nonce")
+	assert.False(t, m.serviceModeActivated.Get(), "responses must not go to serviceChannel anymore")
+}
+
+func TestHandshakeRequestsAreNotPending(t *testing.T) {
+	s, err := mtprototest.NewServer(mtprototest.Config{})
+	require.NoError(t, err)
+	defer s.Close()
+
+	m, err := NewMTProto(Config{ServerHost: s.Addr(), PublicKeys: []*rsa.PublicKey{s.PublicKey()}})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, m.connect(ctx))
+	m.startReadingResponses(ctx)
+
+	require.NoError(t, m.makeAuthKey(0))
+	assert.Empty(t, m.responseChannels.Keys(), "handshake requests must not wait for restoring or closing")
+}
+
+func TestHandshakeEncodesDC(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		cfg       Config
+		expiresIn int32
+		encoded   int
+	}{
+		{name: "production", cfg: Config{DC: 2}, encoded: 2},
+		{name: "test", cfg: Config{DC: 2, TestDC: true}, encoded: 10002},
+		{name: "media", cfg: Config{DC: 4, MediaDC: true}, encoded: -4},
+		{name: "test media", cfg: Config{DC: 4, TestDC: true, MediaDC: true}, encoded: -10004},
+		{name: "temporary key", cfg: Config{DC: 2, TestDC: true}, expiresIn: 3600, encoded: 10002},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// server refuses handshake, if dc in p_q_inner_data_dc is not the same
+			s, err := mtprototest.NewServer(mtprototest.Config{DC: tt.encoded})
+			require.NoError(t, err)
+			defer s.Close()
+
+			cfg := tt.cfg
+			cfg.ServerHost = s.Addr()
+			cfg.PublicKeys = []*rsa.PublicKey{s.PublicKey()}
+			m, err := NewMTProto(cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.encoded, m.encodedDC())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			require.NoError(t, m.connect(ctx))
+			m.startReadingResponses(ctx)
+
+			require.NoError(t, m.makeAuthKey(tt.expiresIn))
+		})
+	}
+}
@@ -8,6 +8,7 @@ package ige
 import (
 	"bytes"
 	"crypto/aes"
+	"crypto/sha256"
 	"math/big"
 
 	"github.com/xelaj/go-dry"
@@ -17,34 +18,87 @@ type AesBlock [aes.BlockSize]byte
 type AesKV [32]byte
 type AesIgeBlock [48]byte
 
-func MessageKey(msg []byte) []byte {
-	return dry.Sha1(string(msg))[4:20]
+// MessageKey returns msg_key of MTProto 2.0: middle 128 bits of SHA256 from part of auth key and message
+// (with padding). decode is true, when message is sent by server.
+// https://core.telegram.org/mtproto/description#defining-aes-key-and-initialization-vector
+func MessageKey(authKey, msgPadded []byte, decode bool) []byte {
+	var x int
+	if decode {
+		x = 8
+	}
+
+	msgKeyLarge := sha256.Sum256(bytes.Join([][]byte{authKey[88+x : 88+x+32], msgPadded}, nil))
+	return msgKeyLarge[8 : 8+16]
+}
+
+// Encrypt encrypts message, which will be sent from client to server. msg must be already padded to
+// the size divisible by 16 (MTProto 2.0 requires 12 to 1024 random padding bytes)
+func Encrypt(msg, authKey []byte) (msgKey, encrypted []byte, err error) {
+	return encrypt(msg, authKey, false)
+}
+
+// Decrypt decrypts message, which was sent from server to client. msgKey is required to generate aes
+// key, checking that decrypted data is the same as msgKey is on the caller side.
+func Decrypt(msg, authKey, msgKey []byte) ([]byte, error) {
+	return decrypt(msg, authKey, msgKey, true)
+}
+
+// EncryptAsServer encrypts message in the same way as server does it. It's useful only for fake servers in
+// tests.
+func EncryptAsServer(msg, authKey []byte) (msgKey, encrypted []byte, err error) {
+	return encrypt(msg, authKey, true)
+}
+
+// DecryptAsServer decrypts message, which was sent from client to server.
+func DecryptAsServer(msg, authKey, msgKey []byte) ([]byte, error) {
+	return decrypt(msg, authKey, msgKey, false)
 }
 
-func Encrypt(msg, key []byte) ([]byte, error) {
-	msgKey := MessageKey(msg)
-	aesKey, aesIV := generateAESIGE(msgKey, key, false)
+// EncryptV1 encrypts message by MTProto 1.0 rules. It's still required to encrypt inner message of
+// auth.bindTempAuthKey. msg is not padded, random padding (up to 15 bytes) is added here.
+// https://core.telegram.org/mtproto/description_v1#defining-aes-key-and-initialization-vector
+func EncryptV1(msg, authKey []byte) (msgKey, encrypted []byte, err error) {
+	// msg_key is lower 128 bits of SHA1 of message without padding
+	msgKey = dry.Sha1Byte(msg)[4:20]
+
+	padded := msg
+	if len(msg)%aes.BlockSize != 0 {
+		padded = append(append([]byte{}, msg...), dry.RandomBytes(aes.BlockSize-len(msg)%aes.BlockSize)...)
+	}
 
-	// СУДЯ ПО ВСЕМУ вообще не уверен, но это видимо паддинг для добива блока, чтоб он делился на 256 бит
-	data := make([]byte, len(msg)+((16-(len(msg)%16))&15))
-	copy(data, msg)
+	aesKey, aesIV := generateAESIGEv1(msgKey, authKey, false)
+
+	encrypted = make([]byte, len(padded))
+	if err := doAES256IGEencrypt(padded, encrypted, aesKey, aesIV); err != nil {
+		return nil, nil, err
+	}
+
+	return msgKey, encrypted, nil
+}
+
+func encrypt(msg, authKey []byte, decode bool) (msgKey, encrypted []byte, err error) {
+	if err := isCorrectData(msg); err != nil {
+		return nil, nil, err
+	}
+
+	msgKey = MessageKey(authKey, msg, decode)
+	aesKey, aesIV := generateAESIGEv2(msgKey, authKey, decode)
 
 	c, err := NewCipher(aesKey, aesIV)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	out := make([]byte, len(data))
-	if err := c.doAES256IGEencrypt(data, out); err != nil {
-		return nil, err
+	encrypted = make([]byte, len(msg))
+	if err := c.doAES256IGEencrypt(msg, encrypted); err != nil {
+		return nil, nil, err
 	}
 
-	return out, nil
+	return msgKey, encrypted, nil
 }
 
-// checkData это msgkey в понятиях мтпрото, нужно что бы проверить, успешно ли прошла расшифровка
-func Decrypt(msg, key, checkData []byte) ([]byte, error) {
-	aesKey, aesIV := generateAESIGE(checkData, key, true)
+func decrypt(msg, authKey, msgKey []byte, decode bool) ([]byte, error) {
+	aesKey, aesIV := generateAESIGEv2(msgKey, authKey, decode)
 
 	c, err := NewCipher(aesKey, aesIV)
 	if err != nil {
@@ -75,6 +129,25 @@ func doAES256IGEdecrypt(data, out, key, iv []byte) error {
 	return c.doAES256IGEdecrypt(data, out)
 }
 
+// EncryptAES256IGE encrypts data by plain AES-256-IGE with specified key and iv (iv is 32 bytes). Length of data
+// must be divisible by 16.
+func EncryptAES256IGE(data, key, iv []byte) ([]byte, error) {
+	out := make([]byte, len(data))
+	if err := doAES256IGEencrypt(data, out, key, iv); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DecryptAES256IGE is reverse function for EncryptAES256IGE.
+func DecryptAES256IGE(data, key, iv []byte) ([]byte, error) {
+	out := make([]byte, len(data))
+	if err := doAES256IGEdecrypt(data, out, key, iv); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DecryptMessageWithTempKeys дешифрует сообщение паролем, которые получены в процессе обмена ключами диффи хеллмана
 func DecryptMessageWithTempKeys(msg []byte, nonceSecond, nonceServer *big.Int) []byte {
 	key, iv := generateTempKeys(nonceSecond, nonceServer)
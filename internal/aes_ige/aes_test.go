@@ -6,11 +6,14 @@
 package ige
 
 import (
+	"crypto/aes"
 	"encoding/hex"
 	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xelaj/go-dry"
 )
 
 func TestDoAES256IGEdecrypt(t *testing.T) {
@@ -229,41 +232,103 @@ func newCasesDecryptMessageWithTempKeys() []caseDecryptMessageWithTempKeys {
 	}
 }
 
+var testAuthKey = Hexed("28A92FE20173B347A8BB324B5FAB2667C9A8BBCE6468D5B509A4CBDDC186240A" +
+	"C912CF7006AF8926DE606A2E74C0493CAA57741E6C82451F54D3E068F5CCC49B" +
+	"4444124B9666FFB405AAB564A3D01E67F6E912867C8D20D9882707DC330B17B4" +
+	"E0DD57CB53BFAAFA9EF5BE76AE6C1B9B6C51E2D6502A47C883095C46C81E3BE2" +
+	"5F62427B585488BB3BF239213BF48EB8FE34C9A026CC8413934043974DB03556" +
+	"633038392CECB51F94824E140B98637730A4BE79A8F9DAFA39BAE81E1095849E" +
+	"A4C83467C92A3A17D997817C8A7AC61C3FF414DA37B7D66E949C0AEC858F0482" +
+	"24210FCC61F11C3A910B431CCBD104CCCC8DC6D29D4A5D133BE639A4C32BBFF1" +
+	"53E63ACA3AC52F2E4709B8AE01844B142C1EE89D075D64F69A399FEB04E656FE" +
+	"3675A6F8F412078F3D0B58DA15311C1A9F8E53B3CD6BB5572C294904B726D0BE" +
+	"337E2E21977DA26DD6E33270251C2CA29DFCC70227F0755F84CFDA9AC4B8DD5F" +
+	"84F1D1EB36BA45CDDC70444D8C213E4BD8F63B8AB95A2D0B4180DC91283DC063" +
+	"ACFB92D6A4E407CDE7C8C69689F77A007441D4A6A8384B666502D9B77FC68B5B" +
+	"43CC607E60A146223E110FCB43BC3C942EF981930CDC4A1D310C0B64D5E55D30" +
+	"8D863251AB90502C3E46CC599E886A927CDA963B9EB16CE62603B68529EE98F9" +
+	"F5206419E03FB458EC4BD9454AA8F6BA777573CC54B328895B1DF25EAD9FB4CD" +
+	"5198EE022B2B81F388D281D5E5BC580107CA01A50665C32B552715F335FD7626" +
+	"4FAD00DDD5AE45B94832AC79CE7C511D194BC42B70EFA850BB15C2012C5215CA" +
+	"BFE97CE66B8D8734D0EE759A638AF013")
+
 func TestMessageKey(t *testing.T) {
 	tests := []struct {
-		name string
-		msg  []byte
-		want []byte
+		name   string
+		msg    []byte
+		decode bool
+		want   []byte
 	}{
 		{
-			name: "empty",
-			msg:  Hexed(""),
-			// correct: http://craiccomputing.blogspot.com/2009/09/sha1-digest-of-empty-string.html
-			want: Hexed("5E6B4B0D3255BFEF95601890AFD80709"),
+			name: "from client",
+			msg:  append([]byte("hello world!"), 0, 0, 0, 0),
+			want: Hexed("0900776FADA995358E38DE060D514CB2"),
 		},
 		{
-			name: "zeros",
-			msg:  Hexed("00000000000000000000000000000000"),
-			want: Hexed("5103BC5CC44BCDF0A15E160D445066FF"),
+			name:   "from server",
+			msg:    append([]byte("hello world!"), 0, 0, 0, 0),
+			decode: true,
+			want:   Hexed("CD694D45A9DECB800D569EAD29ED72F9"),
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MessageKey(testAuthKey, tt.msg, tt.decode))
+		})
+	}
+}
+
+func TestGenerateAESIGEv2(t *testing.T) {
+	tests := []struct {
+		name    string
+		msgKey  []byte
+		decode  bool
+		wantKey []byte
+		wantIV  []byte
+	}{
 		{
-			name: "randomized",
-			msg:  Hexed("5103BC5CC44BCDF0A15E160D445066FF"),
-			want: Hexed("F9D401E298F3EEEC1C927312AEB6B412"),
+			name:    "from client",
+			msgKey:  Hexed("0900776FADA995358E38DE060D514CB2"),
+			wantKey: Hexed("19F9EA19027C188B3A77DFE16D9C8EA686F5AE4AAB63A9B8ED8952E74B396CD5"),
+			wantIV:  Hexed("FC396A5AA8E1C0873046D94F7E84F2509BC9FB4278F0705D3DD82FAC2871A643"),
 		},
 		{
-			name: "any words",
-			msg:  []byte("some cool message"),
-			want: Hexed("4170ED208083FAFD2DFA8507FD4A75B6"),
+			name:    "from server",
+			msgKey:  Hexed("CD694D45A9DECB800D569EAD29ED72F9"),
+			decode:  true,
+			wantKey: Hexed("8AC2125BCE210587E0486AFE48BBF02F296E9D9875A4EFD6AE19167078C88C3B"),
+			wantIV:  Hexed("31DD32EB2C8BD91333EB372B0997953982846F649EE46CE6979010338DFF602E"),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.want, MessageKey(tt.msg))
+			key, iv := generateAESIGEv2(tt.msgKey, testAuthKey, tt.decode)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantIV, iv)
 		})
 	}
 }
 
+func TestGenerateAESIGEv1(t *testing.T) {
+	key, iv := generateAESIGEv1(Hexed("0900776FADA995358E38DE060D514CB2"), testAuthKey, false)
+	assert.Equal(t, Hexed("2772162B8BB0270D9A68DA3FF998AF67CC39F865BC9255C61B49A169E138B58F"), key)
+	assert.Equal(t, Hexed("78B10CFF35D5046AD39789A0C31FAF7B7197AED08B15647CB1336136D8ECA017"), iv)
+}
+
+func TestEncryptV1(t *testing.T) {
+	msg := []byte("some message, which is not divisible by 16")
+
+	msgKey, encrypted, err := EncryptV1(msg, testAuthKey)
+	require.NoError(t, err)
+	assert.Equal(t, dry.Sha1Byte(msg)[4:20], msgKey)
+	assert.Zero(t, len(encrypted)%aes.BlockSize)
+
+	key, iv := generateAESIGEv1(msgKey, testAuthKey, false)
+	decrypted := make([]byte, len(encrypted))
+	require.NoError(t, doAES256IGEdecrypt(encrypted, decrypted, key, iv))
+	assert.Equal(t, msg, decrypted[:len(msg)])
+}
+
 func TestEncryptMessageWithTempKeys(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -297,35 +362,20 @@ func TestEncryptMessageWithTempKeys(t *testing.T) {
 
 func TestEncryptDecrypt(t *testing.T) {
 	tests := []struct {
-		name    string
-		msg     []byte
-		key     []byte
-		want    []byte
-		wantErr assert.ErrorAssertionFunc
+		name       string
+		msg        []byte
+		wantMsgKey []byte
+		wantErr    assert.ErrorAssertionFunc
 	}{
 		{
-			name: "simple",
-			msg:  []byte("hello world!"),
-			key: Hexed("28A92FE20173B347A8BB324B5FAB2667C9A8BBCE6468D5B509A4CBDDC186240A" +
-				"C912CF7006AF8926DE606A2E74C0493CAA57741E6C82451F54D3E068F5CCC49B" +
-				"4444124B9666FFB405AAB564A3D01E67F6E912867C8D20D9882707DC330B17B4" +
-				"E0DD57CB53BFAAFA9EF5BE76AE6C1B9B6C51E2D6502A47C883095C46C81E3BE2" +
-				"5F62427B585488BB3BF239213BF48EB8FE34C9A026CC8413934043974DB03556" +
-				"633038392CECB51F94824E140B98637730A4BE79A8F9DAFA39BAE81E1095849E" +
-				"A4C83467C92A3A17D997817C8A7AC61C3FF414DA37B7D66E949C0AEC858F0482" +
-				"24210FCC61F11C3A910B431CCBD104CCCC8DC6D29D4A5D133BE639A4C32BBFF1" +
-				"53E63ACA3AC52F2E4709B8AE01844B142C1EE89D075D64F69A399FEB04E656FE" +
-				"3675A6F8F412078F3D0B58DA15311C1A9F8E53B3CD6BB5572C294904B726D0BE" +
-				"337E2E21977DA26DD6E33270251C2CA29DFCC70227F0755F84CFDA9AC4B8DD5F" +
-				"84F1D1EB36BA45CDDC70444D8C213E4BD8F63B8AB95A2D0B4180DC91283DC063" +
-				"ACFB92D6A4E407CDE7C8C69689F77A007441D4A6A8384B666502D9B77FC68B5B" +
-				"43CC607E60A146223E110FCB43BC3C942EF981930CDC4A1D310C0B64D5E55D30" +
-				"8D863251AB90502C3E46CC599E886A927CDA963B9EB16CE62603B68529EE98F9" +
-				"F5206419E03FB458EC4BD9454AA8F6BA777573CC54B328895B1DF25EAD9FB4CD" +
-				"5198EE022B2B81F388D281D5E5BC580107CA01A50665C32B552715F335FD7626" +
-				"4FAD00DDD5AE45B94832AC79CE7C511D194BC42B70EFA850BB15C2012C5215CA" +
-				"BFE97CE66B8D8734D0EE759A638AF013"),
-			want: Hexed("BABBC03F65F828E4B97DBC5A992394C2"),
+			name:       "simple",
+			msg:        append([]byte("hello world!"), 0, 0, 0, 0),
+			wantMsgKey: Hexed("CD694D45A9DECB800D569EAD29ED72F9"),
+		},
+		{
+			name:    "not_divisible_by_blocks",
+			msg:     []byte("hello world!"),
+			wantErr: assert.Error,
 		},
 	}
 	for _, tt := range tests {
@@ -334,23 +384,22 @@ func TestEncryptDecrypt(t *testing.T) {
 			if wantErr == nil {
 				wantErr = assert.NoError
 			}
-			got, err := Encrypt(tt.msg, tt.key)
-			if !wantErr(t, err) {
+			// encrypting as server, cause Decrypt works only with server messages
+			msgKey, got, err := encrypt(tt.msg, testAuthKey, true)
+			if !wantErr(t, err) || err != nil {
+				return
+			}
+
+			if !assert.Equal(t, tt.wantMsgKey, msgKey) {
 				return
 			}
 
-			if !assert.Equal(t, tt.want, got) {
+			decrypted, err := Decrypt(got, testAuthKey, msgKey)
+			if !assert.NoError(t, err) {
 				return
 			}
 
-			// TODO: почему-то расшифровка так легко не проходит
-			//msgkey := MessageKey(tt.msg)
-			//decrypted, err := Decrypt(got, tt.key, msgkey)
-			//if !wantErr(t, err) {
-			//	return
-			//}
-			//
-			//assert.Equal(t, tt.msg, decrypted)
+			assert.Equal(t, tt.msg, decrypted)
 		})
 	}
 }
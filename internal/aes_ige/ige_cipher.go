@@ -6,12 +6,14 @@
 package ige
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/sha1"
+	"crypto/sha256"
 	"fmt"
 
 	"github.com/pkg/errors"
-	"github.com/xelaj/go-dry"
 )
 
 type Cipher struct {
@@ -87,68 +89,35 @@ func isCorrectData(data []byte) error {
 
 // --------------------------------------------------------------------------------------------------
 
-// generateAESIGEv2 это переписанная функция generateAESIGE, которая выглядить чуточку более понятно.
-func generateAESIGEv2(msgKey, authKey []byte, decode bool) (aesKey, aesIv []byte) { //nolint:deadcode wait for it
-	var (
-		kvBlock  [2]AesKV
-		igeBlock [4]AesIgeBlock
-	)
-
-	aesKey = kvBlock[0][:]
-	aesIv = kvBlock[1][:]
-
-	tA := igeBlock[0][:]
-	tB := igeBlock[1][:]
-	tC := igeBlock[2][:]
-	tD := igeBlock[3][:]
-
+// generateAESIGEv1 generates aes key and iv by MTProto 1.0 rules, x is same as in generateAESIGEv2.
+// https://core.telegram.org/mtproto/description_v1#defining-aes-key-and-initialization-vector
+func generateAESIGEv1(msgKey, authKey []byte, decode bool) (aesKey, aesIv []byte) {
 	var x int
 	if decode {
 		x = 8
-	} else {
-		x = 0
 	}
 
-	var (
-		step       = 32
-		tAOffStart = x
-		tAOffEnd   = tAOffStart + step
-
-		tBOffP0Start = tAOffEnd
-		tBOffP0End   = tBOffP0Start + aes.BlockSize
-
-		tBOffP1Start = tAOffEnd + aes.BlockSize
-		tBOffP1End   = tBOffP1Start + aes.BlockSize
-
-		tCOffStart = x + 64
-		tCOffEnd   = tCOffStart + step
-
-		tDOffStart = x + 96
-		tDOffEnd   = tDOffStart + step
-	)
-
-	tA = append(tA, msgKey...)
-	tA = append(tA, authKey[tAOffStart:tAOffEnd]...)
-
-	tB = append(tB, authKey[tBOffP0Start:tBOffP0End]...)
-	tB = append(tB, msgKey...)
-	tB = append(tB, authKey[tBOffP1Start:tBOffP1End]...)
-
-	tC = append(tC, authKey[tCOffStart:tCOffEnd]...)
-	tC = append(tC, msgKey...)
-
-	tD = append(tD, msgKey...)
-	tD = append(tD, authKey[tDOffStart:tDOffEnd]...)
-
-	sha1PartA := dry.Sha1Byte(tA)
-	sha1PartB := dry.Sha1Byte(tB)
-	sha1PartC := dry.Sha1Byte(tC)
-	sha1PartD := dry.Sha1Byte(tD)
+	if len(authKey) < 96+x+32 {
+		panic(fmt.Sprintf("wrong len of auth key, got %v want at least %v", len(authKey), 96+x+32))
+	}
 
+	// sha1_a = SHA1 (msg_key + substr (auth_key, x, 32))
+	sha1PartA := sha1.Sum(bytes.Join([][]byte{msgKey, authKey[x : x+32]}, nil))
+	// sha1_b = SHA1 (substr (auth_key, 32+x, 16) + msg_key + substr (auth_key, 48+x, 16))
+	sha1PartB := sha1.Sum(bytes.Join([][]byte{authKey[32+x : 32+x+16], msgKey, authKey[48+x : 48+x+16]}, nil))
+	// sha1_c = SHA1 (substr (auth_key, 64+x, 32) + msg_key)
+	sha1PartC := sha1.Sum(bytes.Join([][]byte{authKey[64+x : 64+x+32], msgKey}, nil))
+	// sha1_d = SHA1 (msg_key + substr (auth_key, 96+x, 32))
+	sha1PartD := sha1.Sum(bytes.Join([][]byte{msgKey, authKey[96+x : 96+x+32]}, nil))
+
+	aesKey = make([]byte, 0, 32)
+	// aes_key = substr (sha1_a, 0, 8) + substr (sha1_b, 8, 12) + substr (sha1_c, 4, 12)
 	aesKey = append(aesKey, sha1PartA[0:8]...)
 	aesKey = append(aesKey, sha1PartB[8:8+12]...)
 	aesKey = append(aesKey, sha1PartC[4:4+12]...)
 
+	aesIv = make([]byte, 0, 32)
+	// aes_iv = substr (sha1_a, 8, 12) + substr (sha1_b, 0, 8) + substr (sha1_c, 16, 4) + substr (sha1_d, 0, 8)
 	aesIv = append(aesIv, sha1PartA[8:8+12]...)
 	aesIv = append(aesIv, sha1PartB[0:8]...)
 	aesIv = append(aesIv, sha1PartC[16:16+4]...)
@@ -157,53 +126,45 @@ func generateAESIGEv2(msgKey, authKey []byte, decode bool) (aesKey, aesIv []byte
 	return aesKey, aesIv
 }
 
-// generateAESIGE ЭТО ЕБАНАЯ МАГИЧЕСКАЯ ФУНКЦИЯ ОНА НАХУЙ РАБОТАЕТ ПРОСТО БЛЯТЬ НЕ ТРОГАЙ ШАКАЛ ЕБАНЫЙ
-//nolint:godox ты че ебанулся // TODO: порезать себе вены
-func generateAESIGE(msg_key, auth_key []byte, decode bool) ([]byte, []byte) {
+// generateAESIGEv2 generates aes key and iv by MTProto 2.0 rules. x is 0 for messages from client to
+// server and 8 for messages from server to client.
+// https://core.telegram.org/mtproto/description#defining-aes-key-and-initialization-vector
+func generateAESIGEv2(msgKey, authKey []byte, decode bool) (aesKey, aesIv []byte) {
 	var x int
 	if decode {
 		x = 8
-	} else {
-		x = 0
 	}
 
-	if len(auth_key) < 96+x+32 {
-		panic(fmt.Sprintf("wrong len of auth key, got %v want at least %v", len(auth_key), 96+x+32))
+	if len(authKey) < 40+x+36 {
+		panic(fmt.Sprintf("wrong len of auth key, got %v want at least %v", len(authKey), 40+x+36))
 	}
 
-	aes_key := make([]byte, 0, 32)
-	aes_iv := make([]byte, 0, 32)
-	t_a := make([]byte, 0, 48)
-	t_b := make([]byte, 0, 48)
-	t_c := make([]byte, 0, 48)
-	t_d := make([]byte, 0, 48)
-
-	t_a = append(t_a, msg_key...)
-	t_a = append(t_a, auth_key[x:x+32]...)
-
-	t_b = append(t_b, auth_key[32+x:32+x+16]...)
-	t_b = append(t_b, msg_key...)
-	t_b = append(t_b, auth_key[48+x:48+x+16]...)
+	var (
+		step = 36
 
-	t_c = append(t_c, auth_key[64+x:64+x+32]...)
-	t_c = append(t_c, msg_key...)
+		tAOffStart = x
+		tAOffEnd   = tAOffStart + step
 
-	t_d = append(t_d, msg_key...)
-	t_d = append(t_d, auth_key[96+x:96+x+32]...)
+		tBOffStart = x + 40
+		tBOffEnd   = tBOffStart + step
+	)
 
-	sha1_a := dry.Sha1Byte(t_a)
-	sha1_b := dry.Sha1Byte(t_b)
-	sha1_c := dry.Sha1Byte(t_c)
-	sha1_d := dry.Sha1Byte(t_d)
+	// sha256_a = SHA256 (msg_key + substr (auth_key, x, 36))
+	sha256PartA := sha256.Sum256(bytes.Join([][]byte{msgKey, authKey[tAOffStart:tAOffEnd]}, nil))
+	// sha256_b = SHA256 (substr (auth_key, 40+x, 36) + msg_key)
+	sha256PartB := sha256.Sum256(bytes.Join([][]byte{authKey[tBOffStart:tBOffEnd], msgKey}, nil))
 
-	aes_key = append(aes_key, sha1_a[0:8]...)
-	aes_key = append(aes_key, sha1_b[8:8+12]...)
-	aes_key = append(aes_key, sha1_c[4:4+12]...)
+	aesKey = make([]byte, 0, 32)
+	// aes_key = substr (sha256_a, 0, 8) + substr (sha256_b, 8, 16) + substr (sha256_a, 24, 8)
+	aesKey = append(aesKey, sha256PartA[0:8]...)
+	aesKey = append(aesKey, sha256PartB[8:8+16]...)
+	aesKey = append(aesKey, sha256PartA[24:24+8]...)
 
-	aes_iv = append(aes_iv, sha1_a[8:8+12]...)
-	aes_iv = append(aes_iv, sha1_b[0:8]...)
-	aes_iv = append(aes_iv, sha1_c[16:16+4]...)
-	aes_iv = append(aes_iv, sha1_d[0:8]...)
+	aesIv = make([]byte, 0, 32)
+	// aes_iv = substr (sha256_b, 0, 8) + substr (sha256_a, 8, 16) + substr (sha256_b, 24, 8)
+	aesIv = append(aesIv, sha256PartB[0:8]...)
+	aesIv = append(aesIv, sha256PartA[8:8+16]...)
+	aesIv = append(aesIv, sha256PartB[24:24+8]...)
 
-	return aes_key, aes_iv
+	return aesKey, aesIv
 }
@@ -28,6 +28,11 @@ func (g *Generator) generateMethods(f *jen.File) {
 		}
 		f.Add(g.generateMethodFunction(&method))
 		f.Line()
+		if method.Comment != "" {
+			f.Comment(method.Comment)
+		}
+		f.Add(g.generateMethodContextFunction(&method))
+		f.Line()
 	}
 
 	//	sort.Strings(keys)
@@ -47,21 +52,19 @@ func (g *Generator) generateMethods(f *jen.File) {
 }
 
 func (g *Generator) generateMethodFunction(obj *tlparser.Method) jen.Code {
-	resp := g.typeIdFromSchemaType(obj.Response.Type)
-	if obj.Response.IsList {
-		resp = jen.Index().Add(resp)
-	}
+	//*	return c.AuthSendCodeContext(context.Background(), phoneNumber, apiID, apiHash, settings)
+	args := []jen.Code{jen.Qual("context", "Background").Call()}
+	args = append(args, g.generateArgumentNamesForMethod(obj)...)
 
-	// еще одно злоебучее исключение. проблема в том, что bool это вот как бы и объект, да вот как бы и нет
-	// трабла только в том, что нельзя просто так взять, и получить bool из MakeRequest. так что
-	// возвращаем tl.Bool
-	if obj.Response.Type == "Bool" {
-		resp = jen.Op("*").Qual(tlPackagePath, "PseudoBool")
-	}
+	return jen.Func().Params(jen.Id("c").Op("*").Id("Client")).Id(goify(obj.Name, true)).Params(g.generateArgumentsForMethod(obj)...).Params(g.generateMethodResponses(obj)...).Block(
+		jen.Return(jen.Id("c").Dot(goify(obj.Name, true) + "Context").Call(args...)),
+	)
+}
 
-	responses := []jen.Code{resp, jen.Error()}
+func (g *Generator) generateMethodContextFunction(obj *tlparser.Method) jen.Code {
+	resp := g.generateMethodResponses(obj)[0]
 
-	//*	data, err := c.MakeRequest(params)
+	//*	data, err := c.MakeRequestContext(ctx, params)
 	//*	if err != nil {
 	//*		return nil, errors.Wrap(err, "sedning AuthSendCode")
 	//*	}
@@ -72,8 +75,9 @@ func (g *Generator) generateMethodFunction(obj *tlparser.Method) jen.Code {
 	//*	}
 	//*
 	//*	return resp, nil
-	method := jen.Func().Params(jen.Id("c").Op("*").Id("Client")).Id(goify(obj.Name, true)).Params(g.generateArgumentsForMethod(obj)...).Params(responses...).Block(
-		jen.List(jen.Id("responseData"), jen.Id("err")).Op(":=").Id("c").Dot("MakeRequest").Call(g.generateMethodArgumentForMakingRequest(obj)),
+	arguments := append([]jen.Code{jen.Id("ctx").Qual("context", "Context")}, g.generateArgumentsForMethod(obj)...)
+	method := jen.Func().Params(jen.Id("c").Op("*").Id("Client")).Id(goify(obj.Name, true)+"Context").Params(arguments...).Params(g.generateMethodResponses(obj)...).Block(
+		jen.List(jen.Id("responseData"), jen.Id("err")).Op(":=").Id("c").Dot("MakeRequestContext").Call(jen.Id("ctx"), g.generateMethodArgumentForMakingRequest(obj)),
 		jen.If(jen.Err().Op("!=").Nil()).Block(
 			jen.Return(jen.Nil(), jen.Qual(errorsPackagePath, "Wrap").Call(jen.Err(), jen.Lit("sending "+goify(obj.Name, true)))),
 		),
@@ -88,6 +92,22 @@ func (g *Generator) generateMethodFunction(obj *tlparser.Method) jen.Code {
 	return method
 }
 
+func (g *Generator) generateMethodResponses(obj *tlparser.Method) []jen.Code {
+	resp := g.typeIdFromSchemaType(obj.Response.Type)
+	if obj.Response.IsList {
+		resp = jen.Index().Add(resp)
+	}
+
+	// еще одно злоебучее исключение. проблема в том, что bool это вот как бы и объект, да вот как бы и нет
+	// трабла только в том, что нельзя просто так взять, и получить bool из MakeRequest. так что
+	// возвращаем tl.Bool
+	if obj.Response.Type == "Bool" {
+		resp = jen.Op("*").Qual(tlPackagePath, "PseudoBool")
+	}
+
+	return []jen.Code{resp, jen.Error()}
+}
+
 func (g *Generator) generateArgumentsForMethod(obj *tlparser.Method) []jen.Code {
 	if len(obj.Parameters) == 0 {
 		return []jen.Code{}
@@ -117,6 +137,22 @@ func (g *Generator) generateArgumentsForMethod(obj *tlparser.Method) []jen.Code
 	return items
 }
 
+func (g *Generator) generateArgumentNamesForMethod(obj *tlparser.Method) []jen.Code {
+	if len(obj.Parameters) > maximumPositionalArguments {
+		return []jen.Code{jen.Id("params")}
+	}
+
+	items := make([]jen.Code, 0)
+	for _, p := range obj.Parameters {
+		if p.Type == "bitflags" {
+			continue // ну а зачем?
+		}
+
+		items = append(items, jen.Id(goify(p.Name, false)))
+	}
+	return items
+}
+
 func (g *Generator) generateMethodArgumentForMakingRequest(obj *tlparser.Method) *jen.Statement {
 	if len(obj.Parameters) > maximumPositionalArguments {
 		return jen.Id("params")
@@ -48,3 +48,9 @@ func RegisterEnums(enums ...Object) {
 		registerEnum(e)
 	}
 }
+
+// IsRegistered returns true, if some type is already registered with crc of o.
+func IsRegistered(o Object) bool {
+	_, found := objectByCrc[o.CRC()]
+	return found
+}
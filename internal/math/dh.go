@@ -0,0 +1,105 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+//nolint:gochecknoglobals using it just for simplification and more readable
+package math
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+)
+
+// checks of diffie-hellman parameters, which are required by protocol.
+// https://core.telegram.org/mtproto/auth_key#presenting-proof-of-work-server-authentication
+// https://github.com/tdlib/td/blob/master/td/mtproto/DhHandshake.cpp
+
+const (
+	dhPrimeBits = 2048
+	// g_a and g_b must be between 2^(2048-64) and dh_prime - 2^(2048-64)
+	dhSafetyMarginBits = dhPrimeBits - 64
+	// rounds of Miller-Rabin test (Baillie-PSW is applied too)
+	primalityRounds = 20
+)
+
+var (
+	ErrDHPrimeSize    = errors.New("dh_prime must be 2048-bit number")
+	ErrDHPrimeNotSafe = errors.New("dh_prime is not a safe prime")
+	ErrDHBadGenerator = errors.New("g doesn't generate cyclic subgroup of prime order (p-1)/2")
+	ErrDHValueRange   = errors.New("value is out of safe range")
+
+	dhSafetyMargin = new(big.Int).Lsh(big1, dhSafetyMarginBits)
+
+	// checking primality is slow, and server sends same prime almost every time
+	checkedPrimes sync.Map // string -> struct{}
+)
+
+// CheckDHParams checks that dh_prime is safe 2048-bit prime, and g generates cyclic subgroup of prime
+// order (p-1)/2, i.e. g is quadratic residue mod p.
+func CheckDHParams(g int32, dhPrime *big.Int) error {
+	if dhPrime.BitLen() != dhPrimeBits {
+		return ErrDHPrimeSize
+	}
+
+	if !checkGenerator(g, dhPrime) {
+		return ErrDHBadGenerator
+	}
+
+	key := string(dhPrime.Bytes())
+	if _, ok := checkedPrimes.Load(key); ok {
+		return nil
+	}
+
+	// (p-1)/2 must be prime too
+	if !dhPrime.ProbablyPrime(primalityRounds) || !new(big.Int).Rsh(dhPrime, 1).ProbablyPrime(primalityRounds) {
+		return ErrDHPrimeNotSafe
+	}
+
+	checkedPrimes.Store(key, struct{}{})
+	return nil
+}
+
+// checkGenerator checks, that g is quadratic residue mod p, by quadratic reciprocity law.
+func checkGenerator(g int32, p *big.Int) bool {
+	mod := func(m int64) int64 {
+		return new(big.Int).Mod(p, big.NewInt(m)).Int64()
+	}
+
+	switch g {
+	case 2: //nolint:gomnd not magic
+		return mod(8) == 7
+	case 3: //nolint:gomnd not magic
+		return mod(3) == 2
+	case 4: //nolint:gomnd not magic
+		return true
+	case 5: //nolint:gomnd not magic
+		r := mod(5)
+		return r == 1 || r == 4
+	case 6: //nolint:gomnd not magic
+		r := mod(24)
+		return r == 19 || r == 23
+	case 7: //nolint:gomnd not magic
+		r := mod(7)
+		return r == 3 || r == 5 || r == 6
+	default:
+		return false
+	}
+}
+
+// CheckDHValue checks that g_a or g_b is in range 1 < x < dh_prime - 1, and also far enough from bounds:
+// 2^(2048-64) <= x <= dh_prime - 2^(2048-64)
+func CheckDHValue(x, dhPrime *big.Int) error {
+	upper := new(big.Int).Sub(dhPrime, big1)
+	if x.Cmp(big1) <= 0 || x.Cmp(upper) >= 0 {
+		return ErrDHValueRange
+	}
+
+	upper.Sub(dhPrime, dhSafetyMargin)
+	if x.Cmp(dhSafetyMargin) < 0 || x.Cmp(upper) > 0 {
+		return ErrDHValueRange
+	}
+
+	return nil
+}
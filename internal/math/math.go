@@ -7,6 +7,7 @@
 package math
 
 import (
+	crand "crypto/rand"
 	"crypto/rsa"
 	"math"
 	"math/big"
@@ -117,14 +118,24 @@ func SplitPQ(pq *big.Int) (p1, p2 *big.Int) {
 	return p1, p2
 }
 
-func MakeGAB(g int32, g_a, dh_prime *big.Int) (b, g_b, g_ab *big.Int) {
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint: gosec зачем
+// MakeGAB generates secret b and calculates g_b and g_ab. b is generated again, until g_b is in safe range
+// (see CheckDHValue).
+func MakeGAB(g int32, g_a, dh_prime *big.Int) (b, g_b, g_ab *big.Int, err error) {
 	rndmax := big.NewInt(0).SetBit(big.NewInt(0), 2048, 1)
-	b = big.NewInt(0).Rand(rnd, rndmax)
-	g_b = big.NewInt(0).Exp(big.NewInt(int64(g)), b, dh_prime)
+	for {
+		b, err = crand.Int(crand.Reader, rndmax)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		g_b = big.NewInt(0).Exp(big.NewInt(int64(g)), b, dh_prime)
+		if CheckDHValue(g_b, dh_prime) == nil {
+			break
+		}
+	}
 	g_ab = big.NewInt(0).Exp(g_a, b, dh_prime)
 
-	return
+	return b, g_b, g_ab, nil
 }
 
 func Xor(dst, src []byte) {
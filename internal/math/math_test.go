@@ -6,9 +6,19 @@
 package math_test
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
 	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xelaj/go-dry"
+
+	ige "github.com/umesproject/mtproto/internal/aes_ige"
 	"github.com/umesproject/mtproto/internal/math"
 )
 
@@ -28,3 +38,114 @@ func TestSplitPQ(t *testing.T) {
 		}
 	}
 }
+
+// dh_prime, which is sent by telegram servers
+var telegramDHPrime, _ = new(big.Int).SetString("C71CAEB9C6B1C9048E6C522F70F13F73980D40238E3E21C14934D037563D930F"+
+	"48198A0AA7C14058229493D22530F4DBFA336F6E0AC925139543AED44CCE7C3720FD51F69458705AC68CD4FE6B6B13AB"+
+	"DC9746512969328454F18FAF8C595F642477FE96BB2A941D5BCD1D4AC8CC49880708FA9B378E3C4F3A9060BEE67CF9A4"+
+	"A4A695811051907E162753B56B0F6B410DBA74D8A84B2A14B3144E0EF1284754FD17ED950D5965B4B9DD46582DB1178D"+
+	"169C6BC465B0D6FF9CA3928FEF5B9AE4E418FC15E83EBEA0F87FA9FF5EED70050DED2849F47BF959D956850CE929851F"+
+	"0D8115F635B105EE2E4E15D04B2454BF6F4FADF034B10403119CD8E3B92FCC5B", 16)
+
+func TestCheckDHParams(t *testing.T) {
+	notPrime := new(big.Int).Add(telegramDHPrime, big.NewInt(2))
+
+	cases := []struct {
+		name  string
+		g     int32
+		prime *big.Int
+		want  error
+	}{
+		{"telegram prime", 3, telegramDHPrime, nil},
+		{"always residue", 4, telegramDHPrime, nil},
+		{"not a residue", 2, telegramDHPrime, math.ErrDHBadGenerator},
+		{"unknown generator", 8, telegramDHPrime, math.ErrDHBadGenerator},
+		{"small prime", 3, big.NewInt(23), math.ErrDHPrimeSize},
+		{"not prime", 4, notPrime, math.ErrDHPrimeNotSafe},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, math.CheckDHParams(c.g, c.prime))
+		})
+	}
+}
+
+func TestCheckDHValue(t *testing.T) {
+	margin := new(big.Int).Lsh(big.NewInt(1), 2048-64)
+
+	cases := []struct {
+		name  string
+		value *big.Int
+		ok    bool
+	}{
+		{"one", big.NewInt(1), false},
+		{"too small", new(big.Int).Sub(margin, big.NewInt(1)), false},
+		{"lower bound", margin, true},
+		{"middle", new(big.Int).Rsh(telegramDHPrime, 1), true},
+		{"upper bound", new(big.Int).Sub(telegramDHPrime, margin), true},
+		{"too big", new(big.Int).Sub(telegramDHPrime, big.NewInt(2)), false},
+		{"prime itself", telegramDHPrime, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := math.CheckDHValue(c.value, telegramDHPrime)
+			if c.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, math.ErrDHValueRange, err)
+			}
+		})
+	}
+}
+
+func TestMakeGAB(t *testing.T) {
+	gA := big.NewInt(0).Exp(big.NewInt(3), big.NewInt(0).Lsh(big.NewInt(1), 2000), telegramDHPrime)
+
+	b, gB, gAB, err := math.MakeGAB(3, gA, telegramDHPrime)
+	require.NoError(t, err)
+	assert.NoError(t, math.CheckDHValue(gB, telegramDHPrime))
+	assert.Equal(t, big.NewInt(0).Exp(gA, b, telegramDHPrime), gAB)
+}
+
+// decryptRSAPad does the same, what server does with encrypted_data
+func decryptRSAPad(t *testing.T, encrypted []byte, key *rsa.PrivateKey) []byte {
+	t.Helper()
+	c := big.NewInt(0).SetBytes(encrypted)
+	keyAESEncrypted := dry.BigIntBytes(big.NewInt(0).Exp(c, key.D, key.N), 2048)
+
+	tempKeyXor, aesEncrypted := keyAESEncrypted[:32], keyAESEncrypted[32:]
+	aesHash := sha256.Sum256(aesEncrypted)
+	tempKey := dry.BytesXor(tempKeyXor, aesHash[:])
+
+	dataWithHash, err := ige.DecryptAES256IGE(aesEncrypted, tempKey, make([]byte, 32))
+	require.NoError(t, err)
+
+	dataWithPadding := make([]byte, 192)
+	for i := range dataWithPadding {
+		dataWithPadding[i] = dataWithHash[191-i]
+	}
+	hash := sha256.Sum256(append(append([]byte{}, tempKey...), dataWithPadding...))
+	require.Equal(t, hash[:], dataWithHash[192:], "hash mismatch")
+
+	return dataWithPadding
+}
+
+func TestDoRSAPadEncrypt(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	data := bytes.Repeat([]byte{0xab, 0xcd}, 70)
+	for i := 0; i < 4; i++ {
+		encrypted, err := math.DoRSAPadEncrypt(data, &key.PublicKey)
+		require.NoError(t, err)
+		require.Len(t, encrypted, 256)
+
+		decrypted := decryptRSAPad(t, encrypted, key)
+		assert.Equal(t, data, decrypted[:len(data)])
+	}
+
+	_, err = math.DoRSAPadEncrypt(make([]byte, 145), &key.PublicKey)
+	assert.Error(t, err)
+}
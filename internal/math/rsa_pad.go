@@ -0,0 +1,75 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package math
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/xelaj/go-dry"
+
+	ige "github.com/umesproject/mtproto/internal/aes_ige"
+)
+
+const (
+	rsaPadDataLen = 192 // data_with_padding size
+	rsaPadKeyLen  = 32  // temp_key size
+	rsaBlockLen   = 256 // size of 2048 bit rsa block
+)
+
+// rsaPadMaxTries limits attempts to generate key_aes_encrypted, which is less than key modulus. Each
+// attempt fails with probability less than 1/2, so it's practically unreachable.
+const rsaPadMaxTries = 64
+
+// DoRSAPadEncrypt encrypts data (inner data of req_DH_params, up to 144 bytes) by RSA_PAD scheme:
+// data is padded to 192 bytes, reversed and encrypted by AES-256-IGE with random temporary key
+// (it's integrity protected by SHA256), then temp key is xored with SHA256 of encrypted data, and
+// the whole 256 byte block is encrypted by rsa public key.
+// https://core.telegram.org/mtproto/auth_key#41-rsa-paddata-server-public-key-mentioned-above-is-implemented-as-follows
+func DoRSAPadEncrypt(data []byte, key *rsa.PublicKey) ([]byte, error) {
+	const maxDataLen = 144
+	if len(data) > maxDataLen {
+		return nil, errors.Errorf("data is too long: %v bytes, max %v", len(data), maxDataLen)
+	}
+
+	dataWithPadding := make([]byte, rsaPadDataLen)
+	copy(dataWithPadding, data)
+	copy(dataWithPadding[len(data):], dry.RandomBytes(rsaPadDataLen-len(data)))
+
+	dataPadReversed := make([]byte, rsaPadDataLen)
+	for i := range dataWithPadding {
+		dataPadReversed[i] = dataWithPadding[rsaPadDataLen-1-i]
+	}
+
+	for i := 0; i < rsaPadMaxTries; i++ {
+		tempKey := dry.RandomBytes(rsaPadKeyLen)
+
+		hash := sha256.Sum256(bytes.Join([][]byte{tempKey, dataWithPadding}, nil))
+		dataWithHash := bytes.Join([][]byte{dataPadReversed, hash[:]}, nil)
+
+		aesEncrypted, err := ige.EncryptAES256IGE(dataWithHash, tempKey, make([]byte, rsaPadKeyLen))
+		if err != nil {
+			return nil, errors.Wrap(err, "encrypting data with temp key")
+		}
+
+		aesHash := sha256.Sum256(aesEncrypted)
+		tempKeyXor := dry.BytesXor(tempKey, aesHash[:])
+
+		keyAESEncrypted := big.NewInt(0).SetBytes(bytes.Join([][]byte{tempKeyXor, aesEncrypted}, nil))
+		if keyAESEncrypted.Cmp(key.N) >= 0 {
+			// value must be less than modulus, otherwise server can't decrypt it, so we just retry
+			continue
+		}
+
+		c := big.NewInt(0).Exp(keyAESEncrypted, big.NewInt(int64(key.E)), key.N)
+		return dry.BigIntBytes(c, rsaBlockLen*8), nil //nolint:gomnd bits in byte
+	}
+
+	return nil, errors.New("can't generate rsa padded data less than key modulus")
+}
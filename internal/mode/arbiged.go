@@ -83,6 +83,9 @@ func (m *abridged) ReadMsg() ([]byte, error) {
 	}
 
 	size *= tl.WordLen
+	if err := checkReadSize(size); err != nil {
+		return nil, err
+	}
 
 	msg := make([]byte, size)
 
@@ -31,3 +31,44 @@ func checkMsgSize(msg []byte) error {
 	}
 	return nil
 }
+
+// ErrWrongChecksum returned by full mode, when crc32 of packet doesn't match, e.g. stream is corrupted.
+type ErrWrongChecksum struct {
+	Expected uint32
+	Got      uint32
+}
+
+func (e *ErrWrongChecksum) Error() string {
+	return fmt.Sprintf("wrong crc32 of packet: expected 0x%08x, got 0x%08x", e.Expected, e.Got)
+}
+
+// ErrWrongSeqNo returned by full mode, when packet was lost or reordered.
+type ErrWrongSeqNo struct {
+	Expected uint32
+	Got      uint32
+}
+
+func (e *ErrWrongSeqNo) Error() string {
+	return fmt.Sprintf("wrong sequence number of packet: expected %d, got %d", e.Expected, e.Got)
+}
+
+// MaxMsgSize is a biggest size of packet, which could be received from server: 1 MB of message with headers
+// of encrypted message and transport padding.
+const MaxMsgSize = 1024*1024 + 1024
+
+// ErrMsgTooBig returned, when size of packet, which was read from connection, is bigger than any mtproto
+// message could be. Stream is corrupted (or is sent by hostile proxy), so memory for it is not allocated.
+type ErrMsgTooBig struct {
+	Size int
+}
+
+func (e *ErrMsgTooBig) Error() string {
+	return fmt.Sprintf("packet size %d is bigger than maximum %d", e.Size, MaxMsgSize)
+}
+
+func checkReadSize(size int) error {
+	if size > MaxMsgSize {
+		return &ErrMsgTooBig{Size: size}
+	}
+	return nil
+}
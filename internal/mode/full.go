@@ -0,0 +1,85 @@
+package mode
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+)
+
+// full is the only mode without announcement. Every packet has its length, sequence number and crc32
+// checksum, so corrupted or reordered stream is detected.
+// https://core.telegram.org/mtproto/mtproto-transports#full
+type full struct {
+	conn io.ReadWriter
+	// reader is used for reading instead of conn, cause Detect could already read first bytes of packet
+	reader io.Reader
+
+	writeSeqNo uint32
+	readSeqNo  uint32
+}
+
+var _ Mode = (*full)(nil)
+
+// length, seqno and crc32
+const fullModeOverhead = tl.WordLen * 3
+
+func (*full) getModeAnnouncement() []byte {
+	return nil
+}
+
+func (m *full) WriteMsg(msg []byte) error {
+	if err := checkMsgSize(msg); err != nil {
+		return err
+	}
+
+	buf := make([]byte, tl.WordLen*2, len(msg)+fullModeOverhead)
+	binary.LittleEndian.PutUint32(buf, uint32(len(msg)+fullModeOverhead))
+	binary.LittleEndian.PutUint32(buf[tl.WordLen:], m.writeSeqNo)
+	buf = append(buf, msg...)
+	buf = append(buf, make([]byte, tl.WordLen)...)
+	binary.LittleEndian.PutUint32(buf[len(buf)-tl.WordLen:], crc32.ChecksumIEEE(buf[:len(buf)-tl.WordLen]))
+
+	if _, err := m.conn.Write(buf); err != nil {
+		return err
+	}
+	m.writeSeqNo++
+
+	return nil
+}
+
+func (m *full) ReadMsg() ([]byte, error) {
+	sizeBuf := make([]byte, tl.WordLen)
+	if _, err := io.ReadFull(m.reader, sizeBuf); err != nil {
+		return nil, err
+	}
+
+	size := int(binary.LittleEndian.Uint32(sizeBuf))
+	if size < fullModeOverhead {
+		return nil, fmt.Errorf("packet size %d is less than minimal %d", size, fullModeOverhead)
+	}
+	if err := checkReadSize(size); err != nil {
+		return nil, err
+	}
+
+	packet := make([]byte, size)
+	copy(packet, sizeBuf)
+	if _, err := io.ReadFull(m.reader, packet[tl.WordLen:]); err != nil {
+		return nil, err
+	}
+
+	crc := binary.LittleEndian.Uint32(packet[size-tl.WordLen:])
+	if expected := crc32.ChecksumIEEE(packet[:size-tl.WordLen]); crc != expected {
+		return nil, &ErrWrongChecksum{Expected: expected, Got: crc}
+	}
+
+	seqNo := binary.LittleEndian.Uint32(packet[tl.WordLen:])
+	if seqNo != m.readSeqNo {
+		return nil, &ErrWrongSeqNo{Expected: m.readSeqNo, Got: seqNo}
+	}
+	m.readSeqNo++
+
+	return packet[tl.WordLen*2 : size-tl.WordLen], nil
+}
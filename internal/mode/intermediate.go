@@ -44,6 +44,10 @@ func (m *intermediate) ReadMsg() ([]byte, error) {
 	}
 
 	size := binary.LittleEndian.Uint32(sizeBuf)
+	if err := checkReadSize(int(size)); err != nil {
+		return nil, err
+	}
+
 	msg := make([]byte, int(size))
 	n, err = m.conn.Read(msg)
 	if err != nil {
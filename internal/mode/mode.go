@@ -2,9 +2,11 @@ package mode
 
 import (
 	"bytes"
+	"encoding/binary"
 	"io"
 
 	"github.com/pkg/errors"
+	"github.com/umesproject/mtproto/internal/encoding/tl"
 )
 
 // Mode is an interface which handles many ways as the connection sides must determine the size of the
@@ -39,23 +41,51 @@ func New(v Variant, conn io.ReadWriter) (Mode, error) {
 	if err != nil {
 		return nil, err
 	}
-	announcement := m.getModeAnnouncement()
-	_, err = conn.Write(announcement)
-	if err != nil {
-		return nil, errors.Wrap(err, "can't setup connection")
+	// full mode has no announcement
+	if announcement := m.getModeAnnouncement(); len(announcement) > 0 {
+		_, err = conn.Write(announcement)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't setup connection")
+		}
 	}
 
 	return m, nil
 }
 
+// Wrap creates mode without sending announcement to other side. It's useful for obfuscated connections,
+// where mode tag is sent inside of init header (see Tag).
+func Wrap(v Variant, conn io.ReadWriter) (Mode, error) {
+	if conn == nil {
+		return nil, ErrInterfaceIsNil
+	}
+
+	return initMode(v, conn)
+}
+
+// Tag returns 4 byte mode tag, which is used in obfuscated init header instead of plain announcement.
+func Tag(v Variant) ([4]byte, error) {
+	switch v {
+	case Abridged:
+		return [4]byte{0xef, 0xef, 0xef, 0xef}, nil
+	case Intermediate:
+		return transportModeIntermediate, nil
+	case PaddedIntermediate:
+		return transportModePaddedIntermediate, nil
+	default:
+		return [4]byte{}, ErrModeNotSupported
+	}
+}
+
 func initMode(v Variant, conn io.ReadWriter) (Mode, error) {
 	switch v {
-	case PaddedIntermediate, Full:
-		panic("not supported yet")
+	case Full:
+		return &full{conn: conn, reader: conn}, nil
 	case Abridged:
 		return &abridged{conn: conn}, nil
 	case Intermediate:
 		return &intermediate{conn: conn}, nil
+	case PaddedIntermediate:
+		return &paddedIntermediate{conn: conn}, nil
 	default:
 		return nil, ErrModeNotSupported
 	}
@@ -67,31 +97,40 @@ func Detect(conn io.ReadWriter) (Mode, error) {
 		return nil, ErrInterfaceIsNil
 	}
 	b := []byte{0x0}
-	_, err := conn.Read(b)
+	_, err := io.ReadFull(conn, b)
 	if err != nil {
 		return nil, err
 	}
 
-	var detectedMode Variant
-	switch b[0] {
-	case transportModeAbridged[0]:
-		detectedMode = Abridged
-	case transportModeIntermediate[0]:
-		modeAnnounce := make([]byte, 4)
-		copy(modeAnnounce, b)
-		_, err = conn.Read(modeAnnounce[1:])
-		if err != nil {
-			return nil, err
-		}
-		if !bytes.Equal(modeAnnounce, transportModeIntermediate[:]) {
-			return nil, ErrAmbiguousModeAnnounce
-		}
-		detectedMode = Intermediate
-	default:
-		return nil, ErrModeNotSupported
+	if b[0] == transportModeAbridged[0] {
+		return initMode(Abridged, conn)
 	}
 
-	return initMode(detectedMode, conn)
+	head := make([]byte, tl.WordLen)
+	copy(head, b)
+	_, err = io.ReadFull(conn, head[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case bytes.Equal(head, transportModeIntermediate[:]):
+		return initMode(Intermediate, conn)
+	case bytes.Equal(head, transportModePaddedIntermediate[:]):
+		return initMode(PaddedIntermediate, conn)
+	case b[0] == transportModeIntermediate[0] || b[0] == transportModePaddedIntermediate[0]:
+		return nil, ErrAmbiguousModeAnnounce
+	default:
+		// full mode has no announcement, so it's a length of first packet. length is always multiple of 4,
+		// so it can't be confused with announcements of other modes.
+		if binary.LittleEndian.Uint32(head)%tl.WordLen != 0 {
+			return nil, ErrModeNotSupported
+		}
+		return &full{
+			conn:   conn,
+			reader: io.MultiReader(bytes.NewReader(head), conn),
+		}, nil
+	}
 }
 
 func GetVariant(m Mode) (Variant, error) {
@@ -100,6 +139,10 @@ func GetVariant(m Mode) (Variant, error) {
 		return Abridged, nil
 	case *intermediate:
 		return Intermediate, nil
+	case *paddedIntermediate:
+		return PaddedIntermediate, nil
+	case *full:
+		return Full, nil
 	default:
 		return Variant(0xff), errors.New("using custom mode, cant't detect")
 	}
@@ -38,6 +38,16 @@ func TestModeEncode(t *testing.T) {
 				0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
 			},
 		},
+		{
+			name: "full, with checksum",
+			in:   []byte("test message"),
+			mode: mode.Full,
+			expect: []byte{
+				0x18, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x74, 0x65, 0x73, 0x74, 0x20, 0x6d, 0x65, 0x73,
+				0x73, 0x61, 0x67, 0x65, 0xfe, 0x47, 0x91, 0x16,
+			},
+		},
 		{
 			name: "arbiged, but huge message",
 			in:   randomBigByteset,
@@ -89,6 +99,26 @@ func TestModeDecode(t *testing.T) {
 			mode:   mode.Abridged,
 			expect: []byte("test message"),
 		},
+		{
+			name: "full, with checksum",
+			in: []byte{
+				0x18, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x74, 0x65, 0x73, 0x74, 0x20, 0x6d, 0x65, 0x73,
+				0x73, 0x61, 0x67, 0x65, 0xfe, 0x47, 0x91, 0x16,
+			},
+			mode:   mode.Full,
+			expect: []byte("test message"),
+		},
+		{
+			name: "padded intermediate, without padding",
+			in: []byte{
+				0xdd, 0xdd, 0xdd, 0xdd, 0x0c, 0x00, 0x00, 0x00,
+				0x74, 0x65, 0x73, 0x74, 0x20, 0x6d, 0x65, 0x73,
+				0x73, 0x61, 0x67, 0x65,
+			},
+			mode:   mode.PaddedIntermediate,
+			expect: []byte("test message"),
+		},
 		{
 			name: "arbiged, but huge message",
 			in: append([]byte{
@@ -113,3 +143,72 @@ func TestModeDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestPaddedIntermediate(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	m, err := mode.New(mode.PaddedIntermediate, buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xdd, 0xdd, 0xdd, 0xdd}, buf.Next(4))
+
+	msg := []byte("test message")
+	for i := 0; i < 10; i++ {
+		require.NoError(t, m.WriteMsg(msg))
+
+		got, err := m.ReadMsg()
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(got)-len(msg), 15)
+		require.Equal(t, msg, got[:len(msg)])
+	}
+}
+
+func TestReadMsgTooBig(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		variant mode.Variant
+		size    []byte
+	}{
+		// 2 GB packets
+		{"intermediate", mode.Intermediate, []byte{0x00, 0x00, 0x00, 0x80}},
+		{"padded intermediate", mode.PaddedIntermediate, []byte{0x00, 0x00, 0x00, 0x80}},
+		{"full", mode.Full, []byte{0x00, 0x00, 0x00, 0x80}},
+		{"abridged", mode.Abridged, []byte{0x7f, 0x00, 0x00, 0x20}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+
+			m, err := mode.New(tt.variant, buf)
+			require.NoError(t, err)
+			buf.Reset()
+
+			buf.Write(tt.size)
+			_, err = m.ReadMsg()
+			require.IsType(t, &mode.ErrMsgTooBig{}, err)
+		})
+	}
+}
+
+func TestFullCorrupted(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	m, err := mode.New(mode.Full, buf)
+	require.NoError(t, err)
+	require.NoError(t, m.WriteMsg([]byte("test message")))
+	require.NoError(t, m.WriteMsg([]byte("next message")))
+
+	first := append([]byte{}, buf.Next(24)...)
+	second := append([]byte{}, buf.Next(24)...)
+
+	// reordered packets
+	buf.Write(second)
+	_, err = m.ReadMsg()
+	require.IsType(t, &mode.ErrWrongSeqNo{}, err)
+
+	// corrupted packet
+	m, err = mode.New(mode.Full, buf)
+	require.NoError(t, err)
+	first[10] ^= 0xff
+	buf.Write(first)
+	_, err = m.ReadMsg()
+	require.IsType(t, &mode.ErrWrongChecksum{}, err)
+}
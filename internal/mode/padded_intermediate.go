@@ -0,0 +1,64 @@
+package mode
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	mathrand "math/rand"
+
+	"github.com/pkg/errors"
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+)
+
+// paddedIntermediate is same as intermediate, but adds 0-15 random bytes to every message, so
+// packet sizes doesn't reveal mtproto traffic. Used by obfuscated connections through MTProxy.
+// https://core.telegram.org/mtproto/mtproto-transports#padded-intermediate
+type paddedIntermediate struct {
+	conn io.ReadWriter
+}
+
+var _ Mode = (*paddedIntermediate)(nil)
+
+var transportModePaddedIntermediate = [...]byte{0xdd, 0xdd, 0xdd, 0xdd} // meta:immutable
+
+const maxPaddingLen = 15
+
+func (*paddedIntermediate) getModeAnnouncement() []byte {
+	return transportModePaddedIntermediate[:]
+}
+
+func (m *paddedIntermediate) WriteMsg(msg []byte) error {
+	padding := make([]byte, mathrand.Intn(maxPaddingLen+1)) //nolint:gosec padding is not secret
+	if _, err := rand.Read(padding); err != nil {
+		return errors.Wrap(err, "generating padding")
+	}
+
+	buf := make([]byte, tl.WordLen, tl.WordLen+len(msg)+len(padding))
+	binary.LittleEndian.PutUint32(buf, uint32(len(msg)+len(padding)))
+	buf = append(buf, msg...)
+	buf = append(buf, padding...)
+
+	_, err := m.conn.Write(buf)
+	return err
+}
+
+// ReadMsg returns message WITH padding: mode can't know real size of message, so it must be cut off by
+// message decoder.
+func (m *paddedIntermediate) ReadMsg() ([]byte, error) {
+	sizeBuf := make([]byte, tl.WordLen)
+	if _, err := io.ReadFull(m.conn, sizeBuf); err != nil {
+		return nil, err
+	}
+
+	size := int(binary.LittleEndian.Uint32(sizeBuf))
+	if err := checkReadSize(size); err != nil {
+		return nil, err
+	}
+
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(m.conn, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
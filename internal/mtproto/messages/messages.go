@@ -10,8 +10,10 @@ package messages
 
 import (
 	"bytes"
+	"crypto/aes"
 	"encoding/binary"
 	"fmt"
+	"math/rand"
 
 	"github.com/pkg/errors"
 	"github.com/xelaj/go-dry"
@@ -41,7 +43,9 @@ type Encrypted struct {
 
 func (msg *Encrypted) Serialize(client MessageInformator, requireToAck bool) ([]byte, error) {
 	obj := serializePacket(client, msg.Msg, msg.MsgID, requireToAck)
-	encryptedData, err := ige.Encrypt(obj, client.GetAuthKey())
+	obj = append(obj, dry.RandomBytes(paddingLen(len(obj)))...)
+
+	msgKey, encryptedData, err := ige.Encrypt(obj, client.GetAuthKey())
 	if err != nil {
 		return nil, errors.Wrap(err, "encrypting")
 	}
@@ -50,15 +54,79 @@ func (msg *Encrypted) Serialize(client MessageInformator, requireToAck bool) ([]
 
 	e := tl.NewEncoder(buf)
 	e.PutRawBytes(utils.AuthKeyHash(client.GetAuthKey()))
-	e.PutRawBytes(ige.MessageKey(obj))
+	e.PutRawBytes(msgKey)
+	e.PutRawBytes(encryptedData)
+
+	return buf.Bytes(), nil
+}
+
+// SerializeAsServer encrypts message like server does it: salt, session id and seqno are taken from msg
+// itself. It's used only by fake servers in tests.
+func (msg *Encrypted) SerializeAsServer(authKey []byte) ([]byte, error) {
+	obj := serializePacket(&serverInformator{msg: msg, authKey: authKey}, msg.Msg, msg.MsgID, false)
+	obj = append(obj, dry.RandomBytes(paddingLen(len(obj)))...)
+
+	msgKey, encryptedData, err := ige.EncryptAsServer(obj, authKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting")
+	}
+
+	buf := bytes.NewBuffer(nil)
+
+	e := tl.NewEncoder(buf)
+	e.PutRawBytes(utils.AuthKeyHash(authKey))
+	e.PutRawBytes(msgKey)
 	e.PutRawBytes(encryptedData)
 
 	return buf.Bytes(), nil
 }
 
+// serverInformator gives session info for server side serialization
+type serverInformator struct {
+	msg     *Encrypted
+	authKey []byte
+}
+
+func (s *serverInformator) GetSessionID() int64  { return s.msg.SessionID }
+func (s *serverInformator) GetSeqNo() int32      { return s.msg.SeqNo }
+func (s *serverInformator) GetServerSalt() int64 { return s.msg.Salt }
+func (s *serverInformator) GetAuthKey() []byte   { return s.authKey }
+
+const (
+	// https://core.telegram.org/mtproto/description#encrypted-message-encrypted-data
+	minPaddingLen = 12
+	maxPaddingLen = 1024
+)
+
+// paddingLen returns random length of padding for message with size msgLen. padding is between 12 and
+// 1024 bytes, and total size of message is divisible by 16
+func paddingLen(msgLen int) int {
+	// minimal padding, which makes message divisible by block size
+	padding := minPaddingLen + (aes.BlockSize-(msgLen+minPaddingLen)%aes.BlockSize)%aes.BlockSize
+	// adding random count of blocks, so message length is not so obvious
+	maxExtraBlocks := (maxPaddingLen - padding) / aes.BlockSize
+	padding += rand.Intn(maxExtraBlocks+1) * aes.BlockSize //nolint:gosec padding length is not a secret
+
+	return padding
+}
+
 func DeserializeEncrypted(data, authKey []byte) (*Encrypted, error) {
+	return deserializeEncrypted(data, authKey, false)
+}
+
+// DeserializeEncryptedAsServer decrypts message, which was sent by client. It's used only by fake servers
+// in tests.
+func DeserializeEncryptedAsServer(data, authKey []byte) (*Encrypted, error) {
+	return deserializeEncrypted(data, authKey, true)
+}
+
+func deserializeEncrypted(data, authKey []byte, asServer bool) (*Encrypted, error) {
 	msg := new(Encrypted)
 
+	if len(data) < tl.LongLen+tl.Int128Len+aes.BlockSize {
+		return nil, fmt.Errorf("message is too small to be encrypted: %v bytes", len(data))
+	}
+
 	buf := bytes.NewBuffer(data)
 	d, err := tl.NewDecoder(buf)
 	if err != nil {
@@ -68,13 +136,23 @@ func DeserializeEncrypted(data, authKey []byte) (*Encrypted, error) {
 	if !bytes.Equal(keyHash, utils.AuthKeyHash(authKey)) {
 		return nil, errors.New("wrong encryption key")
 	}
-	msg.MsgKey = d.PopRawBytes(tl.Int128Len) // msgKey это хэш от расшифрованного набора байт, последние 16 символов
+	msg.MsgKey = d.PopRawBytes(tl.Int128Len) // msgKey это хэш от расшифрованного набора байт, средние 16 байт
 	encryptedData := d.PopRawBytes(len(data) - (tl.LongLen + tl.Int128Len))
 
-	decrypted, err := ige.Decrypt(encryptedData, authKey, msg.MsgKey)
+	decrypt := ige.Decrypt
+	if asServer {
+		decrypt = ige.DecryptAsServer
+	}
+	decrypted, err := decrypt(encryptedData, authKey, msg.MsgKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "decrypting message")
 	}
+
+	// этот кусок проверяет валидность данных по ключу. в MTProto 2.0 хэш считается вместе с паддингом
+	if !bytes.Equal(ige.MessageKey(authKey, decrypted, !asServer), msg.MsgKey) {
+		return nil, errors.New("wrong message key, can't trust to sender")
+	}
+
 	buf = bytes.NewBuffer(decrypted)
 	d, err = tl.NewDecoder(buf)
 	if err != nil {
@@ -86,20 +164,21 @@ func DeserializeEncrypted(data, authKey []byte) (*Encrypted, error) {
 	msg.SeqNo = d.PopInt()
 	messageLen := d.PopInt()
 
-	if len(decrypted) < int(messageLen)-(tl.LongLen+tl.LongLen+tl.LongLen+tl.WordLen+tl.WordLen) {
-		return nil, fmt.Errorf("message is smaller than it's defining: have %v, but messageLen is %v", len(decrypted), messageLen)
+	const headerLen = tl.LongLen + tl.LongLen + tl.LongLen + tl.WordLen + tl.WordLen
+	if messageLen < 0 || messageLen%tl.WordLen != 0 {
+		return nil, fmt.Errorf("invalid message length: %v", messageLen)
+	}
+	padding := len(decrypted) - headerLen - int(messageLen)
+	if padding < minPaddingLen || padding > maxPaddingLen {
+		return nil, fmt.Errorf("message padding must be between %v and %v bytes, got %v", minPaddingLen, maxPaddingLen, padding)
 	}
 
+	// client message ids are divisible by 4, server ones are odd
 	mod := msg.MsgID & 3
-	if mod != 1 && mod != 3 {
+	if (asServer && mod != 0) || (!asServer && mod != 1 && mod != 3) {
 		return nil, fmt.Errorf("wrong bits of message_id: %d", mod)
 	}
 
-	// этот кусок проверяет валидность данных по ключу
-	trimed := decrypted[0 : 32+messageLen] // суммарное сообщение, после расшифровки
-	if !bytes.Equal(dry.Sha1Byte(trimed)[4:20], msg.MsgKey) {
-		return nil, errors.New("wrong message key, can't trust to sender")
-	}
 	msg.Msg = d.PopRawBytes(int(messageLen))
 
 	return msg, nil
@@ -134,6 +213,16 @@ func (msg *Unencrypted) Serialize(client MessageInformator) ([]byte, error) {
 }
 
 func DeserializeUnencrypted(data []byte) (*Unencrypted, error) {
+	return deserializeUnencrypted(data, false)
+}
+
+// DeserializeUnencryptedAsServer parses message, which was sent by client. It's used only by fake servers in
+// tests.
+func DeserializeUnencryptedAsServer(data []byte) (*Unencrypted, error) {
+	return deserializeUnencrypted(data, true)
+}
+
+func deserializeUnencrypted(data []byte, asServer bool) (*Unencrypted, error) {
 	msg := new(Unencrypted)
 	d, _ := tl.NewDecoder(bytes.NewBuffer(data))
 	_ = d.PopRawBytes(tl.LongLen) // authKeyHash, always 0 if unencrypted
@@ -141,13 +230,12 @@ func DeserializeUnencrypted(data []byte) (*Unencrypted, error) {
 	msg.MsgID = d.PopLong()
 
 	mod := msg.MsgID & 3
-	if mod != 1 && mod != 3 {
+	if (asServer && mod != 0) || (!asServer && mod != 1 && mod != 3) {
 		return nil, fmt.Errorf("Wrong bits of message_id: %#v", uint64(mod))
 	}
 
 	messageLen := d.PopUint()
 	if len(data)-(tl.LongLen+tl.LongLen+tl.WordLen) != int(messageLen) {
-		fmt.Println(len(data), int(messageLen), int(messageLen+(tl.LongLen+tl.LongLen+tl.WordLen)))
 		return nil, fmt.Errorf("message not equal defined size: have %v, want %v", len(data), messageLen)
 	}
 
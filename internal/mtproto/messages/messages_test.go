@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/xelaj/go-dry"
 
 	. "github.com/umesproject/mtproto/internal/mtproto/messages"
@@ -87,7 +88,6 @@ func TestSerializeEncryptedMessage(t *testing.T) {
 		name    string
 		msg     *Encrypted
 		ack     bool
-		want    []byte
 		wantErr assert.ErrorAssertionFunc
 	}{
 		{
@@ -96,9 +96,6 @@ func TestSerializeEncryptedMessage(t *testing.T) {
 				Msg:   []byte("hello mtproto messages!"),
 				MsgID: 123,
 			},
-			want: Hexed("26C877F943462A4247DC1ACF8232053834D146BE164547066924AB8509629E8C" +
-				"2C2B353A77C8A37EAB2D8982723DD7027941408F91F84BF1FE8FD7CDE3E4D29F" +
-				"AFAFFD26489DFFC18DFC09C9C4A53973B9C943910C28B687"),
 		},
 	}
 	for _, tt := range tests {
@@ -109,20 +106,61 @@ func TestSerializeEncryptedMessage(t *testing.T) {
 			}
 
 			got, err := tt.msg.Serialize(client, tt.ack)
-
 			if !wantErr(t, err) {
 				return
 			}
-			if !assert.Equal(t, tt.want, got) {
-				return
-			}
 
-			// TODO: тоже не работает
-			//pp.Println(DeserializeEncryptedMessage(got, client.GetAuthKey()))
+			// auth key hash, then msg key, then encrypted data
+			assert.Equal(t, Hexed("26C877F943462A42"), got[:8])
+			encrypted := got[8+16:]
+			assert.Zero(t, len(encrypted)%16)
+
+			//              salt session msgID seqNo len
+			packetLen := len(tt.msg.Msg) + 8 + 8 + 8 + 4 + 4
+			assert.GreaterOrEqual(t, len(encrypted)-packetLen, 12)
+			assert.LessOrEqual(t, len(encrypted)-packetLen, 1024)
+
+			// padding is random, so message key (and whole message) must be different every time
+			again, err := tt.msg.Serialize(client, tt.ack)
+			assert.NoError(t, err)
+			assert.NotEqual(t, got[8:8+16], again[8:8+16])
+
+			// message key is calculated with other part of auth key for client messages, so it can't be
+			// parsed as message from server
+			_, err = DeserializeEncrypted(got, client.GetAuthKey())
+			assert.Error(t, err)
 		})
 	}
 }
 
+func TestEncryptedMessageAsServer(t *testing.T) {
+	c := &DummyClient{sessionID: 42, lastSeqNo: 6, serverSalt: 777, authKey: client.authKey}
+	msg := &Encrypted{Msg: []byte("hello mtproto messages!!"), MsgID: 4 * 100}
+
+	// server decrypts client messages
+	data, err := msg.Serialize(c, true)
+	assert.NoError(t, err)
+	got, err := DeserializeEncryptedAsServer(data, c.authKey)
+	require.NoError(t, err)
+	assert.Equal(t, msg.Msg, got.Msg)
+	assert.Equal(t, int64(42), got.SessionID)
+	assert.Equal(t, int64(777), got.Salt)
+	assert.Equal(t, int32(7), got.SeqNo)
+
+	// and client decrypts server messages
+	answer := &Encrypted{Msg: []byte("hello from server!!!"), MsgID: 4*101 + 1, Salt: 777, SessionID: 42, SeqNo: 3}
+	data, err = answer.SerializeAsServer(c.authKey)
+	assert.NoError(t, err)
+	got, err = DeserializeEncrypted(data, c.authKey)
+	require.NoError(t, err)
+	assert.Equal(t, answer, &Encrypted{
+		Msg: got.Msg, MsgID: got.MsgID, Salt: got.Salt, SessionID: got.SessionID, SeqNo: got.SeqNo,
+	})
+
+	_, err = DeserializeEncryptedAsServer(data, c.authKey)
+	assert.Error(t, err)
+}
+
 func Hexed(in string) []byte {
 	res, err := hex.DecodeString(in)
 	dry.PanicIfErr(err)
@@ -14,9 +14,14 @@ func init() {
 		&ReqPQParams{},
 		&ReqDHParamsParams{},
 		&SetClientDHParamsParams{},
+		&RpcDropAnswerParams{},
 		&PingParams{},
+		&HttpWaitParams{},
 		&ResPQ{},
 		&PQInnerData{},
+		&PQInnerDataDC{},
+		&PQInnerDataTempDC{},
+		&BindAuthKeyInner{},
 		&ServerDHParamsFail{},
 		&ServerDHParamsOk{},
 		&ServerDHInnerData{},
@@ -104,8 +104,50 @@ func SetClientDHParams(m requester, nonce, serverNonce *tl.Int128, encryptedData
 	return resp, nil
 }
 
-// rpc_drop_answer
-// get_future_salts
+// BindTempAuthKeyParams is auth.bindTempAuthKey. It's a method of api layer, but it's required by
+// protocol itself, when client uses temporary auth keys.
+type BindTempAuthKeyParams struct {
+	PermAuthKeyID    int64
+	Nonce            int64
+	ExpiresAt        int32
+	EncryptedMessage []byte
+}
+
+func (*BindTempAuthKeyParams) CRC() uint32 {
+	return 0xcdd42a05 //nolint:gomnd not magic
+}
+
+type RpcDropAnswerParams struct {
+	ReqMsgID int64
+}
+
+func (*RpcDropAnswerParams) CRC() uint32 {
+	return 0x58e4a740 //nolint:gomnd not magic
+}
+
+type GetFutureSaltsParams struct {
+	Num int32
+}
+
+func (*GetFutureSaltsParams) CRC() uint32 {
+	return 0xb921bd04 //nolint:gomnd not magic
+}
+
+func GetFutureSalts(m requester, num int32) (*FutureSalts, error) {
+	data, err := m.MakeRequest(&GetFutureSaltsParams{
+		Num: num,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "sending GetFutureSalts")
+	}
+
+	resp, ok := data.(*FutureSalts)
+	if !ok {
+		return nil, errors.New("got invalid response type: " + reflect.TypeOf(data).String())
+	}
+
+	return resp, nil
+}
 
 type PingParams struct {
 	PingID int64
@@ -137,9 +179,19 @@ func Ping(m requester, pingID int64) (*Pong, error) {
 
 // set_client_DH_params#f5045f1f nonce:int128 server_nonce:int128 encrypted_data:bytes = Set_client_DH_params_answer;
 
-// rpc_drop_answer#58e4a740 req_msg_id:long = RpcDropAnswer;
-// get_future_salts#b921bd04 num:int = FutureSalts;
 // ping_delay_disconnect#f3427b8c ping_id:long disconnect_delay:int = Pong;
 // destroy_session#e7512126 session_id:long = DestroySessionRes;
 
 // http_wait#9299359f max_delay:int wait_after:int max_wait:int = HttpWait;
+
+// HttpWaitParams is used only by http transport: server holds http request until it has something to
+// send, or MaxWait milliseconds passed.
+type HttpWaitParams struct {
+	MaxDelay  int32
+	WaitAfter int32
+	MaxWait   int32
+}
+
+func (*HttpWaitParams) CRC() uint32 {
+	return 0x9299359f //nolint:gomnd not magic
+}
@@ -51,6 +51,55 @@ func (*PQInnerData) CRC() uint32 {
 	return 0x83c95aec //nolint:gomnd not magic
 }
 
+// PQInnerDataDC is used instead of PQInnerData, when client creates permanent auth key, it contains
+// id of DC, which client connects to.
+// https://core.telegram.org/mtproto/auth_key#presenting-proof-of-work-server-authentication
+type PQInnerDataDC struct {
+	Pq          []byte
+	P           []byte
+	Q           []byte
+	Nonce       *tl.Int128
+	ServerNonce *tl.Int128
+	NewNonce    *tl.Int256
+	DC          int32
+}
+
+func (*PQInnerDataDC) CRC() uint32 {
+	return 0xa9f55f95 //nolint:gomnd not magic
+}
+
+// PQInnerDataTempDC is used instead of PQInnerData, when client creates temporary auth key, which
+// expires after ExpiresIn seconds.
+// https://core.telegram.org/mtproto/auth_key#presenting-proof-of-work-server-authentication
+type PQInnerDataTempDC struct {
+	Pq          []byte
+	P           []byte
+	Q           []byte
+	Nonce       *tl.Int128
+	ServerNonce *tl.Int128
+	NewNonce    *tl.Int256
+	DC          int32
+	ExpiresIn   int32
+}
+
+func (*PQInnerDataTempDC) CRC() uint32 {
+	return 0x56fddf88 //nolint:gomnd not magic
+}
+
+// BindAuthKeyInner is encrypted by permanent key and sent in auth.bindTempAuthKey
+// https://core.telegram.org/method/auth.bindTempAuthKey
+type BindAuthKeyInner struct {
+	Nonce         int64
+	TempAuthKeyID int64
+	PermAuthKeyID int64
+	TempSessionID int64
+	ExpiresAt     int32
+}
+
+func (*BindAuthKeyInner) CRC() uint32 {
+	return 0x75a3f765 //nolint:gomnd not magic
+}
+
 type ServerDHParams interface {
 	tl.Object
 	ImplementsServerDHParams()
@@ -240,6 +289,41 @@ func (*FutureSalts) CRC() uint32 {
 	return 0xae500895 //nolint:gomnd not magic
 }
 
+// salts are written as bare vector of bare future_salt:
+// `future_salts#ae500895 req_msg_id:long now:int salts:vector<future_salt> = FutureSalts;`
+func (t *FutureSalts) MarshalTL(e *tl.Encoder) error {
+	e.PutUint(t.CRC())
+	e.PutLong(t.ReqMsgID)
+	e.PutInt(t.Now)
+	e.PutInt(int32(len(t.Salts)))
+	for _, salt := range t.Salts {
+		e.PutInt(salt.ValidSince)
+		e.PutInt(salt.ValidUntil)
+		e.PutLong(salt.Salt)
+	}
+	return e.CheckErr()
+}
+
+func (t *FutureSalts) UnmarshalTL(d *tl.Decoder) error {
+	t.ReqMsgID = d.PopLong()
+	t.Now = d.PopInt()
+	count := int(d.PopInt())
+	if count < 0 {
+		return errors.New("negative count of salts")
+	}
+
+	t.Salts = make([]*FutureSalt, 0, count)
+	for i := 0; i < count; i++ {
+		t.Salts = append(t.Salts, &FutureSalt{
+			ValidSince: d.PopInt(),
+			ValidUntil: d.PopInt(),
+			Salt:       d.PopLong(),
+		})
+	}
+
+	return nil
+}
+
 type Pong struct {
 	MsgID  int64
 	PingID int64
@@ -282,8 +366,8 @@ func (t *MessageContainer) MarshalTL(e *tl.Encoder) error {
 	for _, msg := range *t {
 		e.PutLong(msg.MsgID)
 		e.PutInt(msg.SeqNo)
-		//       msgID        seqNo        len                object
-		e.PutInt(tl.LongLen + tl.WordLen + tl.WordLen + int32(len(msg.Msg)))
+		// bytes is a length of body only, as decoder expects
+		e.PutInt(int32(len(msg.Msg)))
 		e.PutRawBytes(msg.Msg)
 	}
 	return e.CheckErr()
@@ -328,8 +412,41 @@ func (*GzipPacked) CRC() uint32 {
 	return CrcGzipPacked
 }
 
-func (*GzipPacked) MarshalTL(e *tl.Encoder) error {
-	panic("not implemented")
+func (t *GzipPacked) MarshalTL(e *tl.Encoder) error {
+	obj, err := tl.Marshal(t.Obj)
+	if err != nil {
+		return errors.Wrap(err, "encoding object")
+	}
+
+	packed, err := PackGzipped(obj)
+	if err != nil {
+		return err
+	}
+
+	e.PutRawBytes(packed)
+	return e.CheckErr()
+}
+
+// PackGzipped сжимает уже сериализованный объект и возвращает его в виде gzip_packed#3072cfa1
+func PackGzipped(obj []byte) ([]byte, error) {
+	compressed := bytes.NewBuffer(nil)
+	w := gzip.NewWriter(compressed)
+	if _, err := w.Write(obj); err != nil {
+		return nil, errors.Wrap(err, "compressing object")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "compressing object")
+	}
+
+	buf := bytes.NewBuffer(nil)
+	e := tl.NewEncoder(buf)
+	e.PutCRC(CrcGzipPacked)
+	e.PutMessage(compressed.Bytes())
+	if err := e.CheckErr(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
 }
 
 func (t *GzipPacked) UnmarshalTL(d *tl.Decoder) error {
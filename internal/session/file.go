@@ -87,10 +87,19 @@ func (l *genericFileSessionLoader) Store(s *Session) error {
 }
 
 type tokenStorageFormat struct {
-	Key      string `json:"key"`
-	Hash     string `json:"hash"`
-	Salt     string `json:"salt"`
-	Hostname string `json:"hostname"`
+	Key        string `json:"key"`
+	Hash       string `json:"hash"`
+	Salt       string `json:"salt"`
+	Hostname   string `json:"hostname"`
+	TimeOffset int64  `json:"time_offset,omitempty"`
+
+	FutureSalts []futureSaltStorageFormat `json:"future_salts,omitempty"`
+}
+
+type futureSaltStorageFormat struct {
+	Salt       string `json:"salt"`
+	ValidSince int64  `json:"valid_since"`
+	ValidUntil int64  `json:"valid_until"`
 }
 
 func (t *tokenStorageFormat) writeSession(s *Session) {
@@ -98,6 +107,16 @@ func (t *tokenStorageFormat) writeSession(s *Session) {
 	t.Hash = base64.StdEncoding.EncodeToString(s.Hash)
 	t.Salt = encodeInt64ToBase64(s.Salt)
 	t.Hostname = s.Hostname
+	t.TimeOffset = s.TimeOffset
+
+	t.FutureSalts = nil
+	for _, salt := range s.FutureSalts {
+		t.FutureSalts = append(t.FutureSalts, futureSaltStorageFormat{
+			Salt:       encodeInt64ToBase64(salt.Salt),
+			ValidSince: salt.ValidSince,
+			ValidUntil: salt.ValidUntil,
+		})
+	}
 }
 
 func (t *tokenStorageFormat) readSession() (*Session, error) {
@@ -117,6 +136,20 @@ func (t *tokenStorageFormat) readSession() (*Session, error) {
 		return nil, errors.Wrap(err, "invalid binary data of 'salt'")
 	}
 	s.Hostname = t.Hostname
+	s.TimeOffset = t.TimeOffset
+
+	for _, salt := range t.FutureSalts {
+		value, err := decodeInt64ToBase64(salt.Salt)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid binary data of future salt")
+		}
+		s.FutureSalts = append(s.FutureSalts, FutureSalt{
+			Salt:       value,
+			ValidSince: salt.ValidSince,
+			ValidUntil: salt.ValidUntil,
+		})
+	}
+
 	return s, nil
 }
 
@@ -58,6 +58,30 @@ func TestMTProto_LoadSession(t *testing.T) {
 	}, sess)
 }
 
+func TestMTProto_FutureSalts(t *testing.T) {
+	storePath := filepath.Join(os.TempDir(), "session_salts.json")
+	defer os.Remove(storePath)
+
+	os.Remove(storePath)
+
+	s := &session.Session{
+		Key:      []byte("some auth key"),
+		Hash:     []byte("oooooh that's definitely a key hash!"),
+		Salt:     1,
+		Hostname: "1337.228.1488.0",
+		FutureSalts: []session.FutureSalt{
+			{Salt: 2, ValidSince: 1600000000, ValidUntil: 1600003600},
+			{Salt: -3, ValidSince: 1600003600, ValidUntil: 1600007200},
+		},
+	}
+
+	require.NoError(t, session.NewFromFile(storePath).Store(s))
+
+	loaded, err := session.NewFromFile(storePath).Load()
+	require.NoError(t, err)
+	assert.Equal(t, s, loaded)
+}
+
 func check(err error) {
 	if err != nil {
 		panic(err)
@@ -8,10 +8,21 @@ type SessionLoader interface {
 }
 
 // Sesion is a basic data of specific session. Typically, session stores default hostname of mtproto server
-// (cause all accounts ties to specific server after sign in), session key, server hash and salt. 
+// (cause all accounts ties to specific server after sign in), session key, server hash and salt.
 type Session struct {
 	Key      []byte
 	Hash     []byte
 	Salt     int64
 	Hostname string
+	// TimeOffset is difference between server and local time in seconds
+	TimeOffset int64
+	// FutureSalts is a schedule of next server salts, sorted by validity time
+	FutureSalts []FutureSalt
+}
+
+// FutureSalt is a server salt, which server accepts during specified period of time.
+type FutureSalt struct {
+	Salt       int64
+	ValidSince int64 // unix time
+	ValidUntil int64 // unix time
 }
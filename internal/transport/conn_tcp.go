@@ -7,12 +7,11 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
-	"github.com/xelaj/go-dry/ioutil"
 )
 
 type tcpConn struct {
-	cancelReader *ioutil.CancelableReader
-	conn         *net.TCPConn
+	cancelReader *cancelableReader
+	conn         net.Conn
 	timeout      time.Duration
 }
 
@@ -20,20 +19,28 @@ type TCPConnConfig struct {
 	Ctx     context.Context
 	Host    string
 	Timeout time.Duration
+	// Dialer is used to open connection (e.g. through proxy). If nil, direct tcp connection is used.
+	Dialer Dialer
 }
 
 func NewTCP(cfg TCPConnConfig) (Conn, error) {
-	tcpAddr, err := net.ResolveTCPAddr("tcp", cfg.Host)
-	if err != nil {
-		return nil, errors.Wrap(err, "resolving tcp")
+	dialer := cfg.Dialer
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+
+	ctx := cfg.Ctx
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	conn, err := net.DialTCP("tcp", nil, tcpAddr)
+
+	conn, err := dialer.DialContext(ctx, "tcp", cfg.Host)
 	if err != nil {
 		return nil, errors.Wrap(err, "dialing tcp")
 	}
 
 	return &tcpConn{
-		cancelReader: ioutil.NewCancelableReader(cfg.Ctx, conn),
+		cancelReader: newCancelableReader(ctx, conn),
 		conn:         conn,
 		timeout:      cfg.Timeout,
 	}, nil
@@ -49,8 +56,10 @@ func (t *tcpConn) Write(b []byte) (int, error) {
 
 func (t *tcpConn) Read(b []byte) (int, error) {
 	if t.timeout > 0 {
-		err := t.conn.SetReadDeadline(time.Now().Add(t.timeout))
-		check(err)
+		// connection could be already closed by client, then reading fails with the same error
+		if err := t.conn.SetReadDeadline(time.Now().Add(t.timeout)); err != nil {
+			return 0, t.cancelReader.wrap(err)
+		}
 	}
 
 	n, err := t.cancelReader.Read(b)
@@ -70,3 +79,32 @@ func (t *tcpConn) Read(b []byte) (int, error) {
 	}
 	return n, nil
 }
+
+// cancelableReader reads exactly len(b) bytes, like io.ReadFull does. After ctx is done, it returns ctx.Err()
+// instead of error of closed connection. It doesn't start any goroutines, so reading could be unblocked
+// only by closing of connection (which is made by client on cancel).
+type cancelableReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func newCancelableReader(ctx context.Context, r io.Reader) *cancelableReader {
+	return &cancelableReader{ctx: ctx, r: r}
+}
+
+func (c *cancelableReader) Read(b []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := io.ReadFull(c.r, b)
+	return n, c.wrap(err)
+}
+
+// wrap replaces error of connection, which was closed on cancel, by ctx.Err().
+func (c *cancelableReader) wrap(err error) error {
+	if err != nil && c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+	return err
+}
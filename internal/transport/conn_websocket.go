@@ -0,0 +1,90 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/websocket"
+)
+
+// WebSocketConnConfig is a config of websocket connection to /apiws. All data is sent in binary frames,
+// so any mode (and obfuscation) works over it same as over tcp.
+// https://core.telegram.org/mtproto/transports#websocket
+type WebSocketConnConfig struct {
+	Ctx  context.Context
+	Host string
+	// TLS enables wss
+	TLS     bool
+	Timeout time.Duration
+	// Dialer is used to open connection (e.g. through proxy). If nil, direct tcp connection is used.
+	Dialer Dialer
+}
+
+const webSocketSubprotocol = "binary"
+
+func NewWebSocket(cfg WebSocketConnConfig) (Conn, error) {
+	dialer := cfg.Dialer
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+
+	ctx := cfg.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	scheme, originScheme := "ws", "http"
+	if cfg.TLS {
+		scheme, originScheme = "wss", "https"
+	}
+
+	config, err := websocket.NewConfig(
+		fmt.Sprintf("%v://%v/apiws", scheme, cfg.Host),
+		fmt.Sprintf("%v://%v", originScheme, cfg.Host),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating websocket config")
+	}
+	config.Protocol = []string{webSocketSubprotocol}
+
+	conn, err := dialer.DialContext(ctx, "tcp", cfg.Host)
+	if err != nil {
+		return nil, errors.Wrap(err, "dialing tcp")
+	}
+
+	if cfg.TLS {
+		host, _, err := net.SplitHostPort(cfg.Host)
+		if err != nil {
+			host = cfg.Host
+		}
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "tls handshake")
+		}
+		conn = tlsConn
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "websocket handshake")
+	}
+	ws.PayloadType = websocket.BinaryFrame
+
+	// websocket connection is a stream of bytes same as tcp, only framing is different
+	return &tcpConn{
+		cancelReader: newCancelableReader(ctx, ws),
+		conn:         ws,
+		timeout:      cfg.Timeout,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/umesproject/mtproto/internal/mode"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/transport"
+)
+
+func webSocketServer(t *testing.T, obfuscated bool) *httptest.Server {
+	t.Helper()
+
+	ws := websocket.Server{
+		Handshake: func(cfg *websocket.Config, r *http.Request) error {
+			if len(cfg.Protocol) != 1 || cfg.Protocol[0] != "binary" {
+				return websocket.ErrBadWebSocketProtocol
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			ws.PayloadType = websocket.BinaryFrame
+
+			var (
+				m   mode.Mode
+				err error
+			)
+			if obfuscated {
+				var (
+					conn transport.Conn
+					tag  [4]byte
+				)
+				conn, tag, _, err = transport.AcceptObfuscated(ws, nil)
+				if err != nil {
+					return
+				}
+				m, err = mode.Wrap(map[[4]byte]mode.Variant{
+					{0xef, 0xef, 0xef, 0xef}: mode.Abridged,
+					{0xee, 0xee, 0xee, 0xee}: mode.Intermediate,
+				}[tag], conn)
+			} else {
+				m, err = mode.Detect(ws)
+			}
+			if err != nil {
+				return
+			}
+
+			for {
+				msg, err := m.ReadMsg()
+				if err != nil {
+					return
+				}
+				if err := m.WriteMsg(msg); err != nil {
+					return
+				}
+			}
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/apiws", ws)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestWebSocket(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		mode       mode.Variant
+		obfuscated bool
+	}{
+		{"abridged", mode.Abridged, false},
+		{"intermediate", mode.Intermediate, false},
+		{"obfuscated abridged", mode.Abridged, true},
+		{"obfuscated intermediate", mode.Intermediate, true},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			srv := webSocketServer(t, tt.obfuscated)
+
+			var conn transport.ConnConfig = transport.WebSocketConnConfig{
+				Ctx:  context.Background(),
+				Host: strings.TrimPrefix(srv.URL, "http://"),
+			}
+			if tt.obfuscated {
+				conn = transport.ObfuscatedConnConfig{Conn: conn, DC: 2}
+			}
+
+			tr, err := transport.NewTransport(nil, conn, tt.mode)
+			require.NoError(t, err)
+			defer tr.Close()
+
+			msg := &messages.Unencrypted{
+				Msg:   []byte("some unencrypted message"),
+				MsgID: 1<<32 | 1,
+			}
+			require.NoError(t, tr.WriteMsg(msg, false))
+
+			got, err := tr.ReadMsg()
+			require.NoError(t, err)
+			assert.Equal(t, msg, got)
+		})
+	}
+}
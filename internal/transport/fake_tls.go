@@ -0,0 +1,318 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package transport
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// fake-TLS connection looks like TLS 1.3 for DPI: client sends ClientHello, signed by proxy secret, proxy
+// answers with ServerHello, signed by same secret, then all traffic goes inside TLS application data
+// records. Inside of records obfuscated2 protocol is used.
+
+const (
+	tlsRecordHandshake        byte = 0x16
+	tlsRecordChangeCipherSpec byte = 0x14
+	tlsRecordApplicationData  byte = 0x17
+
+	tlsRecordHeaderLen = 5
+	// maximum size of data in single record, which is allowed by TLS
+	tlsMaxRecordLen = 1 << 14
+
+	tlsClientHelloLen = 517
+	// offset of random in handshake record: record header, handshake type, length, version
+	tlsRandomOffset = tlsRecordHeaderLen + 1 + 3 + 2
+	tlsRandomLen    = 32
+)
+
+var (
+	tlsVersion10 = []byte{0x03, 0x01} // meta:immutable
+	tlsVersion12 = []byte{0x03, 0x03} // meta:immutable
+
+	// change cipher spec record, which is sent before application data
+	tlsChangeCipherSpec = []byte{tlsRecordChangeCipherSpec, 0x03, 0x03, 0x00, 0x01, 0x01} // meta:immutable
+)
+
+// ErrFakeTLSHandshake returned, when proxy answered with wrong signature. Usually it means, that secret is
+// wrong, or it's a real TLS server.
+var ErrFakeTLSHandshake = errors.New("fake-TLS handshake failed: wrong server digest")
+
+type fakeTLSConn struct {
+	conn Conn
+	// unread data of current record
+	buf []byte
+	// change cipher spec must be sent once, before first application data
+	ccsSent bool
+}
+
+// NewFakeTLS makes fake-TLS handshake with MTProxy server and returns connection, which wraps all data into
+// TLS application data records. key is 16 byte proxy secret, domain is a name from ee-secret.
+func NewFakeTLS(conn Conn, key []byte, domain string) (Conn, error) {
+	hello, err := clientHello(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	clientRandom := signClientHello(hello, key, time.Now())
+	if _, err := conn.Write(hello); err != nil {
+		return nil, errors.Wrap(err, "sending ClientHello")
+	}
+
+	err = readServerHello(conn, key, clientRandom)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fakeTLSConn{conn: conn}, nil
+}
+
+// signClientHello writes HMAC-SHA256 of hello into random field and returns it. Last 4 bytes of digest are
+// XORed with current timestamp, so proxy can reject replayed hellos.
+func signClientHello(hello, key []byte, now time.Time) []byte {
+	random := hello[tlsRandomOffset : tlsRandomOffset+tlsRandomLen]
+	for i := range random {
+		random[i] = 0
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(hello)
+	digest := mac.Sum(nil)
+
+	timestamp := make([]byte, 4)
+	binary.LittleEndian.PutUint32(timestamp, uint32(now.Unix()))
+	for i := range timestamp {
+		digest[tlsRandomLen-4+i] ^= timestamp[i]
+	}
+
+	copy(random, digest)
+	return append([]byte{}, random...)
+}
+
+// readServerHello reads ServerHello, change cipher spec and first application data records, then checks
+// that server random is HMAC of client random and whole response.
+func readServerHello(conn Conn, key, clientRandom []byte) error {
+	var response []byte
+	for _, expected := range []byte{tlsRecordHandshake, tlsRecordChangeCipherSpec, tlsRecordApplicationData} {
+		record, err := readTLSRecord(conn)
+		if err != nil {
+			return errors.Wrap(err, "reading ServerHello")
+		}
+		if record[0] != expected {
+			return errors.Errorf("unexpected TLS record type 0x%x, expected 0x%x", record[0], expected)
+		}
+		response = append(response, record...)
+	}
+
+	if len(response) < tlsRandomOffset+tlsRandomLen {
+		return errors.New("ServerHello is too short")
+	}
+
+	serverRandom := append([]byte{}, response[tlsRandomOffset:tlsRandomOffset+tlsRandomLen]...)
+	for i := 0; i < tlsRandomLen; i++ {
+		response[tlsRandomOffset+i] = 0
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(clientRandom)
+	mac.Write(response)
+	if !hmac.Equal(mac.Sum(nil), serverRandom) {
+		return ErrFakeTLSHandshake
+	}
+
+	return nil
+}
+
+// readTLSRecord reads whole record with header.
+func readTLSRecord(r io.Reader) ([]byte, error) {
+	header := make([]byte, tlsRecordHeaderLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	record := make([]byte, tlsRecordHeaderLen+int(binary.BigEndian.Uint16(header[3:])))
+	copy(record, header)
+	if _, err := io.ReadFull(r, record[tlsRecordHeaderLen:]); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (c *fakeTLSConn) Write(b []byte) (int, error) {
+	var buf []byte
+	if !c.ccsSent {
+		buf = append(buf, tlsChangeCipherSpec...)
+		c.ccsSent = true
+	}
+
+	for data := b; len(data) > 0; {
+		size := len(data)
+		if size > tlsMaxRecordLen {
+			size = tlsMaxRecordLen
+		}
+
+		buf = append(buf, tlsRecordApplicationData)
+		buf = append(buf, tlsVersion12...)
+		buf = append(buf, byte(size>>8), byte(size))
+		buf = append(buf, data[:size]...)
+		data = data[size:]
+	}
+
+	if _, err := c.conn.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (c *fakeTLSConn) Read(b []byte) (int, error) {
+	for len(c.buf) == 0 {
+		record, err := readTLSRecord(c.conn)
+		if err != nil {
+			return 0, err
+		}
+
+		switch record[0] {
+		case tlsRecordApplicationData:
+			c.buf = record[tlsRecordHeaderLen:]
+		case tlsRecordChangeCipherSpec:
+			// nothing to read
+		default:
+			return 0, errors.Errorf("unexpected TLS record type 0x%x", record[0])
+		}
+	}
+
+	n := copy(b, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+func (c *fakeTLSConn) Close() error {
+	return c.conn.Close()
+}
+
+// clientHello builds TLS 1.3 ClientHello, which is similar to hello of popular browsers. Random field is
+// filled with zeros, it must be signed by signClientHello.
+func clientHello(domain string) ([]byte, error) {
+	randomBytes := func(n int) ([]byte, error) {
+		b := make([]byte, n)
+		_, err := rand.Read(b)
+		return b, err
+	}
+
+	sessionID, err := randomBytes(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating session id")
+	}
+	keyShare, err := randomBytes(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating key share")
+	}
+
+	b := &tlsBuilder{}
+	b.bytes(tlsRecordHandshake)
+	b.bytes(tlsVersion10...)
+	b.withLen16(func() {
+		b.bytes(0x01) // client hello
+		b.withLen24(func() {
+			b.bytes(tlsVersion12...)
+			b.bytes(make([]byte, tlsRandomLen)...)
+			b.bytes(byte(len(sessionID)))
+			b.bytes(sessionID...)
+			b.withLen16(func() { // cipher suites
+				b.bytes(
+					0x13, 0x01, 0x13, 0x02, 0x13, 0x03, 0xc0, 0x2b, 0xc0, 0x2f, 0xc0, 0x2c, 0xc0, 0x30,
+					0xcc, 0xa9, 0xcc, 0xa8, 0xc0, 0x13, 0xc0, 0x14, 0x00, 0x9c, 0x00, 0x9d, 0x00, 0x2f,
+					0x00, 0x35,
+				)
+			})
+			b.bytes(0x01, 0x00) // compression methods: null
+			b.withLen16(func() {
+				b.extension(0x0000, func() { // server name
+					b.withLen16(func() {
+						b.bytes(0x00) // host name
+						b.withLen16(func() { b.bytes([]byte(domain)...) })
+					})
+				})
+				b.extension(0x0017, func() {})                // extended master secret
+				b.extension(0xff01, func() { b.bytes(0x00) }) // renegotiation info
+				b.extension(0x000a, func() {                  // supported groups
+					b.withLen16(func() { b.bytes(0x00, 0x1d, 0x00, 0x17, 0x00, 0x18) })
+				})
+				b.extension(0x000b, func() { b.bytes(0x01, 0x00) }) // ec point formats
+				b.extension(0x0023, func() {})                      // session ticket
+				b.extension(0x0010, func() {                        // alpn
+					b.withLen16(func() {
+						b.bytes(0x02)
+						b.bytes([]byte("h2")...)
+						b.bytes(0x08)
+						b.bytes([]byte("http/1.1")...)
+					})
+				})
+				b.extension(0x0005, func() { b.bytes(0x01, 0x00, 0x00, 0x00, 0x00) }) // status request
+				b.extension(0x000d, func() {                                          // signature algorithms
+					b.withLen16(func() {
+						b.bytes(0x04, 0x03, 0x08, 0x04, 0x04, 0x01, 0x05, 0x03, 0x08, 0x05, 0x05, 0x01, 0x08, 0x06, 0x06, 0x01)
+					})
+				})
+				b.extension(0x0012, func() {}) // signed certificate timestamp
+				b.extension(0x0033, func() {   // key share
+					b.withLen16(func() {
+						b.bytes(0x00, 0x1d) // x25519
+						b.withLen16(func() { b.bytes(keyShare...) })
+					})
+				})
+				b.extension(0x002d, func() { b.bytes(0x01, 0x01) }) // psk key exchange modes
+				b.extension(0x002b, func() {                        // supported versions
+					b.bytes(0x04)
+					b.bytes(0x03, 0x04, 0x03, 0x03)
+				})
+
+				// padding, hello of browsers always has same size
+				const extensionHeaderLen = 4
+				if padding := tlsClientHelloLen - len(b.buf) - extensionHeaderLen; padding >= 0 {
+					b.extension(0x0015, func() { b.bytes(make([]byte, padding)...) })
+				}
+			})
+		})
+	})
+
+	return b.buf, nil
+}
+
+type tlsBuilder struct {
+	buf []byte
+}
+
+func (b *tlsBuilder) bytes(data ...byte) {
+	b.buf = append(b.buf, data...)
+}
+
+func (b *tlsBuilder) withLen16(f func()) {
+	start := len(b.buf)
+	b.buf = append(b.buf, 0, 0)
+	f()
+	binary.BigEndian.PutUint16(b.buf[start:], uint16(len(b.buf)-start-2))
+}
+
+func (b *tlsBuilder) withLen24(f func()) {
+	start := len(b.buf)
+	b.buf = append(b.buf, 0, 0, 0)
+	f()
+	size := len(b.buf) - start - 3
+	b.buf[start], b.buf[start+1], b.buf[start+2] = byte(size>>16), byte(size>>8), byte(size)
+}
+
+func (b *tlsBuilder) extension(typ uint16, f func()) {
+	b.buf = append(b.buf, byte(typ>>8), byte(typ))
+	b.withLen16(f)
+}
@@ -0,0 +1,221 @@
+package transport_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umesproject/mtproto/internal/transport"
+)
+
+const (
+	tlsRandomOffset = 11
+	tlsRandomLen    = 32
+)
+
+// fakeTLSServer is a minimal MTProxy fake-TLS server: it checks signature of ClientHello, answers with
+// signed ServerHello, then accepts obfuscated connection inside of application data records.
+type fakeTLSServer struct {
+	key []byte
+}
+
+// handshake returns record reader and writer for the rest of connection.
+func (s *fakeTLSServer) handshake(conn net.Conn) (io.ReadWriter, error) {
+	hello, err := readRecord(conn)
+	if err != nil {
+		return nil, err
+	}
+	if hello[0] != 0x16 || len(hello) < tlsRandomOffset+tlsRandomLen+1+32 {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	clientRandom := append([]byte{}, hello[tlsRandomOffset:tlsRandomOffset+tlsRandomLen]...)
+	copy(hello[tlsRandomOffset:], make([]byte, tlsRandomLen))
+
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(hello)
+	digest := mac.Sum(nil)
+
+	// first 28 bytes are digest, last 4 is timestamp xored with digest
+	if !bytes.Equal(digest[:28], clientRandom[:28]) {
+		return nil, transport.ErrFakeTLSHandshake
+	}
+	timestamp := make([]byte, 4)
+	for i := range timestamp {
+		timestamp[i] = digest[28+i] ^ clientRandom[28+i]
+	}
+	if skew := time.Since(time.Unix(int64(binary.LittleEndian.Uint32(timestamp)), 0)); skew > time.Minute || skew < -time.Minute {
+		return nil, transport.ErrFakeTLSHandshake
+	}
+
+	sessionID := hello[tlsRandomOffset+tlsRandomLen+1 : tlsRandomOffset+tlsRandomLen+1+32]
+
+	keyShare := make([]byte, 32)
+	rand.Read(keyShare)
+	serverHello := []byte{0x02, 0, 0, 0, 0x03, 0x03}
+	serverHello = append(serverHello, make([]byte, tlsRandomLen)...)
+	serverHello = append(serverHello, 32)
+	serverHello = append(serverHello, sessionID...)
+	serverHello = append(serverHello, 0x13, 0x01, 0x00)
+	extensions := []byte{0x00, 0x33, 0x00, 0x24, 0x00, 0x1d, 0x00, 0x20}
+	extensions = append(extensions, keyShare...)
+	extensions = append(extensions, 0x00, 0x2b, 0x00, 0x02, 0x03, 0x04)
+	serverHello = append(serverHello, byte(len(extensions)>>8), byte(len(extensions)))
+	serverHello = append(serverHello, extensions...)
+	serverHello[3] = byte(len(serverHello) - 4)
+
+	fakeCert := make([]byte, 2048)
+	rand.Read(fakeCert)
+
+	response := record(0x16, serverHello)
+	response = append(response, record(0x14, []byte{0x01})...)
+	response = append(response, record(0x17, fakeCert)...)
+
+	mac = hmac.New(sha256.New, s.key)
+	mac.Write(clientRandom)
+	mac.Write(response)
+	copy(response[tlsRandomOffset:], mac.Sum(nil))
+
+	if _, err := conn.Write(response); err != nil {
+		return nil, err
+	}
+
+	return &recordConn{conn: conn}, nil
+}
+
+func record(typ byte, data []byte) []byte {
+	return append([]byte{typ, 0x03, 0x03, byte(len(data) >> 8), byte(len(data))}, data...)
+}
+
+func readRecord(r io.Reader) ([]byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	data := make([]byte, binary.BigEndian.Uint16(header[3:]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return append(header, data...), nil
+}
+
+type recordConn struct {
+	conn net.Conn
+	buf  []byte
+}
+
+func (c *recordConn) Read(b []byte) (int, error) {
+	for len(c.buf) == 0 {
+		rec, err := readRecord(c.conn)
+		if err != nil {
+			return 0, err
+		}
+		if rec[0] == 0x17 {
+			c.buf = rec[5:]
+		}
+	}
+	n := copy(b, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+func (c *recordConn) Write(b []byte) (int, error) {
+	_, err := c.conn.Write(record(0x17, b))
+	return len(b), err
+}
+
+func (c *recordConn) Close() error {
+	return c.conn.Close()
+}
+
+func TestFakeTLS(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 16)
+	server := &fakeTLSServer{key: key}
+
+	serverErr := make(chan error, 1)
+	l := listen(t, func(conn net.Conn) {
+		rw, err := server.handshake(conn)
+		if err != nil {
+			serverErr <- err
+			return
+		}
+
+		obfuscated, tag, _, err := transport.AcceptObfuscated(rw.(transport.Conn), key)
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		if tag != [4]byte{0xdd, 0xdd, 0xdd, 0xdd} {
+			serverErr <- io.ErrUnexpectedEOF
+			return
+		}
+		serverErr <- nil
+
+		io.Copy(obfuscated, obfuscated) // echo
+	})
+
+	secret, err := transport.ParseSecret("ee" + hex.EncodeToString(key) + hex.EncodeToString([]byte("example.com")))
+	require.NoError(t, err)
+	require.Equal(t, "example.com", secret.FakeTLSDomain)
+	require.True(t, secret.Padded)
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	tlsConn, err := transport.NewFakeTLS(conn, secret.Key, secret.FakeTLSDomain)
+	require.NoError(t, err)
+
+	c, err := transport.NewObfuscated(tlsConn, [4]byte{0xdd, 0xdd, 0xdd, 0xdd}, secret.Key, 2)
+	require.NoError(t, err)
+	require.NoError(t, <-serverErr)
+
+	msg := make([]byte, 20000) // bigger than single TLS record
+	rand.Read(msg)
+	_, err = c.Write(msg)
+	require.NoError(t, err)
+
+	got := make([]byte, len(msg))
+	_, err = io.ReadFull(c, got)
+	require.NoError(t, err)
+	assert.Equal(t, msg, got)
+}
+
+func TestFakeTLSWrongSecret(t *testing.T) {
+	server := &fakeTLSServer{key: bytes.Repeat([]byte{0x42}, 16)}
+
+	serverErr := make(chan error, 1)
+	l := listen(t, func(conn net.Conn) {
+		_, err := server.handshake(conn)
+		serverErr <- err
+	})
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = transport.NewFakeTLS(conn, bytes.Repeat([]byte{0x24}, 16), "example.com")
+	assert.Error(t, err)
+	assert.Equal(t, transport.ErrFakeTLSHandshake, <-serverErr)
+}
+
+func TestFakeTLSClientHelloSize(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+
+	go transport.NewFakeTLS(client, bytes.Repeat([]byte{0x42}, 16), "example.com") //nolint:errcheck
+
+	hello, err := readRecord(server)
+	require.NoError(t, err)
+	assert.Len(t, hello, 517)
+	client.Close()
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/umesproject/mtproto/internal/mode"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+)
+
+// HTTPConnConfig is a config of http transport: every message is sent as body of POST request to /api,
+// and server answers with single message in response body. Unlike tcp, server can't send messages by
+// itself, so client must keep http_wait request open (see Poller).
+// https://core.telegram.org/mtproto/transports#http
+type HTTPConnConfig struct {
+	Ctx  context.Context
+	Host string
+	// TLS enables https
+	TLS bool
+	// Timeout is a maximum time of single request, it must be bigger than max_wait of http_wait.
+	Timeout time.Duration
+	// Dialer is used to open connections (e.g. through proxy). If nil, direct tcp connection is used.
+	Dialer Dialer
+}
+
+// Poller is implemented by transports, which receive messages only as responses to requests. When
+// PollRequired fires, there is no opened requests, so client must send http_wait to let server send
+// pending messages.
+type Poller interface {
+	PollRequired() <-chan struct{}
+}
+
+type httpTransport struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	client *http.Client
+	url    string
+	m      messages.MessageInformator
+
+	incoming chan httpResponse
+	inflight int32
+	poll     chan struct{}
+
+	closeOnce sync.Once
+}
+
+type httpResponse struct {
+	data []byte
+	err  error
+}
+
+var (
+	_ Transport = (*httpTransport)(nil)
+	_ Poller    = (*httpTransport)(nil)
+)
+
+// how much responses could be received, before reader takes them
+const httpIncomingQueueSize = 32
+
+func NewHTTP(m messages.MessageInformator, cfg HTTPConnConfig) (Transport, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("host is empty")
+	}
+
+	ctx := cfg.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+
+	dialer := cfg.Dialer
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+
+	scheme := "http"
+	if cfg.TLS {
+		scheme = "https"
+	}
+
+	t := &httpTransport{
+		ctx:    ctx,
+		cancel: cancel,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: dialer.DialContext,
+			},
+			Timeout: cfg.Timeout,
+		},
+		url:      fmt.Sprintf("%v://%v/api", scheme, cfg.Host),
+		m:        m,
+		incoming: make(chan httpResponse, httpIncomingQueueSize),
+		poll:     make(chan struct{}, 1),
+	}
+	// nothing is requested yet, so server can't send anything
+	t.poll <- struct{}{}
+
+	return t, nil
+}
+
+func (t *httpTransport) PollRequired() <-chan struct{} {
+	return t.poll
+}
+
+func (t *httpTransport) Close() error {
+	t.closeOnce.Do(func() {
+		t.cancel()
+		t.client.CloseIdleConnections()
+	})
+	return nil
+}
+
+// WriteMsg sends message in background: request could be held by server for long time (http_wait), so
+// it must not block sending of other messages.
+func (t *httpTransport) WriteMsg(msg messages.Common, requireToAck bool) error {
+	data, err := serializeMsg(t.m, msg, requireToAck)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-t.ctx.Done():
+		return t.ctx.Err()
+	default:
+	}
+
+	atomic.AddInt32(&t.inflight, 1)
+	go t.post(data)
+
+	return nil
+}
+
+func (t *httpTransport) post(data []byte) {
+	defer func() {
+		if atomic.AddInt32(&t.inflight, -1) == 0 {
+			select {
+			case t.poll <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	body, err := t.do(data)
+	if err == nil && len(body) == 0 {
+		// http_wait expired, server has nothing to send
+		return
+	}
+
+	select {
+	case t.incoming <- httpResponse{data: body, err: err}:
+	case <-t.ctx.Done():
+	}
+}
+
+func (t *httpTransport) do(data []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(t.ctx, http.MethodPost, t.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+	defer resp.Body.Close()
+
+	// one byte more than maximum, so too big body is detected without reading it whole
+	body, err := io.ReadAll(io.LimitReader(resp.Body, mode.MaxMsgSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading response")
+	}
+	if len(body) > mode.MaxMsgSize {
+		return nil, &mode.ErrMsgTooBig{Size: len(body)}
+	}
+
+	// server returns error codes (like -404) with http error status, so body is parsed anyway
+	if resp.StatusCode != http.StatusOK && len(body) == 0 {
+		return nil, fmt.Errorf("unexpected http status: %v", resp.Status)
+	}
+
+	return body, nil
+}
+
+func (t *httpTransport) ReadMsg() (messages.Common, error) {
+	select {
+	case resp := <-t.incoming:
+		if resp.err != nil {
+			return nil, errors.Wrap(resp.err, "reading message")
+		}
+		return parseMsg(t.m, resp.data)
+
+	case <-t.ctx.Done():
+		// same as tcp connection, which is closed by context
+		return nil, context.Canceled
+	}
+}
@@ -0,0 +1,113 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umesproject/mtproto/internal/mode"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/transport"
+)
+
+func TestHTTPTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if len(body) == 0 {
+			// like expired http_wait: nothing to send
+			return
+		}
+		w.Write(body) // echo
+	}))
+	defer srv.Close()
+
+	tr, err := transport.NewTransport(nil, transport.HTTPConnConfig{
+		Ctx:     context.Background(),
+		Host:    strings.TrimPrefix(srv.URL, "http://"),
+		Timeout: time.Second,
+	}, 0)
+	require.NoError(t, err)
+	defer tr.Close()
+
+	poller, ok := tr.(transport.Poller)
+	require.True(t, ok)
+
+	// nothing is sent yet, so transport must ask for polling
+	select {
+	case <-poller.PollRequired():
+	case <-time.After(time.Second):
+		t.Fatal("transport didn't ask for polling")
+	}
+
+	msg := &messages.Unencrypted{
+		Msg:   []byte("some unencrypted message"),
+		MsgID: 1<<32 | 1,
+	}
+	require.NoError(t, tr.WriteMsg(msg, false))
+
+	got, err := tr.ReadMsg()
+	require.NoError(t, err)
+	assert.Equal(t, msg, got)
+
+	// request finished, so transport asks for polling again
+	select {
+	case <-poller.PollRequired():
+	case <-time.After(time.Second):
+		t.Fatal("transport didn't ask for polling")
+	}
+
+	require.NoError(t, tr.Close())
+	_, err = tr.ReadMsg()
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestHTTPTransportErrorCode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte{0x6c, 0xfe, 0xff, 0xff}) // -404
+	}))
+	defer srv.Close()
+
+	tr, err := transport.NewTransport(nil, transport.HTTPConnConfig{
+		Host: strings.TrimPrefix(srv.URL, "http://"),
+	}, 0)
+	require.NoError(t, err)
+	defer tr.Close()
+
+	require.NoError(t, tr.WriteMsg(&messages.Unencrypted{Msg: []byte{1, 2, 3, 4}, MsgID: 1<<32 | 1}, false))
+
+	_, err = tr.ReadMsg()
+	assert.Equal(t, transport.ErrCode(-404), err)
+}
+
+func TestHTTPTransportTooBigResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(make([]byte, mode.MaxMsgSize*2))
+	}))
+	defer srv.Close()
+
+	tr, err := transport.NewTransport(nil, transport.HTTPConnConfig{
+		Host: strings.TrimPrefix(srv.URL, "http://"),
+	}, 0)
+	require.NoError(t, err)
+	defer tr.Close()
+
+	require.NoError(t, tr.WriteMsg(&messages.Unencrypted{Msg: []byte{1, 2, 3, 4}, MsgID: 1<<32 | 1}, false))
+
+	_, err = tr.ReadMsg()
+	var tooBig *mode.ErrMsgTooBig
+	require.True(t, errors.As(err, &tooBig), err)
+	assert.Equal(t, mode.MaxMsgSize+1, tooBig.Size)
+}
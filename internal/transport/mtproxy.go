@@ -0,0 +1,127 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package transport
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const proxySecretLen = 16
+
+const (
+	secretPrefixPadded  = 0xdd
+	secretPrefixFakeTLS = 0xee
+)
+
+// ProxySecret is parsed MTProxy secret.
+type ProxySecret struct {
+	Key []byte
+	// Padded is true for dd and ee prefixed secrets, which forces padded intermediate mode
+	Padded bool
+	// FakeTLSDomain is set for ee-prefixed secrets: connection is wrapped into fake-TLS, which looks like
+	// connection to this domain
+	FakeTLSDomain string
+}
+
+// ParseSecret parses MTProxy secret in hex or base64 (url or std encoding) form. Secret could be plain
+// 16 bytes, prefixed by dd (random padding required) or prefixed by ee and followed by domain (fake-TLS).
+func ParseSecret(secret string) (*ProxySecret, error) {
+	raw, err := decodeSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(raw) == proxySecretLen:
+		return &ProxySecret{Key: raw}, nil
+	case len(raw) == proxySecretLen+1 && raw[0] == secretPrefixPadded:
+		return &ProxySecret{Key: raw[1:], Padded: true}, nil
+	case len(raw) > proxySecretLen+1 && raw[0] == secretPrefixFakeTLS:
+		return &ProxySecret{
+			Key:           raw[1 : proxySecretLen+1],
+			Padded:        true,
+			FakeTLSDomain: string(raw[proxySecretLen+1:]),
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid secret length or prefix: %v bytes", len(raw))
+	}
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	if raw, err := hex.DecodeString(secret); err == nil {
+		return raw, nil
+	}
+
+	for _, enc := range []*base64.Encoding{
+		base64.RawURLEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.StdEncoding,
+	} {
+		if raw, err := enc.DecodeString(secret); err == nil {
+			return raw, nil
+		}
+	}
+
+	return nil, errors.New("secret is neither hex nor base64")
+}
+
+// MTProxy is address and secret of MTProxy server.
+type MTProxy struct {
+	Addr   string
+	Secret *ProxySecret
+}
+
+// IsMTProxyURL checks, that link is MTProxy link (tg://proxy?... or https://t.me/proxy?...).
+func IsMTProxyURL(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	switch u.Scheme {
+	case "tg":
+		return u.Host == "proxy" || u.Opaque == "proxy"
+	case "http", "https":
+		return u.Host == "t.me" && u.Path == "/proxy"
+	default:
+		return false
+	}
+}
+
+// ParseMTProxyURL parses link like tg://proxy?server=host&port=443&secret=...
+func ParseMTProxyURL(link string) (*MTProxy, error) {
+	if !IsMTProxyURL(link) {
+		return nil, fmt.Errorf("'%v' is not MTProxy link", link)
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing url")
+	}
+
+	q := u.Query()
+	server, port := q.Get("server"), q.Get("port")
+	if server == "" || port == "" {
+		return nil, errors.New("server and port are required")
+	}
+
+	secret, err := ParseSecret(q.Get("secret"))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing secret")
+	}
+
+	return &MTProxy{
+		Addr:   net.JoinHostPort(server, port),
+		Secret: secret,
+	}, nil
+}
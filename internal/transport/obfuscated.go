@@ -0,0 +1,196 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package transport
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// ObfuscatedConnConfig wraps any other connection with obfuscated2 protocol: after random 64 byte init
+// header all traffic is encrypted with AES-256-CTR, so it looks like random noise for DPI.
+// https://core.telegram.org/mtproto/mtproto-transports#transport-obfuscation
+type ObfuscatedConnConfig struct {
+	Conn ConnConfig
+	// Secret is MTProxy secret. Could be nil, if connecting directly to telegram server. If secret has
+	// fake-TLS domain, obfuscated stream is wrapped into fake-TLS records.
+	Secret *ProxySecret
+	// DC is id of datacenter, which proxy must connect to.
+	DC int16
+}
+
+const (
+	obfuscatedHeaderLen = 64
+	obfuscatedKeyLen    = 32
+	obfuscatedIVLen     = 16
+	obfuscatedTagOffset = 56
+	obfuscatedDCOffset  = 60
+)
+
+// first 4 bytes of init header must not be similar to other protocols
+var forbiddenHeaderPrefixes = [][]byte{ // meta:immutable
+	[]byte("HEAD"),
+	[]byte("POST"),
+	[]byte("GET "),
+	[]byte("OPTI"),
+	{0x16, 0x03, 0x01, 0x02}, // TLS handshake
+	{0xdd, 0xdd, 0xdd, 0xdd},
+	{0xee, 0xee, 0xee, 0xee},
+}
+
+type obfuscatedConn struct {
+	conn      Conn
+	encryptor cipher.Stream
+	decryptor cipher.Stream
+}
+
+// NewObfuscated sends init header to conn and returns connection, which encrypts all traffic. tag is
+// 4 byte mode tag (see mode.Tag), secret is 16 byte MTProxy secret or nil.
+func NewObfuscated(conn Conn, tag [4]byte, secret []byte, dc int16) (Conn, error) {
+	header, err := generateObfuscatedHeader()
+	if err != nil {
+		return nil, err
+	}
+	copy(header[obfuscatedTagOffset:], tag[:])
+	binary.LittleEndian.PutUint16(header[obfuscatedDCOffset:], uint16(dc))
+
+	encryptor, decryptor, err := obfuscatedCiphers(header, secret, false)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := make([]byte, obfuscatedHeaderLen)
+	encryptor.XORKeyStream(encrypted, header)
+	// only tag and dc id are sent encrypted, keys must be readable by other side
+	copy(header[obfuscatedTagOffset:], encrypted[obfuscatedTagOffset:])
+
+	if _, err := conn.Write(header); err != nil {
+		return nil, errors.Wrap(err, "sending init header")
+	}
+
+	return &obfuscatedConn{
+		conn:      conn,
+		encryptor: encryptor,
+		decryptor: decryptor,
+	}, nil
+}
+
+// AcceptObfuscated reads init header from client and returns encrypted connection with mode tag and dc
+// id, which client requested. It's a server side of NewObfuscated, used by test servers.
+func AcceptObfuscated(conn Conn, secret []byte) (c Conn, tag [4]byte, dc int16, err error) {
+	header := make([]byte, obfuscatedHeaderLen)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, tag, 0, errors.Wrap(err, "reading init header")
+	}
+
+	encryptor, decryptor, err := obfuscatedCiphers(header, secret, true)
+	if err != nil {
+		return nil, tag, 0, err
+	}
+
+	decrypted := make([]byte, obfuscatedHeaderLen)
+	decryptor.XORKeyStream(decrypted, header)
+	copy(tag[:], decrypted[obfuscatedTagOffset:])
+	dc = int16(binary.LittleEndian.Uint16(decrypted[obfuscatedDCOffset:]))
+
+	return &obfuscatedConn{
+		conn:      conn,
+		encryptor: encryptor,
+		decryptor: decryptor,
+	}, tag, dc, nil
+}
+
+func generateObfuscatedHeader() ([]byte, error) {
+	header := make([]byte, obfuscatedHeaderLen)
+	for {
+		if _, err := rand.Read(header); err != nil {
+			return nil, errors.Wrap(err, "generating init header")
+		}
+		if isValidObfuscatedHeader(header) {
+			return header, nil
+		}
+	}
+}
+
+func isValidObfuscatedHeader(header []byte) bool {
+	if header[0] == 0xef { // abridged announcement
+		return false
+	}
+	for _, prefix := range forbiddenHeaderPrefixes {
+		if bytes.HasPrefix(header, prefix) {
+			return false
+		}
+	}
+	// second word must not be zero, otherwise it looks like full mode seqno
+	return binary.LittleEndian.Uint32(header[4:8]) != 0
+}
+
+// obfuscatedCiphers makes AES-CTR streams from init header. Keys of client are reversed keys of server,
+// so server side sets reversed flag. Both sides must pass init header through stream of client (it is
+// encryptor of client and decryptor of server).
+func obfuscatedCiphers(header, secret []byte, reversed bool) (encryptor, decryptor cipher.Stream, err error) {
+	keyIV := header[8:obfuscatedTagOffset]
+	reversedKeyIV := make([]byte, len(keyIV))
+	for i := range keyIV {
+		reversedKeyIV[i] = keyIV[len(keyIV)-1-i]
+	}
+
+	encryptKeyIV, decryptKeyIV := keyIV, reversedKeyIV
+	if reversed {
+		encryptKeyIV, decryptKeyIV = decryptKeyIV, encryptKeyIV
+	}
+
+	encryptor, err = obfuscatedCipher(encryptKeyIV, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	decryptor, err = obfuscatedCipher(decryptKeyIV, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return encryptor, decryptor, nil
+}
+
+func obfuscatedCipher(keyIV, secret []byte) (cipher.Stream, error) {
+	key := keyIV[:obfuscatedKeyLen]
+	iv := keyIV[obfuscatedKeyLen : obfuscatedKeyLen+obfuscatedIVLen]
+
+	if len(secret) > 0 {
+		hash := sha256.Sum256(append(append([]byte{}, key...), secret...))
+		key = hash[:]
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating cipher")
+	}
+
+	return cipher.NewCTR(block, iv), nil
+}
+
+func (c *obfuscatedConn) Write(b []byte) (int, error) {
+	encrypted := make([]byte, len(b))
+	c.encryptor.XORKeyStream(encrypted, b)
+	return c.conn.Write(encrypted)
+}
+
+func (c *obfuscatedConn) Read(b []byte) (int, error) {
+	n, err := c.conn.Read(b)
+	c.decryptor.XORKeyStream(b[:n], b[:n])
+	return n, err
+}
+
+func (c *obfuscatedConn) Close() error {
+	return c.conn.Close()
+}
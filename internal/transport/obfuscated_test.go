@@ -0,0 +1,149 @@
+package transport_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umesproject/mtproto/internal/transport"
+)
+
+func TestObfuscated(t *testing.T) {
+	for _, secret := range [][]byte{
+		nil,
+		bytes.Repeat([]byte{0x42}, 16),
+	} {
+		client, server := net.Pipe()
+
+		type accepted struct {
+			conn transport.Conn
+			tag  [4]byte
+			dc   int16
+			err  error
+		}
+		acceptCh := make(chan accepted)
+		go func() {
+			conn, tag, dc, err := transport.AcceptObfuscated(server, secret)
+			acceptCh <- accepted{conn, tag, dc, err}
+		}()
+
+		tag := [4]byte{0xee, 0xee, 0xee, 0xee}
+		c, err := transport.NewObfuscated(client, tag, secret, -2)
+		require.NoError(t, err)
+
+		s := <-acceptCh
+		require.NoError(t, s.err)
+		assert.Equal(t, tag, s.tag)
+		assert.Equal(t, int16(-2), s.dc)
+
+		go func() {
+			_, _ = c.Write([]byte("hello, server"))
+		}()
+		buf := make([]byte, len("hello, server"))
+		_, err = io.ReadFull(s.conn, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "hello, server", string(buf))
+
+		go func() {
+			_, _ = s.conn.Write([]byte("hello, client"))
+		}()
+		_, err = io.ReadFull(c, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "hello, client", string(buf))
+
+		c.Close()
+		s.conn.Close()
+	}
+}
+
+func TestObfuscatedWrongSecret(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	go func() {
+		_, _ = transport.NewObfuscated(client, [4]byte{0xee, 0xee, 0xee, 0xee}, bytes.Repeat([]byte{1}, 16), 2)
+	}()
+
+	_, tag, _, err := transport.AcceptObfuscated(server, bytes.Repeat([]byte{2}, 16))
+	require.NoError(t, err)
+	assert.NotEqual(t, [4]byte{0xee, 0xee, 0xee, 0xee}, tag)
+}
+
+func TestParseSecret(t *testing.T) {
+	key := bytes.Repeat([]byte{0xab}, 16)
+
+	for _, tt := range []struct {
+		name    string
+		in      string
+		want    *transport.ProxySecret
+		wantErr bool
+	}{
+		{
+			name: "plain hex",
+			in:   hex.EncodeToString(key),
+			want: &transport.ProxySecret{Key: key},
+		},
+		{
+			name: "dd hex",
+			in:   "dd" + hex.EncodeToString(key),
+			want: &transport.ProxySecret{Key: key, Padded: true},
+		},
+		{
+			name: "dd base64",
+			in:   "3aurq6urq6urq6urq6urq6s",
+			want: &transport.ProxySecret{Key: key, Padded: true},
+		},
+		{
+			name: "ee hex",
+			in:   "ee" + hex.EncodeToString(key) + hex.EncodeToString([]byte("google.com")),
+			want: &transport.ProxySecret{Key: key, Padded: true, FakeTLSDomain: "google.com"},
+		},
+		{
+			name:    "too short",
+			in:      "abcdef",
+			wantErr: true,
+		},
+		{
+			name:    "garbage",
+			in:      "not a secret!",
+			wantErr: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transport.ParseSecret(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseMTProxyURL(t *testing.T) {
+	secret := "dd" + hex.EncodeToString(bytes.Repeat([]byte{0xab}, 16))
+
+	for _, link := range []string{
+		"tg://proxy?server=proxy.example.com&port=443&secret=" + secret,
+		"https://t.me/proxy?server=proxy.example.com&port=443&secret=" + secret,
+	} {
+		require.True(t, transport.IsMTProxyURL(link), link)
+
+		p, err := transport.ParseMTProxyURL(link)
+		require.NoError(t, err)
+		assert.Equal(t, "proxy.example.com:443", p.Addr)
+		assert.True(t, p.Secret.Padded)
+	}
+
+	assert.False(t, transport.IsMTProxyURL("socks5://localhost:1080"))
+
+	_, err := transport.ParseMTProxyURL("tg://proxy?server=proxy.example.com&secret=" + secret)
+	assert.Error(t, err)
+}
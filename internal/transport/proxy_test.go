@@ -0,0 +1,218 @@
+package transport_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umesproject/mtproto/internal/transport"
+)
+
+func TestProxyDialer(t *testing.T) {
+	target := listen(t, func(conn net.Conn) {
+		io.Copy(conn, conn) // echo
+	})
+
+	for _, tt := range []struct {
+		name   string
+		server func(t *testing.T) net.Listener
+		url    func(addr string) string
+	}{
+		{
+			name:   "direct",
+			server: nil,
+			url:    func(string) string { return "" },
+		},
+		{
+			name:   "http connect",
+			server: httpConnectServer,
+			url:    func(addr string) string { return "http://user:pass@" + addr },
+		},
+		{
+			name:   "socks5",
+			server: socks5Server,
+			url:    func(addr string) string { return "socks5://user:pass@" + addr },
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var proxyAddr string
+			if tt.server != nil {
+				proxyAddr = tt.server(t).Addr().String()
+			}
+
+			dialer, err := transport.ProxyDialer(tt.url(proxyAddr))
+			require.NoError(t, err)
+
+			conn, err := transport.NewTCP(transport.TCPConnConfig{
+				Ctx:    context.Background(),
+				Host:   target.Addr().String(),
+				Dialer: dialer,
+			})
+			require.NoError(t, err)
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("ping"))
+			require.NoError(t, err)
+
+			buf := make([]byte, 4)
+			_, err = io.ReadFull(conn, buf)
+			require.NoError(t, err)
+			assert.Equal(t, "ping", string(buf))
+		})
+	}
+}
+
+func TestProxyDialerErrors(t *testing.T) {
+	for _, url := range []string{
+		"ftp://localhost:21",
+		"socks5://",
+		"://broken",
+	} {
+		_, err := transport.ProxyDialer(url)
+		assert.Error(t, err, url)
+	}
+
+	proxy := listen(t, func(conn net.Conn) {
+		_, _ = http.ReadRequest(bufio.NewReader(conn))
+		conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+	})
+
+	dialer, err := transport.ProxyDialer("http://" + proxy.Addr().String())
+	require.NoError(t, err)
+	_, err = dialer.DialContext(context.Background(), "tcp", "127.0.0.1:1")
+	assert.Error(t, err)
+}
+
+func listen(t *testing.T, handle func(conn net.Conn)) net.Listener {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+
+	return l
+}
+
+func pipe(a, b net.Conn) {
+	go io.Copy(a, b)
+	io.Copy(b, a)
+}
+
+func httpConnectServer(t *testing.T) net.Listener {
+	return listen(t, func(conn net.Conn) {
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil || req.Method != http.MethodConnect {
+			return
+		}
+		// BasicAuth reads Authorization header, but proxy credentials are in Proxy-Authorization
+		req.Header.Set("Authorization", req.Header.Get("Proxy-Authorization"))
+		if user, pass, _ := req.BasicAuth(); user != "user" || pass != "pass" {
+			conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+			return
+		}
+
+		target, err := net.Dial("tcp", req.Host)
+		if err != nil {
+			return
+		}
+		defer target.Close()
+
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		pipe(conn, target)
+	})
+}
+
+// socks5Server is minimal RFC 1928 server with username/password auth (RFC 1929).
+func socks5Server(t *testing.T) net.Listener {
+	return listen(t, func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+
+		// greeting: ver, nmethods, methods
+		head := make([]byte, 2)
+		if _, err := io.ReadFull(r, head); err != nil {
+			return
+		}
+		if _, err := io.ReadFull(r, make([]byte, head[1])); err != nil {
+			return
+		}
+		conn.Write([]byte{0x05, 0x02}) // username/password
+
+		// auth: ver, ulen, user, plen, pass
+		user, pass := readSocksAuth(r)
+		if user != "user" || pass != "pass" {
+			conn.Write([]byte{0x01, 0x01})
+			return
+		}
+		conn.Write([]byte{0x01, 0x00})
+
+		// request: ver, cmd, rsv, atyp, addr, port
+		req := make([]byte, 4)
+		if _, err := io.ReadFull(r, req); err != nil {
+			return
+		}
+		var host string
+		switch req[3] {
+		case 0x01:
+			ip := make([]byte, net.IPv4len)
+			io.ReadFull(r, ip)
+			host = net.IP(ip).String()
+		case 0x03:
+			l, _ := r.ReadByte()
+			name := make([]byte, l)
+			io.ReadFull(r, name)
+			host = string(name)
+		default:
+			return
+		}
+		port := make([]byte, 2)
+		io.ReadFull(r, port)
+
+		addr := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+		target, err := net.Dial("tcp", addr)
+		if err != nil {
+			conn.Write([]byte{0x05, 0x05, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+			return
+		}
+		defer target.Close()
+
+		conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		pipe(conn, target)
+	})
+}
+
+func readSocksAuth(r *bufio.Reader) (user, pass string) {
+	readString := func() string {
+		l, _ := r.ReadByte()
+		b := make([]byte, l)
+		io.ReadFull(r, b)
+		return string(b)
+	}
+
+	if _, err := r.ReadByte(); err != nil { // version
+		return "", ""
+	}
+	user = readString()
+	pass = readString()
+	return user, pass
+}
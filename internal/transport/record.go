@@ -0,0 +1,365 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/xelaj/go-dry"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+// Frame is a single decrypted message of recorded traffic. Messages inside of containers are recorded as
+// separate frames, so every frame contains exactly one TL object (possibly gzip_packed).
+type Frame struct {
+	// Out is true for messages, which were sent by client
+	Out   bool  `json:"out"`
+	MsgID int64 `json:"msg_id"`
+	SeqNo int32 `json:"seq_no"`
+	// Container is msg_id of container, which contained this message, server could refer to it
+	Container int64 `json:"container,omitempty"`
+	// CRC is a type of Body (after unpacking gzip_packed), it's written only for humans reading recordings
+	CRC  string `json:"crc,omitempty"`
+	Body []byte `json:"body,omitempty"`
+	// Code is a transport error (e.g. -404), which server sent instead of message
+	Code int `json:"code,omitempty"`
+}
+
+// ReadFrames reads recording, which was written by NewRecorder.
+func ReadFrames(r io.Reader) ([]Frame, error) {
+	var frames []Frame
+
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxFrameLineSize)
+	for s.Scan() {
+		if len(bytes.TrimSpace(s.Bytes())) == 0 {
+			continue
+		}
+
+		var f Frame
+		if err := json.Unmarshal(s.Bytes(), &f); err != nil {
+			return nil, errors.Wrapf(err, "decoding frame %d", len(frames))
+		}
+		frames = append(frames, f)
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading frames")
+	}
+
+	return frames, nil
+}
+
+// base64 of biggest message (1 MB) with other fields of frame
+const maxFrameLineSize = 2 * 1024 * 1024
+
+type recorder struct {
+	Transport
+	m messages.MessageInformator
+
+	mutex sync.Mutex
+	enc   *json.Encoder
+}
+
+type pollingRecorder struct {
+	*recorder
+	Poller
+}
+
+// NewRecorder wraps t and writes all encrypted messages, which are passing through it, to w as json lines
+// (see Frame). Handshake messages are not recorded: they can't be replayed anyway, cause nonces are
+// random.
+func NewRecorder(t Transport, m messages.MessageInformator, w io.Writer) Transport {
+	r := &recorder{Transport: t, m: m, enc: json.NewEncoder(w)}
+	if p, ok := t.(Poller); ok {
+		return &pollingRecorder{recorder: r, Poller: p}
+	}
+
+	return r
+}
+
+func (r *recorder) WriteMsg(msg messages.Common, requireToAck bool) error {
+	if encrypted, ok := msg.(*messages.Encrypted); ok {
+		// seqno is set by client during serialization, so it's taken in same way
+		seqNo := r.m.GetSeqNo()
+		if requireToAck {
+			seqNo |= 1
+		}
+		r.record(&Frame{Out: true, MsgID: encrypted.MsgID, SeqNo: seqNo}, encrypted.Msg)
+	}
+
+	return r.Transport.WriteMsg(msg, requireToAck)
+}
+
+func (r *recorder) ReadMsg() (messages.Common, error) {
+	msg, err := r.Transport.ReadMsg()
+	if code, ok := err.(ErrCode); ok {
+		r.write(&Frame{Code: int(code)})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if encrypted, ok := msg.(*messages.Encrypted); ok {
+		r.record(&Frame{MsgID: encrypted.MsgID, SeqNo: encrypted.SeqNo}, encrypted.Msg)
+	}
+
+	return msg, nil
+}
+
+func (r *recorder) record(f *Frame, body []byte) {
+	if container, ok := decodeContainer(body); ok {
+		for _, item := range container {
+			r.record(&Frame{Out: f.Out, MsgID: item.MsgID, SeqNo: item.SeqNo, Container: f.MsgID}, item.Msg)
+		}
+		return
+	}
+
+	f.CRC, f.Body = crcString(bodyCRC(body)), body
+	r.write(f)
+}
+
+func (r *recorder) write(f *Frame) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// recording must not break connection, so broken writer is just ignored
+	_ = r.enc.Encode(f)
+}
+
+type replay struct {
+	mutex  sync.Mutex
+	frames []Frame
+	sent   []bool
+	next   int
+	// recorded msg_id of client message -> msg_id, which was used by client in replay
+	ids map[int64]int64
+
+	written   chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ Transport = (*replay)(nil)
+
+// NewReplay returns transport, which answers client with recorded server messages. Messages are given in
+// same order, as they were recorded, but every message is given only when client sent all messages, which
+// were sent before it in recording. Client messages are matched with recorded ones by type, and msg_ids
+// in server messages (e.g. req_msg_id of rpc_result) are replaced by ids of matched client messages.
+//
+// If client sends message, which type wasn't recorded, WriteMsg returns error.
+func NewReplay(frames []Frame) Transport {
+	return &replay{
+		frames:  frames,
+		sent:    make([]bool, len(frames)),
+		ids:     make(map[int64]int64),
+		written: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (r *replay) Close() error {
+	r.closeOnce.Do(func() { close(r.closed) })
+	return nil
+}
+
+func (r *replay) WriteMsg(msg messages.Common, _ bool) error {
+	select {
+	case <-r.closed:
+		return io.ErrClosedPipe
+	default:
+	}
+
+	encrypted, ok := msg.(*messages.Encrypted)
+	if !ok {
+		return errors.New("handshake can't be replayed, session with auth key is required")
+	}
+
+	items := []*messages.Encrypted{encrypted}
+	if container, ok := decodeContainer(encrypted.Msg); ok {
+		items = container
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, item := range items {
+		if err := r.match(item, encrypted.MsgID); err != nil {
+			return err
+		}
+	}
+
+	// waking up readers, which are waiting for this message
+	close(r.written)
+	r.written = make(chan struct{})
+
+	return nil
+}
+
+// match marks first not sent recorded message of same type as sent. Acks are not matched, cause they
+// depend on timings.
+func (r *replay) match(msg *messages.Encrypted, containerID int64) error {
+	crc := bodyCRC(msg.Msg)
+	if crc == (&objects.MsgsAck{}).CRC() {
+		return nil
+	}
+
+	for i, f := range r.frames {
+		if !f.Out || r.sent[i] || bodyCRC(f.Body) != crc {
+			continue
+		}
+
+		r.sent[i] = true
+		r.ids[f.MsgID] = msg.MsgID
+		if f.Container != 0 && containerID != msg.MsgID {
+			r.ids[f.Container] = containerID
+		}
+		return nil
+	}
+
+	return fmt.Errorf("message %s wasn't recorded", crcString(crc))
+}
+
+func (r *replay) ReadMsg() (messages.Common, error) {
+	for {
+		r.mutex.Lock()
+		f, ok := r.nextFrame()
+		written := r.written
+		r.mutex.Unlock()
+
+		if ok {
+			if f.Code != 0 {
+				return nil, ErrCode(f.Code)
+			}
+			return &messages.Encrypted{Msg: r.replaceIDs(f.Body), MsgID: f.MsgID, SeqNo: f.SeqNo}, nil
+		}
+
+		// waiting for client, or forever, if recording is over
+		select {
+		case <-written:
+		case <-r.closed:
+			return nil, context.Canceled
+		}
+	}
+}
+
+// nextFrame returns next server message, if client already sent everything, what was sent before it.
+// Must be called with locked mutex.
+func (r *replay) nextFrame() (Frame, bool) {
+	for ; r.next < len(r.frames); r.next++ {
+		f := r.frames[r.next]
+		if !f.Out {
+			r.next++
+			return f, true
+		}
+		if !r.sent[r.next] && bodyCRC(f.Body) != (&objects.MsgsAck{}).CRC() {
+			return Frame{}, false
+		}
+	}
+
+	return Frame{}, false
+}
+
+// idReferences are server messages, which first field is msg_id of client message.
+var idReferences = map[uint32]bool{ //nolint:gochecknoglobals constant
+	(&objects.RpcResult{}).CRC():          true, // req_msg_id
+	(&objects.Pong{}).CRC():               true, // msg_id
+	(&objects.FutureSalts{}).CRC():        true, // req_msg_id
+	(&objects.BadServerSalt{}).CRC():      true, // bad_msg_id
+	(&objects.BadMsgNotification{}).CRC(): true, // bad_msg_id
+	(&objects.NewSessionCreated{}).CRC():  true, // first_msg_id
+	(&objects.MsgsStateInfo{}).CRC():      true, // req_msg_id
+	(&objects.MsgsDetailedInfo{}).CRC():   true, // msg_id
+}
+
+// replaceIDs returns copy of server message, where recorded ids of client messages are replaced by real
+// ones.
+func (r *replay) replaceIDs(body []byte) []byte {
+	if len(body) < tl.WordLen {
+		return body
+	}
+	res := append([]byte(nil), body...)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	replace := func(b []byte) {
+		if id, ok := r.ids[int64(binary.LittleEndian.Uint64(b))]; ok {
+			binary.LittleEndian.PutUint64(b, uint64(id))
+		}
+	}
+
+	crc := binary.LittleEndian.Uint32(res)
+	switch {
+	case idReferences[crc] && len(res) >= tl.WordLen+tl.LongLen:
+		replace(res[tl.WordLen:])
+
+	case crc == (&objects.MsgsAck{}).CRC():
+		// crc, vector crc, count, ids
+		const idsOffset = tl.WordLen * 3
+		for i := idsOffset; i+tl.LongLen <= len(res); i += tl.LongLen {
+			replace(res[i:])
+		}
+	}
+
+	return res
+}
+
+// decodeContainer returns messages of msg_container, ok is false, if data is not a container.
+func decodeContainer(data []byte) ([]*messages.Encrypted, bool) {
+	if bodyCRC(data) != (&objects.MessageContainer{}).CRC() {
+		return nil, false
+	}
+
+	obj, err := tl.DecodeUnknownObject(data)
+	if err != nil {
+		return nil, false
+	}
+	container, ok := obj.(*objects.MessageContainer)
+	if !ok {
+		return nil, false
+	}
+
+	return *container, true
+}
+
+// bodyCRC returns type of message, gzip_packed is unpacked.
+func bodyCRC(data []byte) uint32 {
+	if len(data) < tl.WordLen {
+		return 0
+	}
+
+	crc := binary.LittleEndian.Uint32(data)
+	if crc != objects.CrcGzipPacked {
+		return crc
+	}
+
+	d, err := tl.NewDecoder(bytes.NewReader(data[tl.WordLen:]))
+	if err != nil {
+		return crc
+	}
+	unpacked := dry.BytesUnGzip(d.PopMessage())
+	if len(unpacked) < tl.WordLen {
+		return crc
+	}
+
+	return binary.LittleEndian.Uint32(unpacked)
+}
+
+func crcString(crc uint32) string {
+	return "0x" + strconv.FormatUint(uint64(crc), 16)
+}
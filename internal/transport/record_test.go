@@ -0,0 +1,116 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+	"github.com/umesproject/mtproto/internal/transport"
+)
+
+// queueTransport gives messages from queue and forgets written ones.
+type queueTransport struct {
+	incoming []messages.Common
+}
+
+func (*queueTransport) Close() error                         { return nil }
+func (*queueTransport) WriteMsg(messages.Common, bool) error { return nil }
+
+func (t *queueTransport) ReadMsg() (messages.Common, error) {
+	if len(t.incoming) == 0 {
+		return nil, context.Canceled
+	}
+	msg := t.incoming[0]
+	t.incoming = t.incoming[1:]
+	return msg, nil
+}
+
+type informator struct{}
+
+func (informator) GetSessionID() int64  { return 1 }
+func (informator) GetSeqNo() int32      { return 2 }
+func (informator) GetServerSalt() int64 { return 3 }
+func (informator) GetAuthKey() []byte   { return nil }
+
+func marshal(t *testing.T, o tl.Object) []byte {
+	t.Helper()
+
+	data, err := tl.Marshal(o)
+	require.NoError(t, err)
+	return data
+}
+
+func TestRecordReplay(t *testing.T) {
+	ping := marshal(t, &objects.PingParams{PingID: 42})
+	container := objects.MessageContainer{
+		{MsgID: 101, SeqNo: 1, Msg: marshal(t, &objects.Pong{MsgID: 4, PingID: 42})},
+		{MsgID: 105, SeqNo: 2, Msg: marshal(t, &objects.MsgsAck{MsgIDs: []int64{4}})},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	r := transport.NewRecorder(&queueTransport{incoming: []messages.Common{
+		&messages.Encrypted{MsgID: 109, Msg: marshal(t, &container)},
+	}}, informator{}, buf)
+
+	require.NoError(t, r.WriteMsg(&messages.Encrypted{MsgID: 4, Msg: ping}, true))
+	_, err := r.ReadMsg()
+	require.NoError(t, err)
+
+	// container is split into messages
+	frames, err := transport.ReadFrames(buf)
+	require.NoError(t, err)
+	require.Len(t, frames, 3)
+	assert.Equal(t, transport.Frame{Out: true, MsgID: 4, SeqNo: 3, CRC: "0x7abe77ec", Body: ping}, frames[0])
+	assert.Equal(t, int64(101), frames[1].MsgID)
+	assert.Equal(t, "0x347773c5", frames[1].CRC)
+	assert.Equal(t, int64(105), frames[2].MsgID)
+
+	replay := transport.NewReplay(frames)
+	defer replay.Close()
+
+	received := make(chan messages.Common, 2)
+	go func() {
+		for {
+			msg, err := replay.ReadMsg()
+			if err != nil {
+				return
+			}
+			received <- msg
+		}
+	}()
+
+	// pong is given only after ping
+	select {
+	case msg := <-received:
+		t.Fatalf("unexpected message %v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	assert.Error(t, replay.WriteMsg(&messages.Encrypted{MsgID: 8, Msg: marshal(t, &objects.GetFutureSaltsParams{Num: 1})}, true))
+	require.NoError(t, replay.WriteMsg(&messages.Encrypted{MsgID: 1000, Msg: ping}, true))
+
+	// ids of recorded client messages are replaced by real ones
+	for _, expected := range []tl.Object{
+		&objects.Pong{MsgID: 1000, PingID: 42},
+		&objects.MsgsAck{MsgIDs: []int64{1000}},
+	} {
+		select {
+		case msg := <-received:
+			assert.Equal(t, marshal(t, expected), msg.GetMsg())
+		case <-time.After(time.Second):
+			t.Fatal("message is not replayed")
+		}
+	}
+}
@@ -23,6 +23,8 @@ type transport struct {
 	conn Conn
 	mode Mode
 	m    messages.MessageInformator
+	// in padded intermediate mode messages have random tail, which must be cut off
+	padded bool
 }
 
 func NewTransport(m messages.MessageInformator, conn ConnConfig, modeVariant mode.Variant) (Transport, error) {
@@ -31,22 +33,82 @@ func NewTransport(m messages.MessageInformator, conn ConnConfig, modeVariant mod
 	}
 
 	var err error
+	switch cfg := conn.(type) {
+	case HTTPConnConfig:
+		// http is message based protocol, so modes are not required
+		return NewHTTP(m, cfg)
+
+	case ObfuscatedConnConfig:
+		t.conn, modeVariant, err = newObfuscatedConn(cfg, modeVariant)
+		if err != nil {
+			return nil, errors.Wrap(err, "setup connection")
+		}
+
+		// mode tag is already sent in obfuscated header
+		t.mode, err = mode.Wrap(modeVariant, t.conn)
+
+	default:
+		t.conn, err = newConn(conn)
+		if err != nil {
+			return nil, errors.Wrap(err, "setup connection")
+		}
+
+		t.mode, err = mode.New(modeVariant, t.conn)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "setup mode")
+	}
+	t.padded = modeVariant == mode.PaddedIntermediate
+
+	return t, nil
+}
+
+func newConn(conn ConnConfig) (Conn, error) {
 	switch cfg := conn.(type) {
 	case TCPConnConfig:
-		t.conn, err = NewTCP(cfg)
+		return NewTCP(cfg)
+	case WebSocketConnConfig:
+		return NewWebSocket(cfg)
 	default:
 		return nil, fmt.Errorf("unsupported connection type %v", reflect.TypeOf(conn).String())
 	}
+}
+
+func newObfuscatedConn(cfg ObfuscatedConnConfig, modeVariant mode.Variant) (Conn, mode.Variant, error) {
+	var secret []byte
+	if cfg.Secret != nil {
+		secret = cfg.Secret.Key
+		if cfg.Secret.Padded {
+			modeVariant = mode.PaddedIntermediate
+		}
+	}
+
+	tag, err := mode.Tag(modeVariant)
 	if err != nil {
-		return nil, errors.Wrap(err, "setup connection")
+		return nil, 0, errors.Wrap(err, "getting mode tag")
 	}
 
-	t.mode, err = mode.New(modeVariant, t.conn)
+	conn, err := newConn(cfg.Conn)
 	if err != nil {
-		return nil, errors.Wrap(err, "setup mode")
+		return nil, 0, err
 	}
 
-	return t, nil
+	if cfg.Secret != nil && cfg.Secret.FakeTLSDomain != "" {
+		tlsConn, err := NewFakeTLS(conn, cfg.Secret.Key, cfg.Secret.FakeTLSDomain)
+		if err != nil {
+			conn.Close()
+			return nil, 0, errors.Wrap(err, "setup fake-TLS")
+		}
+		conn = tlsConn
+	}
+
+	obfuscated, err := NewObfuscated(conn, tag, secret, cfg.DC)
+	if err != nil {
+		conn.Close()
+		return nil, 0, errors.Wrap(err, "setup obfuscation")
+	}
+
+	return obfuscated, modeVariant, nil
 }
 
 func (t *transport) Close() error {
@@ -54,23 +116,12 @@ func (t *transport) Close() error {
 }
 
 func (t *transport) WriteMsg(msg messages.Common, requireToAck bool) error {
-	var data []byte
-	switch message := msg.(type) {
-	case *messages.Unencrypted:
-		data, _ = message.Serialize(t.m)
-
-	case *messages.Encrypted:
-		var err error
-		data, err = message.Serialize(t.m, requireToAck)
-		if err != nil {
-			return errors.Wrap(err, "serializing message")
-		}
-
-	default:
-		return fmt.Errorf("supported only mtproto predefined messages, got %v", reflect.TypeOf(msg).String())
+	data, err := serializeMsg(t.m, msg, requireToAck)
+	if err != nil {
+		return err
 	}
 
-	err := t.mode.WriteMsg(data)
+	err = t.mode.WriteMsg(data)
 	if err != nil {
 		return errors.Wrap(err, "sending request")
 	}
@@ -88,15 +139,43 @@ func (t *transport) ReadMsg() (messages.Common, error) {
 		}
 	}
 
+	if t.padded {
+		data = TrimPadding(data)
+	}
+
+	return parseMsg(t.m, data)
+}
+
+func serializeMsg(m messages.MessageInformator, msg messages.Common, requireToAck bool) ([]byte, error) {
+	switch message := msg.(type) {
+	case *messages.Unencrypted:
+		return message.Serialize(m)
+
+	case *messages.Encrypted:
+		data, err := message.Serialize(m, requireToAck)
+		if err != nil {
+			return nil, errors.Wrap(err, "serializing message")
+		}
+		return data, nil
+
+	default:
+		return nil, fmt.Errorf("supported only mtproto predefined messages, got %v", reflect.TypeOf(msg).String())
+	}
+}
+
+func parseMsg(m messages.MessageInformator, data []byte) (messages.Common, error) {
 	// checking that response is not error code
 	if len(data) == tl.WordLen {
-		code := int(binary.LittleEndian.Uint32(data))
+		code := int(int32(binary.LittleEndian.Uint32(data))) // error codes are negative
 		return nil, ErrCode(code)
 	}
 
-	var msg messages.Common
+	var (
+		msg messages.Common
+		err error
+	)
 	if isPacketEncrypted(data) {
-		msg, err = messages.DeserializeEncrypted(data, t.m.GetAuthKey())
+		msg, err = messages.DeserializeEncrypted(data, m.GetAuthKey())
 	} else {
 		msg, err = messages.DeserializeUnencrypted(data)
 	}
@@ -125,3 +204,35 @@ type ErrCode int
 func (e ErrCode) Error() string {
 	return fmt.Sprintf("code %v", int(e))
 }
+
+// minimal size of message: 8 byte auth key id, 8 byte msg id and 4 byte length of unencrypted message
+const minMessageLen = tl.DoubleLen*2 + tl.WordLen
+
+// TrimPadding cuts off random padding of padded intermediate mode, using message structure to get real
+// size of message.
+func TrimPadding(data []byte) []byte {
+	switch {
+	case len(data) < minMessageLen:
+		// error code (negative int32)
+		if len(data) >= tl.WordLen {
+			return data[:tl.WordLen]
+		}
+		return data
+
+	case isPacketEncrypted(data):
+		// auth key id, msg key, then encrypted data aligned by aes block
+		const headerLen = tl.DoubleLen + 16
+		if len(data) < headerLen {
+			return data
+		}
+		body := len(data) - headerLen
+		return data[:headerLen+body-body%16]
+
+	default:
+		size := int(binary.LittleEndian.Uint32(data[tl.DoubleLen*2:]))
+		if minMessageLen+size > len(data) {
+			return data
+		}
+		return data[:minMessageLen+size]
+	}
+}
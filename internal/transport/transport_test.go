@@ -0,0 +1,94 @@
+package transport_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umesproject/mtproto/internal/mode"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/transport"
+)
+
+func TestTransportModes(t *testing.T) {
+	for _, variant := range []mode.Variant{
+		mode.Abridged,
+		mode.Intermediate,
+		mode.PaddedIntermediate,
+		mode.Full,
+	} {
+		variant := variant
+		t.Run(modeName(variant), func(t *testing.T) {
+			detected := make(chan mode.Variant, 1)
+			l := listen(t, func(conn net.Conn) {
+				m, err := mode.Detect(conn)
+				if err != nil {
+					return
+				}
+				v, _ := mode.GetVariant(m)
+				detected <- v
+
+				for {
+					// echo, padded intermediate will add its own padding to client one
+					msg, err := m.ReadMsg()
+					if err != nil {
+						return
+					}
+					if err := m.WriteMsg(msg); err != nil {
+						return
+					}
+				}
+			})
+
+			tr, err := transport.NewTransport(nil, transport.TCPConnConfig{
+				Ctx:  context.Background(),
+				Host: l.Addr().String(),
+			}, variant)
+			require.NoError(t, err)
+			defer tr.Close()
+
+			for i := 0; i < 5; i++ {
+				msg := &messages.Unencrypted{
+					Msg:   []byte("some unencrypted message"),
+					MsgID: int64(i)<<32 | 1,
+				}
+				require.NoError(t, tr.WriteMsg(msg, false))
+
+				got, err := tr.ReadMsg()
+				require.NoError(t, err)
+				assert.Equal(t, msg, got)
+			}
+			assert.Equal(t, variant, <-detected)
+		})
+	}
+}
+
+func modeName(v mode.Variant) string {
+	return map[mode.Variant]string{
+		mode.Abridged:           "abridged",
+		mode.Intermediate:       "intermediate",
+		mode.PaddedIntermediate: "padded intermediate",
+		mode.Full:               "full",
+	}[v]
+}
+
+func TestTCPReadAfterCancel(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	conn, err := transport.NewTCP(transport.TCPConnConfig{Ctx: ctx, Host: l.Addr().String(), Timeout: time.Minute})
+	require.NoError(t, err)
+
+	// client closes connection on cancel, reading must stop with ctx error every time
+	cancel()
+	require.NoError(t, conn.Close())
+	for i := 0; i < 2; i++ {
+		_, err = conn.Read(make([]byte, 4))
+		assert.Equal(t, context.Canceled, err)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// MsgIDGenerator generates msg_id for client messages. Each generated id is strictly bigger than previous
+// one, even if local clock goes back or few messages are sent at the same nanosecond. Time offset allows
+// to generate ids in server time, cause server rejects messages with msg_id too far from it's own clock.
+// https://core.telegram.org/mtproto/description#message-identifier-msg-id
+type MsgIDGenerator struct {
+	mutex  sync.Mutex
+	last   int64
+	offset int64 // in seconds
+
+	now func() time.Time
+}
+
+func NewMsgIDGenerator() *MsgIDGenerator {
+	return &MsgIDGenerator{now: time.Now}
+}
+
+// Next returns new msg_id, which is bigger than all previously generated.
+func (g *MsgIDGenerator) Next() int64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	id := messageIDFromTime(g.now().Add(time.Duration(g.offset) * time.Second))
+	if id <= g.last {
+		// client messages msg_id must be divisible by 4
+		id = g.last + 4
+	}
+	g.last = id
+
+	return id
+}
+
+// TimeOffset returns difference between server and local time in seconds.
+func (g *MsgIDGenerator) TimeOffset() int64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.offset
+}
+
+// SetTimeOffset sets difference between server and local time in seconds (e.g. stored in session).
+func (g *MsgIDGenerator) SetTimeOffset(offset int64) {
+	g.mutex.Lock()
+	g.offset = offset
+	g.mutex.Unlock()
+}
+
+// ServerTime returns current time of server, calculated with time offset.
+func (g *MsgIDGenerator) ServerTime() time.Time {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.now().Add(time.Duration(g.offset) * time.Second)
+}
+
+// SetServerTime calculates time offset from current unix time of server.
+func (g *MsgIDGenerator) SetServerTime(serverTime int64) {
+	g.SetTimeOffset(serverTime - g.now().Unix())
+}
+
+// SyncWithServerMsgID calculates time offset from msg_id of the fresh message from server (first 32
+// bits of msg_id are unix time of the moment when message was created).
+func (g *MsgIDGenerator) SyncWithServerMsgID(msgID int64) {
+	g.SetServerTime(msgID >> 32) //nolint:gomnd not magic
+}
+
+func messageIDFromTime(t time.Time) int64 {
+	const billion = 1000 * 1000 * 1000
+	unixnano := t.UnixNano()
+	seconds := unixnano / billion
+	nanoseconds := unixnano % billion
+	return (seconds << 32) | (nanoseconds & -4)
+}
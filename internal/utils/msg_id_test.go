@@ -0,0 +1,74 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package utils
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgIDGenerator_Monotonic(t *testing.T) {
+	frozen := time.Unix(1600000000, 0)
+
+	g := NewMsgIDGenerator()
+	g.now = func() time.Time { return frozen }
+
+	first := g.Next()
+	second := g.Next()
+	assert.Equal(t, first+4, second)
+
+	// clock goes back, but ids must not
+	frozen = frozen.Add(-time.Hour)
+	third := g.Next()
+	assert.Greater(t, third, second)
+	assert.Zero(t, third%4)
+}
+
+func TestMsgIDGenerator_Concurrent(t *testing.T) {
+	g := NewMsgIDGenerator()
+
+	const workers, perWorker = 8, 1000
+	ids := make(chan int64, workers*perWorker)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				ids <- g.Next()
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[int64]null, workers*perWorker)
+	for id := range ids {
+		_, duplicated := seen[id]
+		require.False(t, duplicated, "duplicated msg_id %v", id)
+		seen[id] = null{}
+	}
+}
+
+func TestMsgIDGenerator_TimeOffset(t *testing.T) {
+	local := time.Unix(1600000000, 0)
+
+	g := NewMsgIDGenerator()
+	g.now = func() time.Time { return local }
+
+	// server is 30 seconds ahead
+	g.SyncWithServerMsgID((local.Unix() + 30) << 32)
+	assert.Equal(t, int64(30), g.TimeOffset())
+	assert.Equal(t, local.Unix()+30, g.Next()>>32)
+
+	g.SetServerTime(local.Unix() - 10)
+	assert.Equal(t, int64(-10), g.TimeOffset())
+}
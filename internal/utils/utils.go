@@ -17,13 +17,10 @@ import (
 )
 
 // GenerateMessageId отдает по сути unix timestamp но ужасно специфическим образом
-// TODO: нахуя нужно битовое и на -4??
+// младшие два бита обнулены, т.к. msg_id клиентских сообщений должен делиться на 4.
+// не гарантирует уникальность, для отправки сообщений используй MsgIDGenerator
 func GenerateMessageId() int64 {
-	const billion = 1000 * 1000 * 1000
-	unixnano := time.Now().UnixNano()
-	seconds := unixnano / billion
-	nanoseconds := unixnano % billion
-	return (seconds << 32) | (nanoseconds & -4)
+	return messageIDFromTime(time.Now())
 }
 
 func AuthKeyHash(key []byte) []byte {
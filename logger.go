@@ -0,0 +1,150 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// LogLevel is importance of log message.
+type LogLevel int8
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", l)
+	}
+}
+
+// Field is a key-value pair, which is attached to log message, e.g. id of datacenter or msg_id.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// FieldDC is id of datacenter.
+func FieldDC(dc int) Field { return Field{Key: "dc", Value: dc} }
+
+// FieldMsgID is id of mtproto message.
+func FieldMsgID(id int64) Field { return Field{Key: "msg_id", Value: id} }
+
+// FieldMethod is name of request type, e.g. HelpGetConfigParams.
+func FieldMethod(request any) Field {
+	t := reflect.TypeOf(request)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	name := "<nil>"
+	if t != nil {
+		name = t.Name()
+	}
+	return Field{Key: "method", Value: name}
+}
+
+// FieldError is an error, which caused log message.
+func FieldError(err error) Field { return Field{Key: "error", Value: err} }
+
+// Logger receives all messages of library. Implementations must be safe for concurrent use. Use NopLogger
+// to disable logging, or NewStdLogger to write messages through standard log package. Adapters for other
+// libraries (zap, logrus, etc.) could be written in few lines.
+type Logger interface {
+	Log(level LogLevel, msg string, fields ...Field)
+}
+
+// LevelEnabler could be implemented by Logger to skip building of fields, which are expensive to compute
+// (e.g. they use reflection), for messages, which will be dropped anyway.
+type LevelEnabler interface {
+	Enabled(level LogLevel) bool
+}
+
+// logEnabled returns false only if l implements LevelEnabler and drops messages of level.
+func logEnabled(l Logger, level LogLevel) bool {
+	if e, ok := l.(LevelEnabler); ok {
+		return e.Enabled(level)
+	}
+	return true
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(LogLevel, string, ...Field) {}
+
+func (nopLogger) Enabled(LogLevel) bool { return false }
+
+// NopLogger returns logger, which ignores all messages. It's used by default.
+func NopLogger() Logger { return nopLogger{} }
+
+// dcLogger adds id of connection's datacenter to all messages, so logs of connections to different DCs
+// could be told apart. dc is changed by migration, so it's read atomically.
+type dcLogger struct {
+	l  Logger
+	dc *int32
+}
+
+func (d *dcLogger) Enabled(level LogLevel) bool {
+	return logEnabled(d.l, level)
+}
+
+func (d *dcLogger) Log(level LogLevel, msg string, fields ...Field) {
+	withDC := make([]Field, 0, len(fields)+1)
+	withDC = append(withDC, FieldDC(int(atomic.LoadInt32(d.dc))))
+	d.l.Log(level, msg, append(withDC, fields...)...)
+}
+
+type stdLogger struct {
+	l     *log.Logger
+	level LogLevel
+}
+
+// NewStdLogger writes messages with level not lower than minLevel to l as
+// "LEVEL message key=value key=value". If l is nil, standard logger of log package is used.
+func NewStdLogger(l *log.Logger, minLevel LogLevel) Logger {
+	if l == nil {
+		l = log.New(log.Writer(), log.Prefix(), log.Flags())
+	}
+
+	return &stdLogger{l: l, level: minLevel}
+}
+
+func (s *stdLogger) Enabled(level LogLevel) bool {
+	return level >= s.level
+}
+
+func (s *stdLogger) Log(level LogLevel, msg string, fields ...Field) {
+	if !s.Enabled(level) {
+		return
+	}
+
+	b := strings.Builder{}
+	b.WriteString(level.String())
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	for _, f := range fields {
+		fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+	}
+
+	s.l.Println(b.String())
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+func TestStdLogger(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	l := NewStdLogger(log.New(buf, "", 0), LevelInfo)
+
+	l.Log(LevelDebug, "hidden")
+	l.Log(LevelWarn, "sending request", FieldDC(2), FieldMsgID(44), FieldMethod(&objects.PingParams{}))
+
+	assert.Equal(t, "WARN sending request dc=2 msg_id=44 method=PingParams\n", buf.String())
+}
+
+type logEntry struct {
+	level  LogLevel
+	msg    string
+	fields []Field
+}
+
+type memoryLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *memoryLogger) Log(level LogLevel, msg string, fields ...Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, fields: fields})
+}
+
+func TestConfigLogger(t *testing.T) {
+	l := &memoryLogger{}
+	m, err := NewMTProto(Config{Logger: l, DC: 4})
+	require.NoError(t, err)
+
+	// every message of connection has id of its dc
+	warning := errors.New("something happened")
+	m.warnError(warning)
+	assert.Equal(t, []logEntry{{level: LevelWarn, msg: "warning", fields: []Field{FieldDC(4), FieldError(warning)}}}, l.entries)
+
+	// debug output goes to logger too, but only in debug mode
+	_, _ = m.DebugPrintf("hidden\n")
+	assert.Len(t, l.entries, 1)
+
+	m, err = NewMTProto(Config{Logger: l, Debug: true})
+	require.NoError(t, err)
+	_, _ = m.DebugPrintf("value is %d\n", 42)
+	require.Len(t, l.entries, 2)
+	assert.Equal(t, logEntry{level: LevelDebug, msg: "value is 42", fields: []Field{FieldDC(defaultDC)}}, l.entries[1])
+
+	// code on top of client writes with dc too
+	m.Logger().Log(LevelInfo, "custom")
+	assert.Equal(t, logEntry{level: LevelInfo, msg: "custom", fields: []Field{FieldDC(defaultDC)}}, l.entries[2])
+
+	// without logger nothing is written
+	m, err = NewMTProto(Config{})
+	require.NoError(t, err)
+	assert.False(t, logEnabled(m.Logger(), LevelError))
+}
+
+func TestLogEnabled(t *testing.T) {
+	assert.False(t, logEnabled(NopLogger(), LevelError))
+
+	l := NewStdLogger(log.New(bytes.NewBuffer(nil), "", 0), LevelInfo)
+	assert.False(t, logEnabled(l, LevelDebug))
+	assert.True(t, logEnabled(l, LevelInfo))
+
+	// loggers without LevelEnabler receive everything
+	assert.True(t, logEnabled(&memoryLogger{}, LevelDebug))
+}
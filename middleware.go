@@ -0,0 +1,88 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+)
+
+// Object is any TL object: request or response. It allows to write middlewares outside of this module.
+type Object = tl.Object
+
+// Invoker sends request to server and returns its response.
+type Invoker func(ctx context.Context, req Object) (any, error)
+
+// Middleware wraps invocation of requests: it could inspect or change request, response and error, measure
+// time, or don't call next at all (e.g. to return cached response). Middleware sees request once per
+// connection: resending of request by connection itself (after flood wait, migration, reconnection, etc.)
+// happens inside of next. If request is sent again through other connection (e.g. telegram.Client
+// redirects it to other DC), middlewares of that connection see it too.
+type Middleware func(next Invoker) Invoker
+
+// Use adds middlewares to chain, which is applied to all requests made by MakeRequest and similar
+// methods, including service requests of client (ping, get_future_salts). Requests of key exchange
+// (req_pq_multi, req_DH_params, set_client_DH_params, auth.bindTempAuthKey) and session init request
+// (see SetSessionInitRequest) don't pass through middlewares: they are sent, while connection is creating
+// and all other requests are waiting for it. First added middleware is outermost: it's called first and
+// receives response last.
+func (m *MTProto) Use(middlewares ...Middleware) {
+	m.middlewaresMutex.Lock()
+	defer m.middlewaresMutex.Unlock()
+
+	m.middlewares = append(m.middlewares, middlewares...)
+}
+
+// invoke sends request through middlewares chain.
+func (m *MTProto) invoke(ctx context.Context, req tl.Object, expectedTypes ...reflect.Type) (any, error) {
+	invoker := Invoker(func(ctx context.Context, req Object) (any, error) {
+		return m.makeRequest(ctx, req, expectedTypes...)
+	})
+
+	m.middlewaresMutex.RLock()
+	for i := len(m.middlewares) - 1; i >= 0; i-- {
+		invoker = m.middlewares[i](invoker)
+	}
+	m.middlewaresMutex.RUnlock()
+
+	return invoker(ctx, req)
+}
+
+// LoggingMiddleware writes every request to l with its method, crc, duration and error (if any). Successful
+// requests are written with LevelDebug, failed ones with LevelWarn.
+func LoggingMiddleware(l Logger) Middleware {
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, req Object) (any, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			level := LevelDebug
+			if err != nil {
+				level = LevelWarn
+			}
+			if !logEnabled(l, level) {
+				return resp, err
+			}
+
+			fields := []Field{
+				FieldMethod(req),
+				{Key: "crc", Value: fmt.Sprintf("0x%08x", req.CRC())},
+				{Key: "duration", Value: time.Since(start)},
+			}
+			if err != nil {
+				l.Log(level, "request failed", append(fields, FieldError(err))...)
+			} else {
+				l.Log(level, "request completed", fields...)
+			}
+
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+func TestMiddlewaresOrder(t *testing.T) {
+	m, err := NewMTProto(Config{})
+	require.NoError(t, err)
+
+	var calls []string
+	named := func(name string) Middleware {
+		return func(next Invoker) Invoker {
+			return func(ctx context.Context, req tl.Object) (any, error) {
+				calls = append(calls, name+" before")
+				resp, err := next(ctx, req)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+	// answers without network, like cache does
+	cache := func(Invoker) Invoker {
+		return func(_ context.Context, req tl.Object) (any, error) {
+			return &objects.Pong{PingID: req.(*objects.PingParams).PingID}, nil
+		}
+	}
+
+	m.Use(named("first"), named("second"))
+	m.Use(cache)
+
+	resp, err := m.MakeRequest(&objects.PingParams{PingID: 42})
+	require.NoError(t, err)
+	assert.Equal(t, &objects.Pong{PingID: 42}, resp)
+	assert.Equal(t, []string{"first before", "second before", "second after", "first after"}, calls)
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	l := &memoryLogger{}
+	failure := errors.New("failure")
+	invoke := LoggingMiddleware(l)(func(context.Context, tl.Object) (any, error) {
+		return nil, failure
+	})
+
+	_, err := invoke(context.Background(), &objects.PingParams{})
+	assert.Equal(t, failure, err)
+
+	require.Len(t, l.entries, 1)
+	entry := l.entries[0]
+	assert.Equal(t, LevelWarn, entry.level)
+	assert.Equal(t, "request failed", entry.msg)
+	require.Len(t, entry.fields, 4)
+	assert.Equal(t, FieldMethod(&objects.PingParams{}), entry.fields[0])
+	assert.Equal(t, Field{Key: "crc", Value: "0x7abe77ec"}, entry.fields[1])
+	assert.Equal(t, "duration", entry.fields[2].Key)
+	assert.IsType(t, time.Duration(0), entry.fields[2].Value)
+	assert.Equal(t, FieldError(failure), entry.fields[3])
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+// how many ids of received messages are remembered for msgs_state_req answers
+const receivedHistorySize = 1024
+
+// states of messages, which are described in msgs_state_info
+// https://core.telegram.org/mtproto/service_messages_about_messages#request-for-message-status
+const (
+	msgStateUnknown     byte = 1 // nothing is known about the message (msg_id too low)
+	msgStateNotReceived byte = 2 // msg_id is in range of stored ids, but message was not received
+	msgStateTooHigh     byte = 3 // msg_id is too high, message was not received yet
+	msgStateReceived    byte = 4
+
+	msgStateAcked         byte = 8  // flag: message already acknowledged
+	msgStateNotRequireAck byte = 16 // flag: message doesn't require acknowledgment
+)
+
+// receivedMessages remembers ids of recently received messages.
+type receivedMessages struct {
+	mutex sync.Mutex
+	ids   map[int64]int32 // msg_id -> seqno
+	order []int64
+	// messages, which msgs_ack was already sent for
+	acked map[int64]bool
+	// biggest received msg_id
+	highest int64
+	// biggest msg_id, which was removed from history
+	forgotten int64
+}
+
+func (r *receivedMessages) add(msgID int64, seqNo int32) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.ids == nil {
+		r.ids = make(map[int64]int32)
+	}
+	if _, ok := r.ids[msgID]; ok {
+		return
+	}
+
+	if len(r.order) >= receivedHistorySize {
+		if r.order[0] > r.forgotten {
+			r.forgotten = r.order[0]
+		}
+		delete(r.ids, r.order[0])
+		delete(r.acked, r.order[0])
+		r.order = r.order[1:]
+	}
+	r.ids[msgID] = seqNo
+	r.order = append(r.order, msgID)
+	if msgID > r.highest {
+		r.highest = msgID
+	}
+}
+
+func (r *receivedMessages) has(msgID int64) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, ok := r.ids[msgID]
+	return ok
+}
+
+// markAcked remembers, that msgs_ack with these messages was sent to server.
+func (r *receivedMessages) markAcked(msgIDs []int64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.acked == nil {
+		r.acked = make(map[int64]bool)
+	}
+	for _, id := range msgIDs {
+		if _, ok := r.ids[id]; ok {
+			r.acked[id] = true
+		}
+	}
+}
+
+// state returns state of message in format of msgs_state_info.
+func (r *receivedMessages) state(msgID int64) byte {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if seqNo, ok := r.ids[msgID]; ok {
+		switch {
+		case seqNo&1 == 0:
+			return msgStateReceived | msgStateNotRequireAck
+		case r.acked[msgID]:
+			return msgStateReceived | msgStateAcked
+		default:
+			// ack is still waiting in outbox
+			return msgStateReceived
+		}
+	}
+
+	switch {
+	case msgID <= r.forgotten:
+		return msgStateUnknown
+	case msgID > r.highest:
+		return msgStateTooHigh
+	default:
+		return msgStateNotReceived
+	}
+}
+
+func (r *receivedMessages) stateInfo(reqMsgID int64, ids []int64) *objects.MsgsStateInfo {
+	info := make([]byte, len(ids))
+	for i, id := range ids {
+		info[i] = r.state(id)
+	}
+
+	return &objects.MsgsStateInfo{ReqMsgID: reqMsgID, Info: info}
+}
+
+// handleMsgsStateReq answers to server, which of its messages were received.
+func (m *MTProto) handleMsgsStateReq(reqMsgID int64, message *objects.MsgsStateReq) {
+	err := m.queueServiceMessage(m.received.stateInfo(reqMsgID, message.MsgIDs))
+	if err != nil {
+		m.warnError(errors.Wrap(err, "answering msgs_state_req"))
+	}
+}
+
+// handleMsgResendReq resends requested messages. If some of them are unknown, server receives
+// msgs_state_info for all requested messages, as it was msgs_state_req.
+func (m *MTProto) handleMsgResendReq(reqMsgID int64, message *objects.MsgResendReq) {
+	allFound, err := m.resendMessages(message.MsgIDs)
+	if err != nil {
+		m.warnError(errors.Wrap(err, "resending messages"))
+	}
+	if allFound {
+		return
+	}
+
+	err = m.queueServiceMessage(m.received.stateInfo(reqMsgID, message.MsgIDs))
+	if err != nil {
+		m.warnError(errors.Wrap(err, "answering msg_resend_req"))
+	}
+}
+
+// handleMsgsAllInfo resends messages, which server didn't receive.
+func (m *MTProto) handleMsgsAllInfo(message *objects.MsgsAllInfo) {
+	lost := make([]int64, 0)
+	for i, id := range message.MsgIDs {
+		if i >= len(message.Info) {
+			break
+		}
+
+		switch message.Info[i] & 7 {
+		case msgStateNotReceived, msgStateTooHigh:
+			lost = append(lost, id)
+		}
+	}
+	if len(lost) == 0 {
+		return
+	}
+
+	// unknown messages are ignored: server just informs about state, it doesn't wait for answer
+	if _, err := m.resendMessages(lost); err != nil {
+		m.warnError(errors.Wrap(err, "resending messages"))
+	}
+}
+
+// handleDetailedInfo acknowledges answer, if it was received, or asks server to send it again.
+// https://core.telegram.org/mtproto/service_messages_about_messages#extended-voluntary-communication-of-status-of-one-message
+func (m *MTProto) handleDetailedInfo(answerMsgID int64) {
+	if m.received.has(answerMsgID) {
+		m.queueAck(answerMsgID)
+		return
+	}
+
+	err := m.queueServiceMessage(&objects.MsgResendReq{MsgIDs: []int64{answerMsgID}})
+	if err != nil {
+		m.warnError(errors.Wrap(err, "requesting resend of answer"))
+	}
+}
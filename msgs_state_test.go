@@ -0,0 +1,127 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+func serverMessage(t *testing.T, msgID int64, seqNo int32, obj tl.Object) *messages.Encrypted {
+	t.Helper()
+
+	data, err := tl.Marshal(obj)
+	require.NoError(t, err)
+	return &messages.Encrypted{Msg: data, MsgID: msgID, SeqNo: seqNo}
+}
+
+func TestReceivedMessagesState(t *testing.T) {
+	var r receivedMessages
+	assert.Equal(t, msgStateTooHigh, r.state(4*1+1))
+
+	r.add(4*10+1, 1)
+	r.add(4*11+1, 2)
+	assert.Equal(t, msgStateReceived, r.state(4*10+1), "ack is not sent yet")
+	assert.Equal(t, msgStateReceived|msgStateNotRequireAck, r.state(4*11+1))
+	r.markAcked([]int64{4*10 + 1})
+	assert.Equal(t, msgStateReceived|msgStateAcked, r.state(4*10+1))
+	assert.Equal(t, msgStateNotReceived, r.state(4*5+1))
+	assert.Equal(t, msgStateTooHigh, r.state(4*12+1))
+
+	for i := 0; i < receivedHistorySize; i++ {
+		r.add(int64(4*(100+i)+1), 1)
+	}
+	assert.Equal(t, msgStateUnknown, r.state(4*10+1), "forgotten message")
+	assert.Equal(t, msgStateUnknown, r.state(4*5+1))
+}
+
+func TestMsgsStateReq(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	require.NoError(t, m.processResponse(serverMessage(t, 4*10+1, 1, &objects.PingParams{PingID: 1})))
+	require.NoError(t, m.processResponse(serverMessage(t, 4*20+1, 3, &objects.MsgsStateReq{
+		MsgIDs: []int64{4*10 + 1, 4*15 + 1, 4*30 + 1},
+	})))
+	require.NoError(t, m.flushOutbox())
+	waitWritten(t, tr, 1)
+
+	assert.Contains(t, tr.decoded(t), &objects.MsgsStateInfo{
+		ReqMsgID: 4*20 + 1,
+		Info: []byte{
+			// ack of ping is sent in same container, after answer was made
+			msgStateReceived,
+			msgStateNotReceived,
+			msgStateTooHigh,
+		},
+	})
+	assert.Equal(t, msgStateReceived|msgStateAcked, m.received.state(4*10+1))
+}
+
+func TestMsgResendReq(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	_, msgID, err := m.sendPacket(&objects.PingParams{PingID: 1})
+	require.NoError(t, err)
+	require.NoError(t, m.flushOutbox())
+	waitWritten(t, tr, 1)
+
+	tr.mu.Lock()
+	sent := (*mustDecode(t, tr.written[0].GetMsg()).(*objects.MessageContainer))[0]
+	tr.mu.Unlock()
+
+	require.NoError(t, m.processResponse(serverMessage(t, 4*20+1, 1, &objects.MsgResendReq{
+		MsgIDs: []int64{msgID},
+	})))
+	require.NoError(t, m.flushOutbox())
+	waitWritten(t, tr, 2)
+
+	tr.mu.Lock()
+	resent := (*mustDecode(t, tr.written[1].GetMsg()).(*objects.MessageContainer))[0]
+	tr.mu.Unlock()
+	assert.Equal(t, sent, resent, "message must be resent with same msg_id and seqno")
+
+	// unknown message: server receives state of all requested messages
+	require.NoError(t, m.processResponse(serverMessage(t, 4*21+1, 1, &objects.MsgResendReq{
+		MsgIDs: []int64{msgID, 4 * 2},
+	})))
+	require.NoError(t, m.flushOutbox())
+	waitWritten(t, tr, 3)
+
+	tr.mu.Lock()
+	answer := decodeMessage(t, tr.written[2].GetMsg())
+	tr.mu.Unlock()
+	assert.Contains(t, answer, &objects.PingParams{PingID: 1})
+	assert.Contains(t, answer, &objects.MsgsStateInfo{
+		ReqMsgID: 4*21 + 1,
+		Info:     []byte{msgStateTooHigh, msgStateNotReceived},
+	})
+}
+
+func TestDetailedInfo(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	require.NoError(t, m.processResponse(serverMessage(t, 4*10+1, 1, &objects.PingParams{PingID: 1})))
+	require.NoError(t, m.processResponse(serverMessage(t, 4*11+1, 0, &objects.MsgsDetailedInfo{
+		MsgID:       4 * 2,
+		AnswerMsgID: 4*10 + 1,
+	})))
+	require.NoError(t, m.processResponse(serverMessage(t, 4*12+1, 0, &objects.MsgsNewDetailedInfo{
+		AnswerMsgID: 4*30 + 1,
+	})))
+	require.NoError(t, m.flushOutbox())
+	waitWritten(t, tr, 1)
+
+	decoded := tr.decoded(t)
+	assert.Contains(t, decoded, &objects.MsgResendReq{MsgIDs: []int64{4*30 + 1}})
+	// answer is acknowledged only once
+	assert.Contains(t, decoded, &objects.MsgsAck{MsgIDs: []int64{4*10 + 1}})
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+func TestMakeRequestContextCancel(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+	m.dropAnswerOnCancel = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := m.MakeRequestWithHintToDecoderContext(ctx, &objects.PingParams{PingID: 1}, reflect.TypeOf(&objects.Pong{}))
+		done <- err
+	}()
+
+	waitWritten(t, tr, 1)
+	keys := m.responseChannels.Keys()
+	require.Len(t, keys, 1)
+	msgID := keys[0]
+	require.True(t, m.expectedTypes.Has(msgID))
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	assert.False(t, m.expectedTypes.Has(msgID))
+
+	// server must not send answer of cancelled request
+	require.Eventually(t, func() bool {
+		for _, obj := range tr.decoded(t) {
+			if drop, ok := obj.(*objects.RpcDropAnswerParams); ok {
+				return drop.ReqMsgID == int64(msgID)
+			}
+		}
+		return false
+	}, time.Second, time.Millisecond)
+	assert.False(t, m.responseChannels.Has(msgID), "only rpc_drop_answer is waiting for response")
+}
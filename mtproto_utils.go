@@ -6,7 +6,7 @@
 package mtproto
 
 import (
-	"fmt"
+	"context"
 	"reflect"
 
 	"github.com/pkg/errors"
@@ -28,11 +28,6 @@ func (m *MTProto) GetSeqNo() int32 {
 	return m.seqNo
 }
 
-// GetServerSalt returns current server salt 🧐
-func (m *MTProto) GetServerSalt() int64 {
-	return m.serverSalt
-}
-
 // GetAuthKey returns decryption key of current session salt 🧐
 func (m *MTProto) GetAuthKey() []byte {
 	return m.authKey
@@ -44,14 +39,24 @@ func (m *MTProto) SetAuthKey(key []byte) {
 }
 
 func (m *MTProto) MakeRequest(msg tl.Object) (any, error) {
-	return m.makeRequest(msg)
+	return m.invoke(context.Background(), msg)
+}
+
+// MakeRequestContext is the same as MakeRequest, but stops waiting for response when ctx is done. In that
+// case ctx.Err() is returned and response (if it will come) is ignored.
+func (m *MTProto) MakeRequestContext(ctx context.Context, msg tl.Object) (any, error) {
+	return m.invoke(ctx, msg)
 }
 
 func (m *MTProto) MakeRequestWithHintToDecoder(msg tl.Object, expectedTypes ...reflect.Type) (any, error) {
+	return m.MakeRequestWithHintToDecoderContext(context.Background(), msg, expectedTypes...)
+}
+
+func (m *MTProto) MakeRequestWithHintToDecoderContext(ctx context.Context, msg tl.Object, expectedTypes ...reflect.Type) (any, error) {
 	if len(expectedTypes) == 0 {
 		return nil, errors.New("expected a few hints. If you don't need it, use m.MakeRequest")
 	}
-	return m.makeRequest(msg, expectedTypes...)
+	return m.invoke(ctx, msg, expectedTypes...)
 }
 
 func (m *MTProto) AddCustomServerRequestHandler(handler customHandlerFunc) {
@@ -59,22 +64,38 @@ func (m *MTProto) AddCustomServerRequestHandler(handler customHandlerFunc) {
 }
 
 func (m *MTProto) warnError(err error) {
-	if m.Warnings != nil && err != nil {
-		m.Warnings <- err
+	if err == nil {
+		return
+	}
+	m.logger.Log(LevelWarn, "warning", FieldError(err))
+
+	m.warningsMutex.RLock()
+	defer m.warningsMutex.RUnlock()
+	if m.Warnings == nil || m.isClosed() {
+		return
+	}
+
+	select {
+	case m.Warnings <- err:
+	case <-m.closing:
+		// nobody will read warnings after closing
 	}
 }
 
 func (m *MTProto) LoadSession(s *session.Session) {
 	m.authKey = s.Key
 	m.authKeyHash = s.Hash
-	m.serverSalt = s.Salt
+	m.setServerSalt(s.Salt)
+	m.setFutureSalts(s.FutureSalts)
 	m.addr = s.Hostname
+	m.msgIDs.SetTimeOffset(s.TimeOffset)
 }
 
 func (m *MTProto) recoverGoroutine() {
 	if r := recover(); r != nil {
 		if m.RecoverFunc != nil {
-			fmt.Println(dry.StackTrace(0))
+			m.logger.Log(LevelError, "panic in goroutine", Field{Key: "panic", Value: r},
+				Field{Key: "stack", Value: dry.StackTrace(0)})
 			m.RecoverFunc(r)
 		} else {
 			panic(r)
@@ -0,0 +1,370 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtprototest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xelaj/go-dry"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mode"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+	"github.com/umesproject/mtproto/internal/transport"
+)
+
+// conn is a single client connection. Before auth key is created, it handles handshake messages, after
+// that, all messages are encrypted.
+type conn struct {
+	s    *Server
+	raw  net.Conn
+	mode mode.Mode
+	// in padded intermediate mode messages have random tail, which must be cut off
+	padded bool
+
+	writeMu sync.Mutex
+
+	mu        sync.Mutex
+	authKey   []byte
+	sessionID int64
+	seqNo     int32
+	dh        *dhState
+}
+
+// fullReader reads exactly requested count of bytes, so modes never receive partial tcp packets.
+type fullReader struct {
+	net.Conn
+}
+
+func (r fullReader) Read(b []byte) (int, error) {
+	return io.ReadFull(r.Conn, b)
+}
+
+func (c *conn) serve() {
+	defer c.raw.Close()
+
+	m, err := mode.Detect(fullReader{c.raw})
+	if err != nil {
+		return
+	}
+	c.mode = m
+	if v, err := mode.GetVariant(m); err == nil && v == mode.PaddedIntermediate {
+		c.padded = true
+	}
+
+	for {
+		data, err := c.mode.ReadMsg()
+		if err != nil {
+			return
+		}
+		if c.padded {
+			data = transport.TrimPadding(data)
+		}
+
+		// any protocol violation closes connection, like telegram does
+		if err := c.handlePacket(data); err != nil {
+			return
+		}
+	}
+}
+
+func (c *conn) close() {
+	c.raw.Close()
+}
+
+func (c *conn) hasSession() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.authKey != nil
+}
+
+// transport error, which is sent instead of message, if auth key is unknown
+// https://core.telegram.org/mtproto/mtproto-transports#transport-errors
+const errCodeAuthKeyNotFound = -404
+
+func (c *conn) handlePacket(data []byte) error {
+	if len(data) < tl.LongLen {
+		return errors.New("packet is too small")
+	}
+
+	if binary.LittleEndian.Uint64(data) == 0 {
+		msg, err := messages.DeserializeUnencryptedAsServer(data)
+		if err != nil {
+			return errors.Wrap(err, "decoding unencrypted message")
+		}
+		return c.handleHandshake(msg)
+	}
+
+	key, ok := c.s.authKey(data[:tl.LongLen])
+	if !ok {
+		code := int32(errCodeAuthKeyNotFound)
+		buf := make([]byte, tl.WordLen)
+		binary.LittleEndian.PutUint32(buf, uint32(code))
+		c.writeRaw(buf) //nolint:errcheck connection is closed anyway
+		return errors.New("unknown auth key")
+	}
+
+	msg, err := messages.DeserializeEncryptedAsServer(data, key)
+	if err != nil {
+		return errors.Wrap(err, "decoding encrypted message")
+	}
+
+	if err := c.startSession(key, msg); err != nil {
+		return err
+	}
+
+	if salt := c.s.currentSalt(); msg.Salt != salt {
+		const incorrectSalt = 48
+		return c.send(&objects.BadServerSalt{
+			BadMsgID:    msg.MsgID,
+			BadMsgSeqNo: msg.SeqNo,
+			ErrorCode:   incorrectSalt,
+			NewSalt:     salt,
+		}, true, false)
+	}
+
+	return c.handleMessage(msg.MsgID, msg.Msg)
+}
+
+// startSession remembers session of client. if server didn't see this session before, it sends
+// new_session_created.
+func (c *conn) startSession(key []byte, msg *messages.Encrypted) error {
+	c.mu.Lock()
+	changed := !bytes.Equal(c.authKey, key) || c.sessionID != msg.SessionID
+	if changed {
+		c.authKey = key
+		c.sessionID = msg.SessionID
+		c.seqNo = 0
+	}
+	c.mu.Unlock()
+
+	if !changed || !c.s.newSession(msg.SessionID) {
+		return nil
+	}
+
+	return c.sendContent(&objects.NewSessionCreated{
+		FirstMsgID: msg.MsgID,
+		UniqueID:   randomLong(),
+		ServerSalt: c.s.currentSalt(),
+	})
+}
+
+const (
+	crcGzipPacked           = 0x3072cfa1
+	crcInvokeWithLayer      = 0xda9b0d0d
+	crcInvokeWithoutUpdates = 0xbf9459b7
+	crcInvokeAfterMsg       = 0xcb9f372d
+	crcInitConnection       = 0xc1cd5ea9
+	crcBindTempAuthKey      = 0xcdd42a05
+)
+
+// handleMessage processes content of single message. Wrappers of api layer (invokeWithLayer,
+// initConnection, etc.) are not registered in tl, so they are unwrapped before decoding, if there is no
+// handler for them.
+func (c *conn) handleMessage(msgID int64, data []byte) error {
+	data, err := c.unwrapQuery(data)
+	if err != nil {
+		return c.sendError(msgID, &Error{Code: 400, Message: "INPUT_REQUEST_INVALID"}) //nolint:gomnd bad request
+	}
+
+	// auth.bindTempAuthKey is registered by telegram package, so it's not decoded. temporary key is already
+	// known by server, so binding is always successful
+	if len(data) >= tl.WordLen && binary.LittleEndian.Uint32(data) == crcBindTempAuthKey {
+		return c.sendResult(msgID, &tl.PseudoTrue{})
+	}
+
+	obj, err := tl.DecodeUnknownObject(data)
+	if err != nil {
+		return c.sendError(msgID, &Error{Code: 400, Message: "INPUT_METHOD_INVALID"}) //nolint:gomnd bad request
+	}
+
+	switch message := obj.(type) {
+	case *objects.MessageContainer:
+		for _, item := range *message {
+			if err := c.handleMessage(item.MsgID, item.Msg); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *objects.MsgsAck, *objects.MsgsStateReq, *objects.MsgResendReq, *objects.MsgsAllInfo,
+		*objects.MsgsStateInfo:
+		// server doesn't track state of messages
+		return nil
+
+	case *objects.PingParams:
+		return c.sendResult(msgID, &objects.Pong{MsgID: msgID, PingID: message.PingID})
+
+	case *objects.GetFutureSaltsParams:
+		now := time.Now()
+		// future_salts is not wrapped into rpc_result
+		return c.send(&objects.FutureSalts{
+			ReqMsgID: msgID,
+			Now:      int32(now.Unix()),
+			Salts: []*objects.FutureSalt{{
+				ValidSince: int32(now.Add(-time.Hour).Unix()),
+				ValidUntil: int32(now.Add(time.Hour).Unix()),
+				Salt:       c.s.currentSalt(),
+			}},
+		}, true, false)
+
+	case *objects.RpcDropAnswerParams:
+		return c.sendResult(msgID, &objects.RpcAnswerUnknown{})
+
+	default:
+		return c.handleRequest(msgID, obj)
+	}
+}
+
+func (c *conn) handleRequest(msgID int64, req Object) error {
+	if e := c.s.takeMigrate(); e != nil {
+		return c.sendError(msgID, e)
+	}
+
+	h, ok := c.s.handler(req.CRC())
+	if !ok {
+		return c.sendError(msgID, &Error{Code: 400, Message: "METHOD_NOT_IMPLEMENTED"}) //nolint:gomnd bad request
+	}
+
+	resp, err := h(req)
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: 500, Message: "INTERNAL: " + err.Error()} //nolint:gomnd internal error
+		}
+		return c.sendError(msgID, rpcErr)
+	}
+
+	return c.sendResult(msgID, resp)
+}
+
+// unwrapQuery returns query of invokeWithLayer and similar methods, data is returned as is, if it's not
+// a wrapper or a handler is registered for this wrapper.
+func (c *conn) unwrapQuery(data []byte) ([]byte, error) {
+	for len(data) >= tl.WordLen {
+		crc := binary.LittleEndian.Uint32(data)
+		if _, ok := c.s.handler(crc); ok {
+			return data, nil
+		}
+
+		d, err := tl.NewDecoder(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		_ = d.PopCRC()
+
+		switch crc {
+		case crcGzipPacked:
+			data = dry.BytesUnGzip(d.PopMessage())
+			if data == nil {
+				return nil, errors.New("invalid gzip data")
+			}
+			continue
+
+		case crcInvokeWithLayer:
+			_ = d.PopInt() // layer
+		case crcInvokeAfterMsg:
+			_ = d.PopLong() // msg_id
+		case crcInvokeWithoutUpdates:
+		case crcInitConnection:
+			if err := skipInitConnection(d); err != nil {
+				return nil, err
+			}
+		default:
+			return data, nil
+		}
+
+		data, err = d.GetRestOfMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// skipInitConnection reads all fields of initConnection, except query
+func skipInitConnection(d *tl.Decoder) error {
+	const (
+		flagProxy  = 1 << 0
+		flagParams = 1 << 1
+		// device_model, system_version, app_version, system_lang_code, lang_pack, lang_code
+		stringsCount = 6
+	)
+
+	flags := d.PopUint()
+	_ = d.PopInt() // api_id
+	for i := 0; i < stringsCount; i++ {
+		_ = d.PopMessage()
+	}
+	if flags&flagProxy != 0 {
+		_ = d.PopCRC()
+		_ = d.PopMessage() // address
+		_ = d.PopInt()     // port
+	}
+	if flags&flagParams != 0 {
+		return errors.New("params of initConnection are not supported")
+	}
+
+	return nil
+}
+
+func (c *conn) sendResult(reqMsgID int64, obj Object) error {
+	return c.send(&objects.RpcResult{ReqMsgID: reqMsgID, Obj: obj}, true, true)
+}
+
+func (c *conn) sendError(reqMsgID int64, e *Error) error {
+	return c.sendResult(reqMsgID, &objects.RpcError{ErrorCode: e.Code, ErrorMessage: e.Message})
+}
+
+// sendContent sends message, which is not a response to any request, e.g. update.
+func (c *conn) sendContent(obj Object) error {
+	return c.send(obj, false, true)
+}
+
+func (c *conn) send(obj Object, response, contentRelated bool) error {
+	data, err := tl.Marshal(obj)
+	if err != nil {
+		return errors.Wrap(err, "encoding message")
+	}
+
+	c.mu.Lock()
+	msg := &messages.Encrypted{
+		Msg:       data,
+		MsgID:     c.s.nextMsgID(response),
+		Salt:      c.s.currentSalt(),
+		SessionID: c.sessionID,
+		SeqNo:     c.seqNo,
+	}
+	if contentRelated {
+		msg.SeqNo = c.seqNo | 1
+		c.seqNo += 2
+	}
+	authKey := c.authKey
+	c.mu.Unlock()
+
+	packet, err := msg.SerializeAsServer(authKey)
+	if err != nil {
+		return err
+	}
+
+	return c.writeRaw(packet)
+}
+
+func (c *conn) writeRaw(data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return c.mode.WriteMsg(data)
+}
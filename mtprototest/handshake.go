@@ -0,0 +1,262 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtprototest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xelaj/go-dry"
+
+	ige "github.com/umesproject/mtproto/internal/aes_ige"
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/keys"
+	"github.com/umesproject/mtproto/internal/math"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+// dhState is server side of auth key creation
+// https://core.telegram.org/mtproto/auth_key
+type dhState struct {
+	nonce       *tl.Int128
+	serverNonce *tl.Int128
+	newNonce    *tl.Int256
+	a           *big.Int
+}
+
+// same dh_prime, as telegram servers use
+var dhPrime, _ = new(big.Int).SetString("C71CAEB9C6B1C9048E6C522F70F13F73980D40238E3E21C14934D037563D930F"+ //nolint:gochecknoglobals constant
+	"48198A0AA7C14058229493D22530F4DBFA336F6E0AC925139543AED44CCE7C3720FD51F69458705AC68CD4FE6B6B13AB"+
+	"DC9746512969328454F18FAF8C595F642477FE96BB2A941D5BCD1D4AC8CC49880708FA9B378E3C4F3A9060BEE67CF9A4"+
+	"A4A695811051907E162753B56B0F6B410DBA74D8A84B2A14B3144E0EF1284754FD17ED950D5965B4B9DD46582DB1178D"+
+	"169C6BC465B0D6FF9CA3928FEF5B9AE4E418FC15E83EBEA0F87FA9FF5EED70050DED2849F47BF959D956850CE929851F"+
+	"0D8115F635B105EE2E4E15D04B2454BF6F4FADF034B10403119CD8E3B92FCC5B", 16)
+
+const (
+	dhGenerator = 3
+	// p and q, which client must find, it doesn't need to be hard
+	pqP = 1229739323
+	pqQ = 1402015859
+)
+
+func (c *conn) handleHandshake(msg *messages.Unencrypted) error {
+	obj, err := tl.DecodeUnknownObject(msg.Msg)
+	if err != nil {
+		return errors.Wrap(err, "decoding handshake message")
+	}
+
+	var resp Object
+	switch req := obj.(type) {
+	case *objects.ReqPQParams:
+		resp = c.resPQ(req)
+	case *objects.ReqDHParamsParams:
+		resp, err = c.serverDHParams(req)
+	case *objects.SetClientDHParamsParams:
+		resp, err = c.dhGenAnswer(req)
+	default:
+		return fmt.Errorf("unexpected handshake message %T", obj)
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := tl.Marshal(resp)
+	if err != nil {
+		return errors.Wrap(err, "encoding handshake answer")
+	}
+	packet, err := (&messages.Unencrypted{Msg: data, MsgID: c.s.nextMsgID(true)}).Serialize(nil)
+	if err != nil {
+		return err
+	}
+
+	return c.writeRaw(packet)
+}
+
+func (c *conn) resPQ(req *objects.ReqPQParams) *objects.ResPQ {
+	c.dh = &dhState{nonce: req.Nonce, serverNonce: tl.RandomInt128()}
+
+	pq := big.NewInt(0).Mul(big.NewInt(pqP), big.NewInt(pqQ))
+	return &objects.ResPQ{
+		Nonce:        req.Nonce,
+		ServerNonce:  c.dh.serverNonce,
+		Pq:           pq.Bytes(),
+		Fingerprints: []int64{c.s.keyFingerprint()},
+	}
+}
+
+func (c *conn) serverDHParams(req *objects.ReqDHParamsParams) (Object, error) {
+	if c.dh == nil || !c.dh.checkNonces(req.Nonce, req.ServerNonce) {
+		return nil, errors.New("wrong nonce")
+	}
+	if req.PublicKeyFingerprint != c.s.keyFingerprint() {
+		return nil, errors.New("unknown key fingerprint")
+	}
+
+	data, err := c.s.decryptInnerData(req.EncryptedData)
+	if err != nil {
+		return nil, err
+	}
+	inner, err := tl.DecodeUnknownObject(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding p_q_inner_data")
+	}
+
+	var (
+		nonce, serverNonce *tl.Int128
+		dc                 int32
+	)
+	switch i := inner.(type) {
+	case *objects.PQInnerData:
+		nonce, serverNonce, c.dh.newNonce = i.Nonce, i.ServerNonce, i.NewNonce
+		dc = int32(c.s.dc) // legacy inner data doesn't contain dc
+	case *objects.PQInnerDataDC:
+		nonce, serverNonce, c.dh.newNonce, dc = i.Nonce, i.ServerNonce, i.NewNonce, i.DC
+	case *objects.PQInnerDataTempDC:
+		nonce, serverNonce, c.dh.newNonce, dc = i.Nonce, i.ServerNonce, i.NewNonce, i.DC
+	default:
+		return nil, fmt.Errorf("unexpected inner data %T", inner)
+	}
+	if !c.dh.checkNonces(nonce, serverNonce) {
+		return nil, errors.New("wrong nonce in p_q_inner_data")
+	}
+	if c.s.dc != 0 && int(dc) != c.s.dc {
+		return nil, fmt.Errorf("wrong dc %d in p_q_inner_data, expected %d", dc, c.s.dc)
+	}
+
+	var gA *big.Int
+	for gA == nil || math.CheckDHValue(gA, dhPrime) != nil {
+		c.dh.a, err = rand.Int(rand.Reader, dhPrime)
+		if err != nil {
+			return nil, errors.Wrap(err, "generating a")
+		}
+		gA = big.NewInt(0).Exp(big.NewInt(dhGenerator), c.dh.a, dhPrime)
+	}
+
+	answer, err := tl.Marshal(&objects.ServerDHInnerData{
+		Nonce:       c.dh.nonce,
+		ServerNonce: c.dh.serverNonce,
+		G:           dhGenerator,
+		DhPrime:     dhPrime.Bytes(),
+		GA:          gA.Bytes(),
+		ServerTime:  int32(time.Now().Unix()),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding server_DH_inner_data")
+	}
+
+	return &objects.ServerDHParamsOk{
+		Nonce:           c.dh.nonce,
+		ServerNonce:     c.dh.serverNonce,
+		EncryptedAnswer: ige.EncryptMessageWithTempKeys(answer, c.dh.newNonce.Int, c.dh.serverNonce.Int),
+	}, nil
+}
+
+func (c *conn) dhGenAnswer(req *objects.SetClientDHParamsParams) (Object, error) {
+	if c.dh == nil || c.dh.newNonce == nil || !c.dh.checkNonces(req.Nonce, req.ServerNonce) {
+		return nil, errors.New("wrong nonce")
+	}
+
+	data := ige.DecryptMessageWithTempKeys(req.EncryptedData, c.dh.newNonce.Int, c.dh.serverNonce.Int)
+	inner, err := tl.DecodeUnknownObject(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding client_DH_inner_data")
+	}
+	clientDH, ok := inner.(*objects.ClientDHInnerData)
+	if !ok {
+		return nil, fmt.Errorf("unexpected inner data %T", inner)
+	}
+
+	gB := big.NewInt(0).SetBytes(clientDH.GB)
+	if err := math.CheckDHValue(gB, dhPrime); err != nil {
+		return nil, errors.Wrap(err, "invalid g_b")
+	}
+
+	const authKeySize = 256
+	authKey := dry.BigIntBytes(big.NewInt(0).Exp(gB, c.dh.a, dhPrime), authKeySize*8) //nolint:gomnd bits in byte
+	c.s.addAuthKey(authKey)
+
+	// new_nonce_hash1: 128 lower bits of SHA1(new_nonce + 1 + auth_key_aux_hash)
+	newNonce := dry.BigIntBytes(c.dh.newNonce.Int, tl.Int256Len*8) //nolint:gomnd bits in byte
+	hash := dry.Sha1Byte(bytes.Join([][]byte{newNonce, {1}, dry.Sha1Byte(authKey)[:8]}, nil))
+
+	resp := &objects.DHGenOk{
+		Nonce:         c.dh.nonce,
+		ServerNonce:   c.dh.serverNonce,
+		NewNonceHash1: &tl.Int128{Int: big.NewInt(0).SetBytes(hash[4:20])},
+	}
+	c.dh = nil
+	return resp, nil
+}
+
+func (s *Server) keyFingerprint() int64 {
+	return int64(binary.LittleEndian.Uint64(keys.RSAFingerprint(s.PublicKey())))
+}
+
+func (d *dhState) checkNonces(nonce, serverNonce *tl.Int128) bool {
+	return nonce != nil && serverNonce != nil &&
+		d.nonce.Cmp(nonce.Int) == 0 && d.serverNonce.Cmp(serverNonce.Int) == 0
+}
+
+// decryptInnerData decrypts encrypted_data of req_DH_params. Both RSA_PAD and old scheme (sha1 + data +
+// padding) are supported.
+func (s *Server) decryptInnerData(encrypted []byte) ([]byte, error) {
+	const (
+		blockLen   = 256
+		tempKeyLen = 32
+		dataLen    = 192
+		sha1Len    = 20
+	)
+
+	c := big.NewInt(0).SetBytes(encrypted)
+	if c.Cmp(s.key.N) >= 0 {
+		return nil, errors.New("encrypted data is bigger than modulus")
+	}
+	block := dry.BigIntBytes(big.NewInt(0).Exp(c, s.key.D, s.key.N), blockLen*8) //nolint:gomnd bits in byte
+
+	// RSA_PAD: temp_key_xor + aes_encrypted(data_pad_reversed + sha256(temp_key + data_with_padding))
+	tempKeyXor, aesEncrypted := block[:tempKeyLen], block[tempKeyLen:]
+	aesHash := sha256.Sum256(aesEncrypted)
+	tempKey := dry.BytesXor(tempKeyXor, aesHash[:])
+	dataWithHash, err := ige.DecryptAES256IGE(aesEncrypted, tempKey, make([]byte, tempKeyLen))
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting data with temp key")
+	}
+
+	dataWithPadding := make([]byte, dataLen)
+	for i := range dataWithPadding {
+		dataWithPadding[i] = dataWithHash[dataLen-1-i]
+	}
+	hash := sha256.Sum256(bytes.Join([][]byte{tempKey, dataWithPadding}, nil))
+	if bytes.Equal(hash[:], dataWithHash[dataLen:]) {
+		return dataWithPadding, nil
+	}
+
+	// old scheme: first byte is always zero, then sha1 of data, data and random padding
+	if block[0] != 0 {
+		return nil, errors.New("can't decrypt inner data")
+	}
+	data := block[1+sha1Len:]
+	obj, err := tl.DecodeUnknownObject(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding inner data")
+	}
+	raw, err := tl.Marshal(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding inner data")
+	}
+	if !bytes.Equal(dry.Sha1Byte(raw), block[1:1+sha1Len]) {
+		return nil, errors.New("wrong hash of inner data")
+	}
+
+	return raw, nil
+}
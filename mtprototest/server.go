@@ -0,0 +1,328 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+// Package mtprototest implements in-process MTProto server, which allows to test clients without
+// telegram: server creates auth keys, decrypts messages and passes requests to registered handlers.
+//
+//	s, err := mtprototest.NewServer(mtprototest.Config{})
+//	s.Handle(&telegram.HelpGetConfigParams{}, func(req mtprototest.Object) (mtprototest.Object, error) {
+//		return &telegram.Config{...}, nil
+//	})
+//	m, err := mtproto.NewMTProto(mtproto.Config{ServerHost: s.Addr(), PublicKeys: []*rsa.PublicKey{s.PublicKey()}})
+package mtprototest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xelaj/go-dry"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+	"github.com/umesproject/mtproto/internal/utils"
+)
+
+// Object is any TL object: request, response or update.
+type Object = tl.Object
+
+// HandlerFunc answers request of client. If returned error is *Error, client receives it as rpc_error
+// with same code and message, other errors are sent as INTERNAL errors with code 500.
+type HandlerFunc func(req Object) (Object, error)
+
+// Error is rpc_error, which is returned to client.
+type Error struct {
+	Code    int32
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// MigrateError returns error, which asks client to reconnect to another dc, e.g. MigrateError("PHONE", 2)
+// is PHONE_MIGRATE_2.
+func MigrateError(kind string, dc int) *Error {
+	const seeOther = 303
+	return &Error{Code: seeOther, Message: fmt.Sprintf("%s_MIGRATE_%d", kind, dc)}
+}
+
+type Config struct {
+	// Key is private rsa key of server, 2048 bit size. If nil, new key is generated.
+	Key *rsa.PrivateKey
+	// Addr is tcp address for listening, by default random port of localhost is used.
+	Addr string
+	// DC is id of datacenter, as clients must encode it in p_q_inner_data_dc (e.g. 10002 for test DC 2).
+	// If not 0, handshake with other dc fails.
+	DC int
+}
+
+// Server is fake MTProto server. It supports only tcp connections without obfuscation.
+type Server struct {
+	key      *rsa.PrivateKey
+	listener net.Listener
+	dc       int
+
+	mu        sync.Mutex
+	handlers  map[uint32]HandlerFunc
+	authKeys  map[string][]byte // by auth key id
+	sessions  map[int64]struct{}
+	conns     map[*conn]struct{}
+	salt      int64
+	migrate   *Error
+	lastMsgID int64
+
+	wg     sync.WaitGroup
+	closed chan struct{}
+}
+
+const rsaKeyBits = 2048
+
+// NewServer starts listening for clients. Server must be closed after use.
+func NewServer(c Config) (*Server, error) {
+	key := c.Key
+	if key == nil {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, errors.Wrap(err, "generating rsa key")
+		}
+	}
+	if key.N.BitLen() != rsaKeyBits {
+		return nil, fmt.Errorf("rsa key must be %d bit size, got %d", rsaKeyBits, key.N.BitLen())
+	}
+
+	addr := c.Addr
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "listening")
+	}
+
+	s := &Server{
+		key:      key,
+		listener: listener,
+		dc:       c.DC,
+		handlers: make(map[uint32]HandlerFunc),
+		authKeys: make(map[string][]byte),
+		sessions: make(map[int64]struct{}),
+		conns:    make(map[*conn]struct{}),
+		salt:     randomLong(),
+		closed:   make(chan struct{}),
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// Addr returns address, which must be used by clients as ServerHost.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// PublicKey returns rsa key, which clients need to create auth key.
+func (s *Server) PublicKey() *rsa.PublicKey {
+	return &s.key.PublicKey
+}
+
+// Handle sets handler for all requests with same type as req. If type of request is not registered in tl
+// registry yet, it's registered here. Requests without handler receive rpc_error.
+func (s *Server) Handle(req Object, h HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !tl.IsRegistered(req) {
+		tl.RegisterObjects(req)
+	}
+	s.handlers[req.CRC()] = h
+}
+
+// PushUpdate sends obj to every connected client, as telegram does it with updates.
+func (s *Server) PushUpdate(obj Object) error {
+	for _, c := range s.activeConns() {
+		if err := c.sendContent(obj); err != nil {
+			return errors.Wrap(err, "sending update")
+		}
+	}
+
+	return nil
+}
+
+// ChangeSalt replaces server salt. All next messages with old salt are rejected with bad_server_salt.
+func (s *Server) ChangeSalt() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.salt = randomLong()
+	return s.salt
+}
+
+// Migrate makes next request (of any type) to fail with KIND_MIGRATE_DC error, see MigrateError.
+func (s *Server) Migrate(kind string, dc int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.migrate = MigrateError(kind, dc)
+}
+
+// Close stops server and disconnects all clients.
+func (s *Server) Close() error {
+	select {
+	case <-s.closed:
+		return nil
+	default:
+	}
+	close(s.closed)
+
+	err := s.listener.Close()
+	for _, c := range s.allConns() {
+		c.close()
+	}
+	s.wg.Wait()
+
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		raw, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &conn{s: s, raw: raw}
+		s.mu.Lock()
+		s.conns[c] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			c.serve()
+
+			s.mu.Lock()
+			delete(s.conns, c)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+func (s *Server) allConns() []*conn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]*conn, 0, len(s.conns))
+	for c := range s.conns {
+		res = append(res, c)
+	}
+	return res
+}
+
+// activeConns returns connections with encrypted sessions.
+func (s *Server) activeConns() []*conn {
+	res := make([]*conn, 0)
+	for _, c := range s.allConns() {
+		if c.hasSession() {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func (s *Server) handler(crc uint32) (HandlerFunc, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.handlers[crc]
+	return h, ok
+}
+
+// takeMigrate returns migrate error once after Migrate call.
+func (s *Server) takeMigrate() *Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.migrate
+	s.migrate = nil
+	return e
+}
+
+func (s *Server) currentSalt() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.salt
+}
+
+func (s *Server) addAuthKey(key []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.authKeys[string(utils.AuthKeyHash(key))] = key
+}
+
+func (s *Server) authKey(id []byte) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.authKeys[string(id)]
+	return key, ok
+}
+
+// newSession returns true, if session id wasn't used before.
+func (s *Server) newSession(id int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[id]; ok {
+		return false
+	}
+	s.sessions[id] = struct{}{}
+	return true
+}
+
+// nextMsgID returns id of server message: response ids are 1 mod 4, other messages are 3 mod 4.
+// https://core.telegram.org/mtproto/description#message-identifier-msg-id
+func (s *Server) nextMsgID(response bool) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	id := now.Unix()<<32 | int64(now.Nanosecond())&^3 //nolint:gomnd not magic
+	if id <= s.lastMsgID {
+		id = s.lastMsgID + 4 //nolint:gomnd next id
+	}
+	s.lastMsgID = id
+
+	if response {
+		return id | 1
+	}
+	return id | 3 //nolint:gomnd not magic
+}
+
+func randomLong() int64 {
+	return int64(binary.LittleEndian.Uint64(dry.RandomBytes(tl.LongLen)))
+}
+
+func init() {
+	// protocol methods, which are answered by server itself. client never decodes them, so they aren't
+	// registered by objects package
+	for _, o := range []Object{&objects.GetFutureSaltsParams{}} {
+		if !tl.IsRegistered(o) {
+			tl.RegisterObjects(o)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtprototest_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xelaj/go-dry"
+
+	"github.com/umesproject/mtproto"
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/session"
+	"github.com/umesproject/mtproto/mtprototest"
+	// server must work with api objects, some of them have same crcs as protocol ones
+	_ "github.com/umesproject/mtproto/telegram"
+)
+
+type echoParams struct {
+	Text string
+}
+
+func (*echoParams) CRC() uint32 {
+	return 0x0e0e0e01 //nolint:gomnd not magic
+}
+
+type echoResult struct {
+	Text string
+}
+
+func (*echoResult) CRC() uint32 {
+	return 0x0e0e0e02 //nolint:gomnd not magic
+}
+
+func init() {
+	tl.RegisterObjects(&echoResult{})
+}
+
+// rsa key generation is slow, so all servers in tests use the same one
+var serverKey, _ = rsa.GenerateKey(rand.Reader, 2048) //nolint:gochecknoglobals only for tests
+
+func newServer(t *testing.T) *mtprototest.Server {
+	t.Helper()
+
+	s, err := mtprototest.NewServer(mtprototest.Config{Key: serverKey})
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	s.Handle(&echoParams{}, func(req mtprototest.Object) (mtprototest.Object, error) {
+		text := req.(*echoParams).Text
+		if text == "" {
+			return nil, &mtprototest.Error{Code: 400, Message: "TEXT_EMPTY"}
+		}
+		return &echoResult{Text: text}, nil
+	})
+
+	return s
+}
+
+func newClient(t *testing.T, s *mtprototest.Server, c mtproto.Config) *mtproto.MTProto {
+	t.Helper()
+
+	c.ServerHost = s.Addr()
+	c.PublicKeys = []*rsa.PublicKey{s.PublicKey()}
+	m, err := mtproto.NewMTProto(c)
+	require.NoError(t, err)
+	require.NoError(t, m.CreateConnection())
+	t.Cleanup(func() { m.Close(context.Background()) })
+
+	return m
+}
+
+func echo(t *testing.T, m *mtproto.MTProto, text string) {
+	t.Helper()
+
+	resp, err := m.MakeRequest(&echoParams{Text: text})
+	require.NoError(t, err)
+	assert.Equal(t, &echoResult{Text: text}, resp)
+}
+
+func TestServer(t *testing.T) {
+	s := newServer(t)
+	m := newClient(t, s, mtproto.Config{})
+
+	echo(t, m, "hello")
+
+	_, err := m.MakeRequest(&echoParams{})
+	var rpcErr *mtproto.ErrResponseCode
+	require.True(t, errors.As(err, &rpcErr), "got %v", err)
+	assert.Equal(t, "TEXT_EMPTY", rpcErr.Message)
+
+	// after salt change client receives bad_server_salt and resends request
+	salt := s.ChangeSalt()
+	echo(t, m, "with new salt")
+	assert.Equal(t, salt, m.GetServerSalt())
+}
+
+func TestServerPushUpdate(t *testing.T) {
+	s := newServer(t)
+	m := newClient(t, s, mtproto.Config{})
+
+	updates := make(chan interface{}, 1)
+	m.AddCustomServerRequestHandler(func(i interface{}) bool {
+		updates <- i
+		return true
+	})
+
+	echo(t, m, "session is ready")
+	require.NoError(t, s.PushUpdate(&echoResult{Text: "update"}))
+
+	select {
+	case u := <-updates:
+		assert.Equal(t, &echoResult{Text: "update"}, u)
+	case <-time.After(5 * time.Second):
+		t.Fatal("update is not received")
+	}
+}
+
+func TestServerMigrate(t *testing.T) {
+	s := newServer(t)
+	other := newServer(t)
+	m := newClient(t, s, mtproto.Config{})
+	m.SetDCList(map[int]string{2: other.Addr()})
+
+	s.Migrate("PHONE", 2)
+	echo(t, m, "after migration")
+}
+
+func TestServerTempAuthKey(t *testing.T) {
+	s := newServer(t)
+	m := newClient(t, s, mtproto.Config{TempAuthKeyTTL: time.Hour})
+
+	echo(t, m, "encrypted by temporary key")
+}
+
+func TestServerSessionInitAfterNewTempKey(t *testing.T) {
+	s := newServer(t)
+	m := newClient(t, s, mtproto.Config{TempAuthKeyTTL: time.Hour})
+
+	var mu sync.Mutex
+	var inits int
+	s.Handle(&echoParams{}, func(req mtprototest.Object) (mtprototest.Object, error) {
+		text := req.(*echoParams).Text
+		if text == "init" {
+			mu.Lock()
+			inits++
+			mu.Unlock()
+		}
+		return &echoResult{Text: text}, nil
+	})
+	m.SetSessionInitRequest(&echoParams{Text: "init"})
+
+	// new temporary key is bound in new session, which must be initialized again
+	require.NoError(t, m.Reconnect(false))
+	echo(t, m, "after new key")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, inits)
+}
+
+func TestServerRecordReplay(t *testing.T) {
+	s := newServer(t)
+	recording := bytes.NewBuffer(nil)
+	m := newClient(t, s, mtproto.Config{RecordTraffic: recording})
+
+	echo(t, m, "first")
+	echo(t, m, "second")
+	require.NoError(t, m.Close(context.Background()))
+	require.NoError(t, s.Close())
+
+	// server is not required anymore: answers are taken from recording
+	replayed, err := mtproto.NewMTProto(mtproto.Config{
+		Session:       &session.Session{Key: dry.RandomBytes(256)},
+		ReplayTraffic: bytes.NewReader(recording.Bytes()),
+	})
+	require.NoError(t, err)
+	require.NoError(t, replayed.CreateConnection())
+	defer replayed.Close(context.Background())
+
+	echo(t, replayed, "first")
+	echo(t, replayed, "second")
+}
+
+func TestServerMiddleware(t *testing.T) {
+	s := newServer(t)
+	m := newClient(t, s, mtproto.Config{})
+
+	// service requests of client (e.g. get_future_salts) are passed through middlewares too
+	var mu sync.Mutex
+	results := make(map[string]interface{})
+	m.Use(func(next mtproto.Invoker) mtproto.Invoker {
+		return func(ctx context.Context, req mtproto.Object) (interface{}, error) {
+			resp, err := next(ctx, req)
+			if echoReq, ok := req.(*echoParams); ok {
+				mu.Lock()
+				results[echoReq.Text] = resp
+				if err != nil {
+					results[echoReq.Text] = err
+				}
+				mu.Unlock()
+			}
+			return resp, err
+		}
+	})
+
+	echo(t, m, "hello")
+	_, err := m.MakeRequest(&echoParams{})
+	require.Error(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, &echoResult{Text: "hello"}, results["hello"])
+	assert.Equal(t, err, results[""])
+}
+
+func TestServerMiddlewareSkipsKeyExchange(t *testing.T) {
+	s := newServer(t)
+	m, err := mtproto.NewMTProto(mtproto.Config{
+		ServerHost:     s.Addr(),
+		PublicKeys:     []*rsa.PublicKey{s.PublicKey()},
+		TempAuthKeyTTL: time.Hour,
+	})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var methods []string
+	m.Use(func(next mtproto.Invoker) mtproto.Invoker {
+		return func(ctx context.Context, req mtproto.Object) (interface{}, error) {
+			mu.Lock()
+			methods = append(methods, mtproto.FieldMethod(req).Value.(string))
+			mu.Unlock()
+			return next(ctx, req)
+		}
+	})
+	m.SetSessionInitRequest(&echoParams{Text: "init"})
+
+	require.NoError(t, m.CreateConnection())
+	t.Cleanup(func() { m.Close(context.Background()) })
+	echo(t, m, "hello")
+
+	mu.Lock()
+	defer mu.Unlock()
+	// service requests (like get_future_salts) could be sent in background, they are passed through
+	// middlewares too
+	assert.Contains(t, methods, "echoParams")
+	for _, method := range []string{"ReqPQParams", "ReqDHParamsParams", "SetClientDHParamsParams", "bindTempAuthKeyRequest"} {
+		assert.NotContains(t, methods, method)
+	}
+	assert.Equal(t, 1, countOf(methods, "echoParams"), "session init request must not be passed")
+}
+
+func countOf(list []string, s string) int {
+	n := 0
+	for _, v := range list {
+		if v == s {
+			n++
+		}
+	}
+	return n
+}
@@ -6,6 +6,7 @@
 package mtproto
 
 import (
+	"encoding/binary"
 	"reflect"
 	"strconv"
 
@@ -15,20 +16,87 @@ import (
 	"github.com/umesproject/mtproto/internal/encoding/tl"
 	"github.com/umesproject/mtproto/internal/mtproto/messages"
 	"github.com/umesproject/mtproto/internal/mtproto/objects"
-	"github.com/umesproject/mtproto/internal/utils"
 )
 
-func (m *MTProto) sendPacket(request tl.Object, expectedTypes ...reflect.Type) (chan tl.Object, error) {
-	msg, err := tl.Marshal(request)
-	if err != nil {
-		return nil, errors.Wrap(err, "encoding request message")
+const (
+	// defaultGzipThreshold is a size of serialized request, starting from which request is compressed
+	defaultGzipThreshold = 1024
+	// compressed request is sent, only if it's smaller at least by 1/gzipMinSaving of original size.
+	// otherwise server spends time for unpacking almost for nothing
+	gzipMinSaving = 10
+)
+
+// incompressibleMethods contain already compressed data (e.g. parts of uploaded files), so they are sent as
+// is. telegram package can't be imported here, so only crc codes are listed.
+var incompressibleMethods = map[uint32]bool{ //nolint:gochecknoglobals constant
+	0xb304a621: true, // upload.saveFilePart
+	0xde7b673d: true, // upload.saveBigFilePart
+}
+
+// sendPacket sends request. If connection is creating right now, it waits until new key is ready.
+func (m *MTProto) sendPacket(request tl.Object, expectedTypes ...reflect.Type) (chan tl.Object, int64, error) {
+	m.rekeyMutex.RLock()
+	defer m.rekeyMutex.RUnlock()
+
+	return m.writePacket(request, false, expectedTypes...)
+}
+
+// sendKeyExchangePacket sends request of key exchange, which holds rekeyMutex by itself.
+func (m *MTProto) sendKeyExchangePacket(request tl.Object, expectedTypes ...reflect.Type) (chan tl.Object, int64, error) {
+	return m.writePacket(request, true, expectedTypes...)
+}
+
+// writePacket serializes and sends request. if direct is true, request is never queued in outbox.
+func (m *MTProto) writePacket(request tl.Object, direct bool, expectedTypes ...reflect.Type) (chan tl.Object, int64, error) {
+	var (
+		msg        []byte
+		compressed []byte
+		err        error
+	)
+	binder, bindsMsgID := request.(msgIDBinder)
+	if !bindsMsgID {
+		msg, err = tl.Marshal(request)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "encoding request message")
+		}
+		// compression of big requests is slow, so it's made before blocking of other senders
+		compressed = m.compressMessage(msg)
+	}
+
+	// reflection is slow, so fields are built before locking and only if they will be written
+	var method Field
+	debug := logEnabled(m.logger, LevelDebug)
+	if debug {
+		method = FieldMethod(request)
+	}
+
+	// must write synchroniously, cuz seqno and msg_id must be upper each request
+	m.seqNoMutex.Lock()
+	defer m.seqNoMutex.Unlock()
+
+	encrypted := m.encrypted.Get() && !m.serviceModeActivated.Get()
+
+	// unencrypted messages are never compressed
+	if encrypted {
+		msg = compressed
 	}
 
 	var (
 		data  messages.Common
-		msgID = utils.GenerateMessageId()
+		msgID = m.msgIDs.Next()
 	)
 
+	if bindsMsgID {
+		msg, err = binder.marshalWithMsgID(msgID)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "encoding request message")
+		}
+	}
+
+	if debug {
+		m.logger.Log(LevelDebug, "sending request", FieldMsgID(msgID), method)
+	}
+
 	// adding types for parser if required
 	if len(expectedTypes) > 0 {
 		m.expectedTypes.Add(int(msgID), expectedTypes)
@@ -36,13 +104,36 @@ func (m *MTProto) sendPacket(request tl.Object, expectedTypes ...reflect.Type) (
 
 	// dealing with response channel
 	resp := m.getRespChannel()
-	if isNullableResponse(request) {
+	switch {
+	case m.serviceModeActivated.Get():
+		// responses of handshake don't contain msg_id of request, they are read from serviceChannel one by
+		// one, so request isn't pending (e.g. it's not restored after reconnection)
+	case isNullableResponse(request):
 		go func() { resp <- &objects.Null{} }() // goroutine cuz we don't read from it RIGHT NOW
-	} else {
+	default:
 		m.responseChannels.Add(int(msgID), resp)
 	}
 
-	if m.encrypted {
+	if encrypted && !direct && !bindsMsgID && len(msg) <= outboxMaxMessageSize {
+		// small requests are sent in containers, so they could share single packet with acks and other
+		// requests
+		seqNo := m.seqNo
+		if MessageRequireToAck(request) {
+			seqNo |= 1
+		}
+		m.seqNo += 2
+
+		if m.outbox.push(&messages.Encrypted{Msg: msg, MsgID: msgID, SeqNo: seqNo}, m.scheduleOutboxFlush) {
+			if err := m.flushOutboxLocked(); err != nil {
+				// request is still pending, it will be replayed after reconnection
+				m.warnError(errors.Wrap(err, "sending container"))
+			}
+		}
+
+		return resp, msgID, nil
+	}
+
+	if m.encrypted.Get() {
 		data = &messages.Encrypted{
 			Msg:         msg,
 			MsgID:       msgID,
@@ -55,16 +146,21 @@ func (m *MTProto) sendPacket(request tl.Object, expectedTypes ...reflect.Type) (
 		}
 	}
 
-	// must write synchroniously, cuz seqno must be upper each request
-	m.seqNoMutex.Lock()
-	defer m.seqNoMutex.Unlock()
-
-	err = m.transport.WriteMsg(data, MessageRequireToAck(request))
+	err = m.currentTransport().WriteMsg(data, MessageRequireToAck(request))
 	if err != nil {
-		return nil, errors.Wrap(err, "sending request")
+		m.responseChannels.Delete(int(msgID))
+		m.expectedTypes.Delete(int(msgID))
+		return nil, 0, errors.Wrap(err, "sending request")
 	}
 
-	if m.encrypted {
+	if m.encrypted.Get() {
+		// seqno is set by transport during serialization, so saving it as it was sent
+		sent := &messages.Encrypted{Msg: msg, MsgID: msgID, SeqNo: m.seqNo}
+		if MessageRequireToAck(request) {
+			sent.SeqNo |= 1
+		}
+		m.outbox.rememberSent(sent)
+
 		// since we sending this message, we are incrementing the seqno BUT ONLY when we
 		// are sending an encrypted message. why? I don’t know. But the fact remains:
 		// we must to block seqno, cause messages with a bigger seqno can go faster than
@@ -72,7 +168,29 @@ func (m *MTProto) sendPacket(request tl.Object, expectedTypes ...reflect.Type) (
 		m.seqNo += 2
 	}
 
-	return resp, nil
+	return resp, msgID, nil
+}
+
+// compressMessage packs serialized request into gzip_packed, if it's big enough and compression really
+// makes it smaller. Otherwise original message is returned.
+func (m *MTProto) compressMessage(msg []byte) []byte {
+	if m.gzipThreshold <= 0 || len(msg) < m.gzipThreshold || len(msg) < tl.WordLen {
+		return msg
+	}
+	if incompressibleMethods[binary.LittleEndian.Uint32(msg)] {
+		return msg
+	}
+
+	packed, err := objects.PackGzipped(msg)
+	if err != nil {
+		m.warnError(errors.Wrap(err, "compressing request"))
+		return msg
+	}
+	if len(packed) > len(msg)-len(msg)/gzipMinSaving {
+		return msg
+	}
+
+	return packed
 }
 
 func (m *MTProto) writeRPCResponse(msgID int, data tl.Object) error {
@@ -89,16 +207,18 @@ func (m *MTProto) writeRPCResponse(msgID int, data tl.Object) error {
 }
 
 func (m *MTProto) getRespChannel() chan tl.Object {
-	if m.serviceModeActivated {
+	if m.serviceModeActivated.Get() {
 		return m.serviceChannel
 	}
-	return make(chan tl.Object)
+	// buffered, cause reader can stop waiting for response (e.g. context was cancelled), so writer
+	// mustn't block on it
+	return make(chan tl.Object, 1)
 }
 
 // проверяет, надо ли ждать от сервера пинга
 func isNullableResponse(t tl.Object) bool {
 	switch t.(type) {
-	case /**objects.Ping,*/ *objects.Pong, *objects.MsgsAck:
+	case /**objects.Ping,*/ *objects.Pong, *objects.MsgsAck, *objects.HttpWaitParams:
 		return true
 	default:
 		return false
@@ -0,0 +1,67 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+func TestSendPacketCompressesBigRequests(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	ids := make([]int64, defaultGzipThreshold)
+	for i := range ids {
+		ids[i] = int64(i)
+	}
+	request := &objects.MsgsAck{MsgIDs: ids}
+	_, _, err := m.sendPacket(request)
+	require.NoError(t, err)
+	require.NoError(t, m.flushOutbox())
+	waitWritten(t, tr, 1)
+
+	tr.mu.Lock()
+	container := mustDecode(t, tr.written[0].GetMsg()).(*objects.MessageContainer)
+	tr.mu.Unlock()
+
+	require.Len(t, *container, 1)
+	packed, ok := mustDecode(t, (*container)[0].Msg).(*objects.GzipPacked)
+	require.True(t, ok, "request must be compressed")
+	assert.Equal(t, request, packed.Obj)
+}
+
+func TestCompressMessage(t *testing.T) {
+	m, err := NewMTProto(Config{GzipThreshold: 64})
+	require.NoError(t, err)
+
+	small := make([]byte, 60)
+	assert.Equal(t, small, m.compressMessage(small), "small messages are sent as is")
+
+	random := make([]byte, 1024)
+	_, err = rand.Read(random)
+	require.NoError(t, err)
+	assert.Equal(t, random, m.compressMessage(random), "compression must shrink message")
+
+	msg, err := tl.Marshal(&objects.MsgsAck{MsgIDs: make([]int64, 64)})
+	require.NoError(t, err)
+	compressed := m.compressMessage(msg)
+	assert.Less(t, len(compressed), len(msg))
+	assert.Equal(t, &objects.MsgsAck{MsgIDs: make([]int64, 64)}, mustDecode(t, compressed).(*objects.GzipPacked).Obj)
+
+	filePart := make([]byte, 1024)
+	copy(filePart, []byte{0x21, 0xa6, 0x04, 0xb3}) // upload.saveFilePart
+	assert.Equal(t, filePart, m.compressMessage(filePart), "file parts are already compressed")
+
+	m, err = NewMTProto(Config{GzipThreshold: -1})
+	require.NoError(t, err)
+	assert.Equal(t, msg, m.compressMessage(msg), "negative threshold disables compression")
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+const (
+	// how long small requests and acks are waiting for others before sending
+	outboxFlushDelay = 5 * time.Millisecond
+	// requests bigger than this are sent immediately, without container
+	outboxMaxMessageSize = 4 * 1024
+	// container is sent immediately, when queued messages are bigger than this
+	outboxFlushSize = 32 * 1024
+	// server accepts no more than 1020 messages in single container
+	outboxMaxMessages = 1020
+	// how many sent containers are remembered for bad_msg_notification handling
+	outboxSentContainers = 64
+	// how many sent content related messages are remembered, so server could ask to resend them
+	outboxHistorySize = 512
+)
+
+// outbox is a queue of small outgoing messages and acks, which are sent in single msg_container.
+// https://core.telegram.org/mtproto/service_messages#containers
+type outbox struct {
+	mutex sync.Mutex
+	msgs  []*messages.Encrypted
+	size  int
+	acks  []int64
+	timer *time.Timer
+
+	// ids of messages inside of recently sent containers, server could send notification about container
+	// instead of message
+	sent      map[int64][]int64
+	sentOrder []int64
+
+	// recently sent content related messages, which could be requested by msg_resend_req
+	history      map[int64]*messages.Encrypted
+	historyOrder []int64
+}
+
+// push adds message to queue and returns true, if queue must be flushed right now. Otherwise flush is
+// scheduled with schedule function.
+func (o *outbox) push(msg *messages.Encrypted, schedule func() *time.Timer) bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.msgs = append(o.msgs, msg)
+	o.size += len(msg.Msg)
+	if o.size >= outboxFlushSize || len(o.msgs)+1 >= outboxMaxMessages { // +1 for acks
+		return true
+	}
+
+	if o.timer == nil {
+		o.timer = schedule()
+	}
+	return false
+}
+
+func (o *outbox) pushAck(msgID int64, schedule func() *time.Timer) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for _, id := range o.acks {
+		if id == msgID {
+			return
+		}
+	}
+
+	o.acks = append(o.acks, msgID)
+	if o.timer == nil {
+		o.timer = schedule()
+	}
+}
+
+// take returns all queued messages and acks, and clears queue.
+func (o *outbox) take() (msgs []*messages.Encrypted, acks []int64) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	msgs, acks = o.msgs, o.acks
+	o.msgs, o.acks, o.size = nil, nil, 0
+	if o.timer != nil {
+		o.timer.Stop()
+		o.timer = nil
+	}
+
+	return msgs, acks
+}
+
+// returnAcks puts acks back to queue, if they were not sent.
+func (o *outbox) returnAcks(acks []int64) {
+	o.mutex.Lock()
+	o.acks = append(acks, o.acks...)
+	o.mutex.Unlock()
+}
+
+// dropMessages removes queued requests, but keeps acks: they are still valid for session.
+func (o *outbox) dropMessages() {
+	o.mutex.Lock()
+	o.msgs, o.size = nil, 0
+	o.mutex.Unlock()
+}
+
+// unschedule forgets about fired flush timer, so next message schedules new one.
+func (o *outbox) unschedule() {
+	o.mutex.Lock()
+	o.timer = nil
+	o.mutex.Unlock()
+}
+
+// scheduleIfQueued schedules flush, if queue is not empty, e.g. after flush was postponed by unschedule.
+func (o *outbox) scheduleIfQueued(schedule func() *time.Timer) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.timer == nil && (len(o.msgs) > 0 || len(o.acks) > 0) {
+		o.timer = schedule()
+	}
+}
+
+// dropAcks removes queued acks, e.g. when auth key is recreated and old session doesn't exist anymore.
+func (o *outbox) dropAcks() {
+	o.mutex.Lock()
+	o.acks = nil
+	o.mutex.Unlock()
+}
+
+// rememberContainer saves ids of messages inside of sent container.
+func (o *outbox) rememberContainer(containerID int64, msgs []*messages.Encrypted) {
+	ids := make([]int64, len(msgs))
+	for i, msg := range msgs {
+		ids[i] = msg.MsgID
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.sent == nil {
+		o.sent = make(map[int64][]int64)
+	}
+	if len(o.sentOrder) >= outboxSentContainers {
+		delete(o.sent, o.sentOrder[0])
+		o.sentOrder = o.sentOrder[1:]
+	}
+	o.sent[containerID] = ids
+	o.sentOrder = append(o.sentOrder, containerID)
+}
+
+// containerMessages returns ids of messages inside of container, if msgID is id of recently sent
+// container.
+func (o *outbox) containerMessages(msgID int64) ([]int64, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	ids, ok := o.sent[msgID]
+	return ids, ok
+}
+
+// rememberSent saves content related messages, so they could be resent later.
+func (o *outbox) rememberSent(msgs ...*messages.Encrypted) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.history == nil {
+		o.history = make(map[int64]*messages.Encrypted)
+	}
+	for _, msg := range msgs {
+		if msg.SeqNo&1 == 0 {
+			continue
+		}
+		if _, ok := o.history[msg.MsgID]; ok {
+			// message is resent, it's already remembered
+			continue
+		}
+
+		if len(o.historyOrder) >= outboxHistorySize {
+			delete(o.history, o.historyOrder[0])
+			o.historyOrder = o.historyOrder[1:]
+		}
+		o.history[msg.MsgID] = msg
+		o.historyOrder = append(o.historyOrder, msg.MsgID)
+	}
+}
+
+// sentMessage returns recently sent message by its id.
+func (o *outbox) sentMessage(msgID int64) (*messages.Encrypted, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	msg, ok := o.history[msgID]
+	return msg, ok
+}
+
+// queueAck remembers message, which must be acknowledged by next msgs_ack.
+func (m *MTProto) queueAck(msgID int64) {
+	m.outbox.pushAck(msgID, m.scheduleOutboxFlush)
+}
+
+func (m *MTProto) scheduleOutboxFlush() *time.Timer {
+	return time.AfterFunc(outboxFlushDelay, func() {
+		if err := m.flushOutbox(); err != nil {
+			m.warnError(errors.Wrap(err, "sending container"))
+		}
+	})
+}
+
+// flushOutbox sends all queued messages and acks in single container.
+func (m *MTProto) flushOutbox() error {
+	m.rekeyMutex.RLock()
+	defer m.rekeyMutex.RUnlock()
+
+	m.seqNoMutex.Lock()
+	defer m.seqNoMutex.Unlock()
+
+	return m.flushOutboxLocked()
+}
+
+// flushOutboxLocked must be called with locked seqNoMutex.
+func (m *MTProto) flushOutboxLocked() error {
+	// while connection is creating, key could be changed. queue will be flushed after key exchange
+	if m.rekeying {
+		m.outbox.unschedule()
+		return nil
+	}
+
+	msgs, acks := m.outbox.take()
+
+	if len(acks) > 0 {
+		ack, err := tl.Marshal(&objects.MsgsAck{MsgIDs: acks})
+		if err != nil {
+			return errors.Wrap(err, "encoding acks")
+		}
+
+		// acks are not content related, so seqno is even
+		msgs = append(msgs, &messages.Encrypted{Msg: ack, MsgID: m.msgIDs.Next(), SeqNo: m.seqNo})
+		m.seqNo += 2
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	container := objects.MessageContainer(msgs)
+	data, err := tl.Marshal(&container)
+	if err != nil {
+		return errors.Wrap(err, "encoding container")
+	}
+
+	// msg_id of container must be bigger than ids of all messages inside
+	containerID := m.msgIDs.Next()
+	err = m.currentTransport().WriteMsg(&messages.Encrypted{Msg: data, MsgID: containerID}, false)
+	if err != nil {
+		m.outbox.returnAcks(acks)
+		return errors.Wrap(err, "sending container")
+	}
+	m.seqNo += 2
+	m.received.markAcked(acks)
+	m.outbox.rememberContainer(containerID, msgs)
+	m.outbox.rememberSent(msgs...)
+
+	return nil
+}
+
+// queueServiceMessage sends service message (e.g. answer to msgs_state_req) with next container. Answers to
+// service messages are not expected.
+func (m *MTProto) queueServiceMessage(obj tl.Object) error {
+	data, err := tl.Marshal(obj)
+	if err != nil {
+		return errors.Wrap(err, "encoding service message")
+	}
+
+	m.seqNoMutex.Lock()
+	defer m.seqNoMutex.Unlock()
+
+	seqNo := m.seqNo
+	if MessageRequireToAck(obj) {
+		seqNo |= 1
+	}
+	m.seqNo += 2
+
+	if m.outbox.push(&messages.Encrypted{Msg: data, MsgID: m.msgIDs.Next(), SeqNo: seqNo}, m.scheduleOutboxFlush) {
+		return m.flushOutboxLocked()
+	}
+	return nil
+}
+
+// resendMessages sends again recently sent messages with same msg_id and seqno. Returns false, if some of
+// messages are unknown (too old or were not sent by client at all).
+func (m *MTProto) resendMessages(ids []int64) (bool, error) {
+	m.seqNoMutex.Lock()
+	defer m.seqNoMutex.Unlock()
+
+	allFound := true
+	flush := false
+	for _, id := range ids {
+		msg, ok := m.outbox.sentMessage(id)
+		if !ok {
+			allFound = false
+			continue
+		}
+
+		flush = m.outbox.push(msg, m.scheduleOutboxFlush) || flush
+	}
+
+	if flush {
+		return allFound, m.flushOutboxLocked()
+	}
+	return allFound, nil
+}
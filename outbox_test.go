@@ -0,0 +1,147 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/messages"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+func newEncryptedTestMTProto(t *testing.T) (*MTProto, *recordingTransport) {
+	t.Helper()
+
+	m, err := NewMTProto(Config{})
+	require.NoError(t, err)
+	m.encrypted.Set(true)
+
+	tr := newRecordingTransport()
+	m.transport = tr
+	t.Cleanup(func() { tr.Close() })
+
+	return m, tr
+}
+
+func waitWritten(t *testing.T, tr *recordingTransport, count int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		tr.mu.Lock()
+		defer tr.mu.Unlock()
+		return len(tr.written) >= count
+	}, time.Second, time.Millisecond)
+}
+
+func TestOutboxCoalescesRequestsAndAcks(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	m.queueAck(4*1 + 1)
+	_, firstID, err := m.sendPacket(&objects.PingParams{PingID: 1})
+	require.NoError(t, err)
+	_, secondID, err := m.sendPacket(&objects.PingParams{PingID: 2})
+	require.NoError(t, err)
+	m.queueAck(4*2 + 1)
+
+	waitWritten(t, tr, 1)
+	time.Sleep(2 * outboxFlushDelay)
+
+	tr.mu.Lock()
+	require.Len(t, tr.written, 1, "everything must be sent in single packet")
+	container := mustDecode(t, tr.written[0].GetMsg())
+	tr.mu.Unlock()
+
+	inner := *container.(*objects.MessageContainer)
+	require.Len(t, inner, 3)
+	assert.Equal(t, firstID, inner[0].MsgID)
+	assert.Equal(t, secondID, inner[1].MsgID)
+	// requests are content related, acks are not
+	assert.Equal(t, int32(1), inner[0].SeqNo&1)
+	assert.Equal(t, int32(0), inner[2].SeqNo&1)
+	assert.Less(t, inner[0].SeqNo, inner[1].SeqNo)
+
+	assert.Equal(t, []tl.Object{
+		&objects.PingParams{PingID: 1},
+		&objects.PingParams{PingID: 2},
+		&objects.MsgsAck{MsgIDs: []int64{5, 9}},
+	}, tr.decoded(t))
+}
+
+func TestOutboxSendsBigRequestsImmediately(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	// zero ids are compressed too good, so message would fit into container after compression
+	m.gzipThreshold = 0
+
+	// each id is 8 bytes, so message is bigger than limit
+	_, _, err := m.sendPacket(&objects.MsgsAck{MsgIDs: make([]int64, outboxMaxMessageSize/8+1)})
+	require.NoError(t, err)
+
+	tr.mu.Lock()
+	require.Len(t, tr.written, 1)
+	_, isContainer := mustDecode(t, tr.written[0].GetMsg()).(*objects.MessageContainer)
+	tr.mu.Unlock()
+	assert.False(t, isContainer)
+}
+
+func TestBadMsgNotificationForContainer(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	resp, msgID, err := m.sendPacket(&objects.PingParams{PingID: 1})
+	require.NoError(t, err)
+	require.NoError(t, m.flushOutbox())
+	waitWritten(t, tr, 1)
+
+	tr.mu.Lock()
+	containerID := int64(tr.written[0].GetMsgID())
+	tr.mu.Unlock()
+	require.NotEqual(t, msgID, containerID)
+
+	m.handleBadMsgNotification(&messages.Encrypted{MsgID: m.msgIDs.Next()}, &objects.BadMsgNotification{
+		BadMsgID:    containerID,
+		BadMsgSeqNo: 0,
+		Code:        int32(ErrBadMsgSeqNoTooLow),
+	})
+
+	assert.IsType(t, &errorSessionConfigsChanged{}, <-resp)
+}
+
+func TestBadMsgSeqNoStartsNewSession(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+	oldSession := m.GetSessionID()
+
+	sent, sentID, err := m.sendPacket(&objects.PingParams{PingID: 1})
+	require.NoError(t, err)
+	require.NoError(t, m.flushOutbox())
+	waitWritten(t, tr, 1)
+
+	queued, _, err := m.sendPacket(&objects.PingParams{PingID: 2})
+	require.NoError(t, err)
+
+	m.handleBadMsgNotification(&messages.Encrypted{MsgID: m.msgIDs.Next()}, &objects.BadMsgNotification{
+		BadMsgID:    sentID,
+		BadMsgSeqNo: 1,
+		Code:        int32(ErrBadMsgSeqNoTooHigh),
+	})
+
+	assert.NotEqual(t, oldSession, m.GetSessionID())
+	assert.Equal(t, int32(0), m.GetSeqNo())
+	assert.IsType(t, &errorSessionConfigsChanged{}, <-sent)
+	assert.IsType(t, &errorSessionConfigsChanged{}, <-queued)
+}
+
+func mustDecode(t *testing.T, data []byte) tl.Object {
+	t.Helper()
+
+	obj, err := tl.DecodeUnknownObject(data)
+	require.NoError(t, err)
+	return obj
+}
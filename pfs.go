@@ -0,0 +1,177 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xelaj/go-dry"
+
+	ige "github.com/umesproject/mtproto/internal/aes_ige"
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+	"github.com/umesproject/mtproto/internal/utils"
+)
+
+// https://core.telegram.org/api/pfs
+
+// msgIDBinder is a request, which content depends on msg_id of message, that contains this request.
+type msgIDBinder interface {
+	tl.Object
+	marshalWithMsgID(msgID int64) ([]byte, error)
+}
+
+// bindTempAuthKeyRequest is auth.bindTempAuthKey. Inner message is encrypted with msg_id of request, so
+// it's serialized only when msg_id is known.
+// https://core.telegram.org/method/auth.bindTempAuthKey
+type bindTempAuthKeyRequest struct {
+	permAuthKey   []byte
+	tempAuthKeyID int64
+	tempSessionID int64
+	nonce         int64
+	expiresAt     int32
+}
+
+func (*bindTempAuthKeyRequest) CRC() uint32 {
+	return (&objects.BindTempAuthKeyParams{}).CRC()
+}
+
+func (r *bindTempAuthKeyRequest) marshalWithMsgID(msgID int64) ([]byte, error) {
+	permAuthKeyID := authKeyID(r.permAuthKey)
+
+	inner, err := tl.Marshal(&objects.BindAuthKeyInner{
+		Nonce:         r.nonce,
+		TempAuthKeyID: r.tempAuthKeyID,
+		PermAuthKeyID: permAuthKeyID,
+		TempSessionID: r.tempSessionID,
+		ExpiresAt:     r.expiresAt,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding bind_auth_key_inner")
+	}
+
+	// message is encrypted like usual MTProto 1.0 message, but random bytes are used instead of salt and
+	// session id, and seqno is always 0
+	buf := bytes.NewBuffer(nil)
+	e := tl.NewEncoder(buf)
+	e.PutRawBytes(dry.RandomBytes(tl.Int128Len))
+	e.PutLong(msgID)
+	e.PutInt(0)
+	e.PutInt(int32(len(inner)))
+	e.PutRawBytes(inner)
+	if err := e.CheckErr(); err != nil {
+		return nil, err
+	}
+
+	msgKey, encrypted, err := ige.EncryptV1(buf.Bytes(), r.permAuthKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting bind_auth_key_inner")
+	}
+
+	return tl.Marshal(&objects.BindTempAuthKeyParams{
+		PermAuthKeyID:    permAuthKeyID,
+		Nonce:            r.nonce,
+		ExpiresAt:        r.expiresAt,
+		EncryptedMessage: bytes.Join([][]byte{utils.AuthKeyHash(r.permAuthKey), msgKey, encrypted}, nil),
+	})
+}
+
+// authKeyID returns auth_key_id: 64 lower bits of SHA1 of key.
+func authKeyID(key []byte) int64 {
+	return int64(binary.LittleEndian.Uint64(utils.AuthKeyHash(key)))
+}
+
+// permanentAuthKey returns key, which must be stored in session.
+func (m *MTProto) permanentAuthKey() (key, hash []byte) {
+	if m.permAuthKey != nil {
+		return m.permAuthKey, utils.AuthKeyHash(m.permAuthKey)
+	}
+	return m.authKey, m.authKeyHash
+}
+
+// createTempAuthKey makes new temporary auth key and binds it to permanent one. Temporary key is used in
+// new session.
+func (m *MTProto) createTempAuthKey() error {
+	if m.permAuthKey == nil {
+		m.permAuthKey = m.authKey
+	}
+
+	expiresIn := int32(m.tempAuthKeyTTL / time.Second)
+	expiresAt := m.msgIDs.ServerTime().Add(m.tempAuthKeyTTL)
+
+	m.encrypted.Set(false)
+	if err := m.makeAuthKey(expiresIn); err != nil {
+		return err
+	}
+
+	// new key requires new session
+	m.startNewSession()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := m.makeRequest(withKeyExchange(ctx), &bindTempAuthKeyRequest{
+		permAuthKey:   m.permAuthKey,
+		tempAuthKeyID: authKeyID(m.authKey),
+		tempSessionID: m.sessionId,
+		nonce:         int64(binary.LittleEndian.Uint64(dry.RandomBytes(tl.LongLen))),
+		expiresAt:     int32(expiresAt.Unix()),
+	})
+	if err != nil {
+		return errors.Wrap(err, "binding temporary key")
+	}
+	if ok, _ := resp.(bool); !ok {
+		return errors.New("server refused to bind temporary key")
+	}
+
+	m.tempAuthKeyExpiresAt = expiresAt
+	return nil
+}
+
+// startTempAuthKeyRotation replaces temporary key, when 90% of it's lifetime passed.
+func (m *MTProto) startTempAuthKeyRotation(ctx context.Context) {
+	rotateAfter := m.tempAuthKeyExpiresAt.Sub(m.msgIDs.ServerTime()) - m.tempAuthKeyTTL/10 //nolint:gomnd 10%
+
+	m.routineswg.Add(1)
+	go func() {
+		defer m.routineswg.Done()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rotateAfter):
+		}
+
+		// reconnection stops this routine, so it can't wait for it
+		go func() {
+			if err := m.rotateTempAuthKey(); err != nil {
+				m.warnError(errors.Wrap(err, "rotating temporary auth key"))
+			}
+		}()
+	}()
+}
+
+// rotateTempAuthKey opens new connection with new temporary key. New key is used in new session, so
+// requests, which were sent with old key, fail (see reconnectNewSession).
+func (m *MTProto) rotateTempAuthKey() error {
+	if !atomic.CompareAndSwapInt32(&m.reconnecting, 0, 1) {
+		return nil // new key will be created by reconnection
+	}
+
+	err := m.reconnectNewSession(false, 1)
+	atomic.StoreInt32(&m.reconnecting, 0)
+	if err != nil {
+		go m.superviseReconnect(err)
+		return err
+	}
+
+	return nil
+}
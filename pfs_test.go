@@ -0,0 +1,163 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xelaj/go-dry"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+	"github.com/umesproject/mtproto/internal/session"
+	"github.com/umesproject/mtproto/internal/utils"
+	"github.com/umesproject/mtproto/mtprototest"
+)
+
+func TestBindTempAuthKeyRequest(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	permKey := dry.RandomBytes(256)
+	tempKey := dry.RandomBytes(256)
+	m.SetAuthKey(tempKey)
+
+	_, msgID, err := m.sendPacket(&bindTempAuthKeyRequest{
+		permAuthKey:   permKey,
+		tempAuthKeyID: authKeyID(tempKey),
+		tempSessionID: m.sessionId,
+		nonce:         42,
+		expiresAt:     1600000000,
+	})
+	require.NoError(t, err)
+
+	// bind request is never sent in container
+	tr.mu.Lock()
+	require.Len(t, tr.written, 1)
+	written := tr.written[0]
+	tr.mu.Unlock()
+	assert.Equal(t, int(msgID), written.GetMsgID())
+
+	params := new(objects.BindTempAuthKeyParams)
+	require.NoError(t, tl.Decode(written.GetMsg(), params))
+	assert.Equal(t, authKeyID(permKey), params.PermAuthKeyID)
+	assert.Equal(t, int64(42), params.Nonce)
+	assert.Equal(t, int32(1600000000), params.ExpiresAt)
+
+	// auth_key_id + msg_key + encrypted data, padded to 16 bytes
+	encrypted := params.EncryptedMessage
+	require.True(t, bytes.HasPrefix(encrypted, utils.AuthKeyHash(permKey)))
+	assert.Zero(t, (len(encrypted)-tl.LongLen-tl.Int128Len)%16)
+}
+
+func TestPermanentAuthKeyInSession(t *testing.T) {
+	m, err := NewMTProto(Config{TempAuthKeyTTL: 24 * time.Hour})
+	require.NoError(t, err)
+
+	permKey := dry.RandomBytes(256)
+	m.SetAuthKey(permKey)
+	m.permAuthKey = permKey
+	m.SetAuthKey(dry.RandomBytes(256))
+
+	s := new(session.Session)
+	require.NoError(t, json.Unmarshal([]byte(m.GetSessionJSON()), s))
+	assert.Equal(t, permKey, s.Key, "temporary key must not be stored")
+	assert.Equal(t, utils.AuthKeyHash(permKey), s.Hash)
+}
+
+func TestAuthKeyPermEmptyWithoutPFS(t *testing.T) {
+	m, err := NewMTProto(Config{})
+	require.NoError(t, err)
+
+	e := &ErrResponseCode{Code: 401, Message: "AUTH_KEY_PERM_EMPTY"}
+	assert.Equal(t, e, m.tryToProcessErr(e))
+}
+
+type rotationParams struct {
+	Text string
+}
+
+func (*rotationParams) CRC() uint32 {
+	return 0x0e0e0e11 //nolint:gomnd not magic
+}
+
+type rotationResult struct {
+	Text string
+}
+
+func (*rotationResult) CRC() uint32 {
+	return 0x0e0e0e12 //nolint:gomnd not magic
+}
+
+func init() {
+	tl.RegisterObjects(&rotationResult{})
+}
+
+func TestTempAuthKeyRotationDoesNotRepeatRequests(t *testing.T) {
+	s, err := mtprototest.NewServer(mtprototest.Config{})
+	require.NoError(t, err)
+	defer s.Close()
+
+	var mu sync.Mutex
+	received := make(map[string]int)
+	release := make(chan struct{})
+	s.Handle(&rotationParams{}, func(req mtprototest.Object) (mtprototest.Object, error) {
+		text := req.(*rotationParams).Text
+		mu.Lock()
+		received[text]++
+		mu.Unlock()
+		if text == "pending" {
+			<-release
+		}
+		return &rotationResult{Text: text}, nil
+	})
+
+	m, err := NewMTProto(Config{
+		ServerHost:     s.Addr(),
+		PublicKeys:     []*rsa.PublicKey{s.PublicKey()},
+		TempAuthKeyTTL: time.Hour,
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.CreateConnection())
+	defer m.Close(context.Background())
+	defer close(release)
+
+	_, err = m.MakeRequest(&rotationParams{Text: "answered"})
+	require.NoError(t, err)
+
+	pending := make(chan error, 1)
+	go func() {
+		_, err := m.MakeRequest(&rotationParams{Text: "pending"})
+		pending <- err
+	}()
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return received["pending"] == 1
+	}, 5*time.Second, time.Millisecond)
+
+	require.NoError(t, m.rotateTempAuthKey())
+
+	// server already received request, so it's not sent again with new key
+	var reconnectErr *ReconnectError
+	require.True(t, errors.As(<-pending, &reconnectErr))
+	assert.True(t, reconnectErr.Restored)
+
+	_, err = m.MakeRequest(&rotationParams{Text: "after rotation"})
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]int{"answered": 1, "pending": 1, "after rotation": 1}, received)
+}
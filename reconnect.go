@@ -0,0 +1,301 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+// ReconnectPolicy decides, how long client waits before next attempt to restore lost connection.
+type ReconnectPolicy interface {
+	// Delay is called before every attempt, attempt is count of previous failed attempts. If ok is false,
+	// client stops reconnecting and all pending requests fail with *ReconnectError.
+	Delay(attempt int) (delay time.Duration, ok bool)
+}
+
+// ExponentialBackoff doubles delay after every failed attempt, starting with MinDelay and up to MaxDelay.
+// Every delay is randomized in range [delay/2, delay], so a lot of clients don't reconnect at same time.
+// If MaxAttempts is more than zero, client reconnects no more than MaxAttempts times.
+type ExponentialBackoff struct {
+	MinDelay    time.Duration
+	MaxDelay    time.Duration
+	MaxAttempts int
+}
+
+var _ ReconnectPolicy = (*ExponentialBackoff)(nil)
+
+func (p *ExponentialBackoff) Delay(attempt int) (time.Duration, bool) {
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	delay := p.MinDelay
+	for i := 0; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0, true
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1)), true //nolint:gosec jitter is not secret
+}
+
+func defaultReconnectPolicy() ReconnectPolicy {
+	return &ExponentialBackoff{
+		MinDelay:    500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		MaxAttempts: 8,
+	}
+}
+
+// ReconnectError is returned to all pending requests, when connection was lost and ReconnectPolicy
+// decided to stop reconnecting. It's also returned, when connection was restored, but client can't find
+// out, whether server received request before connection was lost.
+type ReconnectError struct {
+	Attempts int
+	Err      error // last error of reconnection, or reason, why request can't be restored
+	// Restored is true, if connection was restored, but request wasn't sent again: server could already
+	// process it, and repeating of request (e.g. messages.sendMessage) could process it twice. Caller
+	// decides, is it safe to repeat request.
+	Restored bool
+}
+
+func (e *ReconnectError) Error() string {
+	if e.Restored {
+		return fmt.Sprintf("connection was restored after %d attempts, but state of request is unknown: %v",
+			e.Attempts, e.Err)
+	}
+	return fmt.Sprintf("connection lost, can't reconnect after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *ReconnectError) Unwrap() error {
+	return e.Err
+}
+
+// CRC is required to send error through response channels
+func (*ReconnectError) CRC() uint32 {
+	panic("makes no sense")
+}
+
+// errConnectionLost wraps errors of transport, after which connection can't be used anymore.
+type errConnectionLost struct {
+	err error
+}
+
+func (e *errConnectionLost) Error() string {
+	return "connection lost: " + e.err.Error()
+}
+
+func (e *errConnectionLost) Unwrap() error {
+	return e.err
+}
+
+// errSessionClosed means, that request was sent in session, which was replaced by new one (e.g. after
+// rotation of temporary key), so response to it is lost.
+var errSessionClosed = errors.New("request was sent in previous session")
+
+// errRequestStateUnknown means, that server can't say, whether it received request (e.g. server already
+// forgot about it).
+var errRequestStateUnknown = errors.New("server doesn't know state of request")
+
+// superviseReconnect restores connection after it was lost, following reconnect policy. After successful
+// reconnection pending requests are restored by restorePendingRequests, otherwise they fail with
+// *ReconnectError.
+func (m *MTProto) superviseReconnect(cause error) {
+	if !atomic.CompareAndSwapInt32(&m.reconnecting, 0, 1) {
+		return // already reconnecting
+	}
+	defer atomic.StoreInt32(&m.reconnecting, 0)
+
+	m.warnError(cause)
+
+	lastErr := cause
+	for attempt := 0; ; attempt++ {
+		delay, ok := m.reconnectPolicy.Delay(attempt)
+		if !ok {
+			m.logger.Log(LevelError, "can't restore connection", FieldError(lastErr))
+			m.failPendingRequests(&ReconnectError{Attempts: attempt, Err: lastErr})
+			return
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-m.closing:
+			// pending requests are resolved by Close
+			return
+		}
+
+		// new temporary key is bound in new session, which knows nothing about requests of old one
+		if m.tempAuthKeyTTL > 0 {
+			err := m.reconnectNewSession(false, attempt+1)
+			if err == nil {
+				m.logger.Log(LevelInfo, "connection restored", Field{Key: "attempt", Value: attempt + 1})
+				return
+			}
+			lastErr = err
+			m.warnError(errors.Wrapf(err, "reconnecting, attempt %d", attempt+1))
+			continue
+		}
+
+		err := m.Reconnect(false)
+		if err == nil {
+			m.logger.Log(LevelInfo, "connection restored", Field{Key: "attempt", Value: attempt + 1})
+			// connection could be lost again while server is answering, so supervisor must be free
+			go m.restorePendingRequests(attempt + 1)
+			return
+		}
+
+		lastErr = err
+		m.warnError(errors.Wrapf(err, "reconnecting, attempt %d", attempt+1))
+	}
+}
+
+// restorePendingRequests asks server about state of requests, which were waiting for response, when
+// connection was lost. Requests, which server didn't receive, are sent again. Received ones keep waiting:
+// session is same, so server sends their responses through new connection. Other requests fail with
+// *ReconnectError, cause it's unsafe to send them again.
+// https://core.telegram.org/mtproto/service_messages_about_messages#request-for-message-status
+func (m *MTProto) restorePendingRequests(attempts int) {
+	ids := m.pendingRequests()
+	if len(ids) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := m.makeRequest(ctx, &objects.MsgsStateReq{MsgIDs: ids})
+	info, ok := resp.(*objects.MsgsStateInfo)
+	switch {
+	case err != nil:
+		err = errors.Wrap(err, "requesting state of messages")
+	case !ok || len(info.Info) != len(ids):
+		err = errors.New("got wrong response to msgs_state_req")
+	}
+	if err != nil {
+		m.warnError(err)
+		for _, id := range ids {
+			m.resolveRequest(id, &ReconnectError{Attempts: attempts, Err: err, Restored: true})
+		}
+		return
+	}
+
+	for i, id := range ids {
+		switch info.Info[i] & 7 { //nolint:gomnd lower 3 bits are state, others are flags
+		case msgStateReceived:
+			// response will be sent by server
+		case msgStateNotReceived, msgStateTooHigh:
+			m.resolveRequest(id, &errorSessionConfigsChanged{})
+		default:
+			m.resolveRequest(id, &ReconnectError{Attempts: attempts, Err: errRequestStateUnknown, Restored: true})
+		}
+	}
+}
+
+// reconnectNewSession recreates connection, which starts new session. Server doesn't send responses of
+// old session to new one, and it could already process requests of old session, so they fail with
+// *ReconnectError (Restored is true), and caller decides, is it safe to repeat them. Requests, which were
+// queued, but not sent yet, are sent again in new session.
+func (m *MTProto) reconnectNewSession(makeAuthKeyAgain bool, attempts int) error {
+	m.rekeyMutex.Lock()
+	sent, unsent := m.takeSessionRequests()
+	err := m.reconnect(makeAuthKeyAgain)
+	m.rekeyMutex.Unlock()
+
+	for _, id := range unsent {
+		m.resolveRequest(id, &errorSessionConfigsChanged{})
+	}
+	for _, id := range sent {
+		m.resolveRequest(id, &ReconnectError{Attempts: attempts, Err: errSessionClosed, Restored: true})
+	}
+
+	return err
+}
+
+// takeSessionRequests clears queue of current session and returns ids of pending requests: already sent
+// and still queued ones. Must be called with locked rekeyMutex, so no other requests could be sent.
+func (m *MTProto) takeSessionRequests() (sent, unsent []int64) {
+	m.seqNoMutex.Lock()
+	// acks belong to current session too
+	msgs, _ := m.outbox.take()
+	m.seqNoMutex.Unlock()
+
+	queued := make(map[int64]bool, len(msgs))
+	for _, msg := range msgs {
+		queued[msg.MsgID] = true
+	}
+
+	for _, id := range m.pendingRequests() {
+		if queued[id] {
+			unsent = append(unsent, id)
+		} else {
+			sent = append(sent, id)
+		}
+	}
+	return sent, unsent
+}
+
+// pendingRequests returns ids of requests, which are waiting for response.
+func (m *MTProto) pendingRequests() []int64 {
+	keys := m.responseChannels.Keys()
+	ids := make([]int64, len(keys))
+	for i, k := range keys {
+		ids[i] = int64(k)
+	}
+	return ids
+}
+
+// resolveRequest sends response to pending request, if it's still waiting.
+func (m *MTProto) resolveRequest(msgID int64, response tl.Object) {
+	ch, ok := m.responseChannels.Get(int(msgID))
+	if !ok {
+		return
+	}
+	m.responseChannels.Delete(int(msgID))
+	m.expectedTypes.Delete(int(msgID))
+
+	select {
+	case ch <- response:
+	default:
+	}
+}
+
+func (m *MTProto) failPendingRequests(err *ReconnectError) {
+	m.resolvePendingRequests(func() tl.Object { return err })
+}
+
+func (m *MTProto) resolvePendingRequests(response func() tl.Object) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, k := range m.responseChannels.Keys() {
+		v, ok := m.responseChannels.Get(k)
+		if !ok {
+			continue
+		}
+		m.responseChannels.Delete(k)
+		m.expectedTypes.Delete(k)
+
+		// channels are buffered, but it's better to not block, if someone already wrote to channel
+		select {
+		case v <- response():
+		default:
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	p := &ExponentialBackoff{
+		MinDelay:    100 * time.Millisecond,
+		MaxDelay:    time.Second,
+		MaxAttempts: 6,
+	}
+
+	for attempt, max := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		delay, ok := p.Delay(attempt)
+		require.True(t, ok, "attempt %d", attempt)
+		assert.GreaterOrEqual(t, int64(delay), int64(max/2), "attempt %d", attempt)
+		assert.LessOrEqual(t, int64(delay), int64(max), "attempt %d", attempt)
+	}
+
+	_, ok := p.Delay(6)
+	assert.False(t, ok)
+}
+
+type giveUpPolicy struct{}
+
+func (giveUpPolicy) Delay(int) (time.Duration, bool) { return 0, false }
+
+func TestSuperviseReconnectFailsPendingRequests(t *testing.T) {
+	m, err := NewMTProto(Config{ReconnectPolicy: giveUpPolicy{}})
+	require.NoError(t, err)
+
+	pending := make([]chan tl.Object, 3)
+	for i := range pending {
+		pending[i] = m.getRespChannel()
+		m.responseChannels.Add(i*4+1, pending[i])
+	}
+
+	m.superviseReconnect(&errConnectionLost{err: io.EOF})
+
+	for _, ch := range pending {
+		select {
+		case resp := <-ch:
+			var reconnectErr *ReconnectError
+			require.True(t, errors.As(resp.(error), &reconnectErr))
+			assert.Equal(t, 0, reconnectErr.Attempts)
+			assert.True(t, errors.Is(reconnectErr, io.EOF))
+		default:
+			t.Fatal("pending request didn't receive error")
+		}
+	}
+	assert.Empty(t, m.responseChannels.Keys())
+}
+
+func TestTakeSessionRequests(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	_, sentID, err := m.sendPacket(&objects.PingParams{PingID: 1})
+	require.NoError(t, err)
+	require.NoError(t, m.flushOutbox())
+	waitWritten(t, tr, 1)
+	_, queuedID, err := m.sendPacket(&objects.PingParams{PingID: 2})
+	require.NoError(t, err)
+
+	sent, unsent := m.takeSessionRequests()
+	assert.Equal(t, []int64{sentID}, sent)
+	assert.Equal(t, []int64{queuedID}, unsent)
+
+	// queue belongs to old session, so nothing is sent by it anymore
+	msgs, _ := m.outbox.take()
+	assert.Empty(t, msgs)
+}
+
+func TestRestorePendingRequests(t *testing.T) {
+	m, tr := newEncryptedTestMTProto(t)
+
+	received, notReceived, unknown := m.getRespChannel(), m.getRespChannel(), m.getRespChannel()
+	m.responseChannels.Add(1*4, received)
+	m.responseChannels.Add(2*4, notReceived)
+	m.responseChannels.Add(3*4, unknown)
+
+	done := make(chan struct{})
+	go func() {
+		m.restorePendingRequests(2)
+		close(done)
+	}()
+
+	waitWritten(t, tr, 1)
+	tr.mu.Lock()
+	container := *mustDecode(t, tr.written[0].GetMsg()).(*objects.MessageContainer)
+	tr.mu.Unlock()
+	require.Len(t, container, 1)
+	req, ok := mustDecode(t, container[0].Msg).(*objects.MsgsStateReq)
+	require.True(t, ok)
+
+	states := map[int64]byte{4: msgStateReceived, 8: msgStateNotReceived, 12: msgStateUnknown}
+	info := make([]byte, len(req.MsgIDs))
+	for i, id := range req.MsgIDs {
+		info[i] = states[id]
+	}
+	require.NoError(t, m.processResponse(serverMessage(t, m.msgIDs.Next(), 0, &objects.MsgsStateInfo{
+		ReqMsgID: container[0].MsgID,
+		Info:     info,
+	})))
+	<-done
+
+	// server received request, so response will come later
+	assert.True(t, m.responseChannels.Has(4))
+	assert.Empty(t, received)
+	// request wasn't received, so it's sent again
+	assert.IsType(t, &errorSessionConfigsChanged{}, <-notReceived)
+	// request could be processed, repeating is unsafe
+	var reconnectErr *ReconnectError
+	require.True(t, errors.As((<-unknown).(error), &reconnectErr))
+	assert.True(t, reconnectErr.Restored)
+	assert.Equal(t, 2, reconnectErr.Attempts)
+}
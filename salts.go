@@ -0,0 +1,142 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+	"github.com/umesproject/mtproto/internal/session"
+)
+
+const (
+	// how many salts are requested by single get_future_salts (server returns no more than 64)
+	futureSaltsCount = 32
+	// new salts are requested, when known schedule ends sooner than this
+	futureSaltsReserve = time.Hour
+	// how often salts schedule is checked
+	futureSaltsCheckInterval = 10 * time.Minute
+)
+
+// https://core.telegram.org/mtproto/service_messages#request-for-several-future-salts
+
+// GetServerSalt returns current server salt 🧐
+// if salt from schedule becomes valid, it replaces current one.
+func (m *MTProto) GetServerSalt() int64 {
+	m.saltMutex.Lock()
+	defer m.saltMutex.Unlock()
+
+	now := m.msgIDs.ServerTime().Unix()
+
+	// expired salts are not needed anymore
+	i := 0
+	for i < len(m.futureSalts) && m.futureSalts[i].ValidUntil <= now {
+		i++
+	}
+	m.futureSalts = m.futureSalts[i:]
+
+	if len(m.futureSalts) > 0 && m.futureSalts[0].ValidSince <= now {
+		m.serverSalt = m.futureSalts[0].Salt
+	}
+
+	return m.serverSalt
+}
+
+func (m *MTProto) setServerSalt(salt int64) {
+	m.saltMutex.Lock()
+	m.serverSalt = salt
+	m.saltMutex.Unlock()
+}
+
+// setFutureSalts replaces salts schedule.
+func (m *MTProto) setFutureSalts(salts []session.FutureSalt) {
+	schedule := make([]session.FutureSalt, len(salts))
+	copy(schedule, salts)
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i].ValidSince < schedule[j].ValidSince })
+
+	m.saltMutex.Lock()
+	m.futureSalts = schedule
+	m.saltMutex.Unlock()
+}
+
+func (m *MTProto) getFutureSalts() []session.FutureSalt {
+	m.saltMutex.Lock()
+	defer m.saltMutex.Unlock()
+
+	salts := make([]session.FutureSalt, len(m.futureSalts))
+	copy(salts, m.futureSalts)
+	return salts
+}
+
+func (m *MTProto) applyFutureSalts(message *objects.FutureSalts) {
+	salts := make([]session.FutureSalt, len(message.Salts))
+	for i, salt := range message.Salts {
+		salts[i] = session.FutureSalt{
+			Salt:       salt.Salt,
+			ValidSince: int64(salt.ValidSince),
+			ValidUntil: int64(salt.ValidUntil),
+		}
+	}
+
+	m.setFutureSalts(salts)
+}
+
+// needFutureSalts returns true, if salts schedule is going to end soon.
+func (m *MTProto) needFutureSalts() bool {
+	m.saltMutex.Lock()
+	defer m.saltMutex.Unlock()
+
+	if len(m.futureSalts) == 0 {
+		return true
+	}
+
+	last := time.Unix(m.futureSalts[len(m.futureSalts)-1].ValidUntil, 0)
+	return last.Sub(m.msgIDs.ServerTime()) < futureSaltsReserve
+}
+
+// requestFutureSalts asks prefetching routine to update salts schedule right now.
+func (m *MTProto) requestFutureSalts() {
+	select {
+	case m.saltsUpdate <- struct{}{}:
+	default:
+		// update is already requested
+	}
+}
+
+// startSaltsPrefetching keeps schedule of future salts, so client switches salt before server rejects
+// messages with bad_server_salt.
+func (m *MTProto) startSaltsPrefetching(ctx context.Context) {
+	m.routineswg.Add(1)
+	go func() {
+		defer m.routineswg.Done()
+		defer m.recoverGoroutine()
+
+		ticker := time.NewTicker(futureSaltsCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			if m.encrypted.Get() && m.needFutureSalts() {
+				salts, err := objects.GetFutureSalts(m, futureSaltsCount)
+				if err != nil {
+					m.warnError(errors.Wrap(err, "getting future salts"))
+				} else {
+					m.applyFutureSalts(salts)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-m.saltsUpdate:
+			}
+		}
+	}()
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package mtproto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+	"github.com/umesproject/mtproto/internal/mtproto/objects"
+	"github.com/umesproject/mtproto/internal/session"
+)
+
+func TestServerSaltRotation(t *testing.T) {
+	m, err := NewMTProto(Config{})
+	require.NoError(t, err)
+
+	now := time.Now().Unix()
+	m.setServerSalt(1)
+	m.setFutureSalts([]session.FutureSalt{
+		{Salt: 3, ValidSince: now + 3600, ValidUntil: now + 7200},
+		{Salt: 2, ValidSince: now - 60, ValidUntil: now + 3600},
+	})
+	assert.Equal(t, int64(2), m.GetServerSalt())
+	assert.False(t, m.needFutureSalts())
+
+	// an hour later server time
+	m.msgIDs.SetTimeOffset(3600)
+	assert.Equal(t, int64(3), m.GetServerSalt())
+	assert.Equal(t, []session.FutureSalt{{Salt: 3, ValidSince: now + 3600, ValidUntil: now + 7200}}, m.getFutureSalts())
+
+	// schedule ended, last known salt is used until server sends new one
+	m.msgIDs.SetTimeOffset(7200)
+	assert.Equal(t, int64(3), m.GetServerSalt())
+	assert.Empty(t, m.getFutureSalts())
+	assert.True(t, m.needFutureSalts())
+}
+
+func TestFutureSaltsResponse(t *testing.T) {
+	m, _ := newEncryptedTestMTProto(t)
+
+	now := int32(time.Now().Unix())
+	salts := &objects.FutureSalts{
+		ReqMsgID: 4 * 10,
+		Now:      now,
+		Salts: []*objects.FutureSalt{
+			{ValidSince: now - 60, ValidUntil: now + 3600, Salt: 2},
+			{ValidSince: now + 3600, ValidUntil: now + 4*3600, Salt: 3},
+		},
+	}
+
+	resp := m.getRespChannel()
+	m.responseChannels.Add(4*10, resp)
+	require.NoError(t, m.processResponse(serverMessage(t, 4*11+1, 0, salts)))
+
+	// decoded as bare vector of bare salts
+	assert.Equal(t, salts, <-resp)
+	assert.Equal(t, int64(2), m.GetServerSalt())
+	assert.False(t, m.needFutureSalts())
+
+	data, err := tl.Marshal(salts)
+	require.NoError(t, err)
+	assert.Len(t, data, tl.WordLen+tl.LongLen+tl.WordLen+tl.WordLen+2*(tl.WordLen+tl.WordLen+tl.LongLen))
+}
+
+func TestBadServerSaltResendsOnlyBadMessage(t *testing.T) {
+	m, _ := newEncryptedTestMTProto(t)
+	m.setFutureSalts([]session.FutureSalt{{Salt: 2, ValidSince: 0, ValidUntil: time.Now().Unix() + 3600}})
+
+	bad, other := m.getRespChannel(), m.getRespChannel()
+	m.responseChannels.Add(4*10, bad)
+	m.responseChannels.Add(4*11, other)
+
+	require.NoError(t, m.processResponse(serverMessage(t, 4*12+1, 0, &objects.BadServerSalt{
+		BadMsgID:  4 * 10,
+		ErrorCode: int32(ErrBadMsgServerSaltIncorrect),
+		NewSalt:   5,
+	})))
+
+	assert.IsType(t, &errorSessionConfigsChanged{}, <-bad)
+	assert.Empty(t, other)
+	assert.Equal(t, int64(5), m.GetServerSalt())
+	assert.Empty(t, m.getFutureSalts(), "outdated schedule is dropped")
+	assert.Len(t, m.saltsUpdate, 1, "new salts are requested")
+}
@@ -6,11 +6,13 @@
 package telegram
 
 import (
+	"crypto/rsa"
 	"encoding/json"
+	"io"
 	"reflect"
 	"runtime"
+	"time"
 
-	"github.com/k0kubun/pp"
 	"github.com/pkg/errors"
 
 	"strconv"
@@ -22,17 +24,26 @@ import (
 )
 
 type Client struct {
+	// MTProto is connection, which was created by NewClient. It's never replaced, so after migration to
+	// other DC requests of Client are sent through other connection (see PrimaryDC).
 	*mtproto.MTProto
 	config               *ClientConfig
 	serverConfig         *Config
 	initConnectionParams *InitConnectionParams
+	publicKeys           []*rsa.PublicKey
+	dcList               map[int]string
+	dcs                  *dcPool
 }
 
 type ClientConfig struct {
-	LiveUpdates     bool
-	Debug           bool
-	Session         *session.Session
-	ServerHost      string
+	LiveUpdates bool
+	Debug       bool
+	Session     *session.Session
+	ServerHost  string
+	// DC is id of datacenter of ServerHost, see mtproto.Config
+	DC int
+	// TestDC must be set, if client connects to test environment, see mtproto.Config
+	TestDC          bool
 	DeviceModel     string
 	SystemVersion   string
 	AppVersion      string
@@ -40,6 +51,24 @@ type ClientConfig struct {
 	AppHash         string
 	InitWarnChannel bool
 	ProxyUrl        string
+	FloodWaitPolicy mtproto.FloodWaitPolicy
+	TransportMode   mtproto.TransportMode
+	Connection      mtproto.ConnectionType
+	ReconnectPolicy mtproto.ReconnectPolicy
+	GzipThreshold   int
+	// TempAuthKeyTTL enables perfect forward secrecy, see mtproto.Config
+	TempAuthKeyTTL time.Duration
+	// PublicKeys replaces built-in telegram keys, e.g. for test DCs or custom servers. PublicKeysFile
+	// is path to pem file with such keys, keys from file are added to PublicKeys.
+	PublicKeys     []*rsa.PublicKey
+	PublicKeysFile string
+	// LegacyRSAEncryption, see mtproto.Config
+	LegacyRSAEncryption bool
+	// RecordTraffic and ReplayTraffic work only for connection to primary DC, see mtproto.Config
+	RecordTraffic io.Writer
+	ReplayTraffic io.Reader
+	// Logger receives messages of all connections, see mtproto.Config
+	Logger mtproto.Logger
 }
 
 const (
@@ -62,39 +91,35 @@ func NewClient(c ClientConfig) (*Client, error) { //nolint: gocritic arg is not
 		c.AppVersion = "v0.0.0"
 	}
 
-	publicKeys, err := keys.Read()
+	publicKeys, err := readPublicKeys(&c)
 	if err != nil {
 		return nil, errors.Wrap(err, "reading public keys")
 	}
 
-	m, err := mtproto.NewMTProto(mtproto.Config{
-		Session:    c.Session,
-		Debug:      c.Debug,
-		ServerHost: c.ServerHost,
-		PublicKey:  publicKeys[0],
-		ProxyUrl:   c.ProxyUrl,
-	})
+	client := &Client{
+		config:     &c,
+		publicKeys: publicKeys,
+		dcs:        newDCPool(),
+	}
 
+	m, err := client.newMTProto(mtproto.Config{
+		Session:       c.Session,
+		ServerHost:    c.ServerHost,
+		DC:            c.DC,
+		RecordTraffic: c.RecordTraffic,
+		ReplayTraffic: c.ReplayTraffic,
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "setup common MTProto client")
 	}
 
-	if c.InitWarnChannel {
-		m.Warnings = make(chan error, warnChannelDefaultCapacity)
-	}
-	//m.Ctx, m.Cancelfunc = context.WithCancel(context.Background())
-	//m.StartReadingResponses(m.Ctx)
-
 	err = m.CreateConnection()
 	if err != nil {
 		return nil, errors.Wrap(err, "creating connection")
 	}
-	//m.StartPinging(m.Ctx)
 
-	client := &Client{
-		MTProto: m,
-		config:  &c,
-	}
+	client.MTProto = m
+	client.dcs.primary = m
 
 	// TODO: Scommentare sotto per leggere gli update in tempo rale
 	if client.config.LiveUpdates {
@@ -121,6 +146,14 @@ func NewClient(c ClientConfig) (*Client, error) { //nolint: gocritic arg is not
 	}
 
 	client.serverConfig = config
+	m.SetSessionInitRequest(client.sessionInitRequest())
+
+	client.dcs.mutex.Lock()
+	// server config is requested from current primary DC (it could be changed by migration)
+	client.dcs.primaryDC = int(config.ThisDc)
+	client.dcs.conns[int(config.ThisDc)] = client.MTProto
+	client.dcs.authorized[int(config.ThisDc)] = true
+	client.dcs.mutex.Unlock()
 
 	dcList := make(map[int]string)
 	for _, dc := range config.DcOptions {
@@ -141,10 +174,60 @@ func NewClient(c ClientConfig) (*Client, error) { //nolint: gocritic arg is not
 		dcList[int(dc.ID)] = dc.IpAddress + ":" + strconv.Itoa(int(dc.Port))
 	}
 
+	client.dcList = dcList
 	client.SetDCList(dcList)
 	return client, nil
 }
 
+// readPublicKeys returns keys from config, or built-in telegram keys, if config doesn't have any
+func readPublicKeys(c *ClientConfig) ([]*rsa.PublicKey, error) {
+	publicKeys := c.PublicKeys
+	if c.PublicKeysFile != "" {
+		fromFile, err := keys.ReadFromFile(c.PublicKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		publicKeys = append(publicKeys, fromFile...)
+	}
+
+	if len(publicKeys) > 0 {
+		return publicKeys, nil
+	}
+
+	return keys.Read()
+}
+
+// newMTProto creates connection with client settings, but doesn't connect it. cfg contains only
+// settings of specific connection (host, session, etc.), others are taken from client config.
+func (c *Client) newMTProto(cfg mtproto.Config) (*mtproto.MTProto, error) {
+	cfg.Debug = c.config.Debug
+	cfg.Logger = c.config.Logger
+	cfg.PublicKeys = c.publicKeys
+	cfg.ProxyUrl = c.config.ProxyUrl
+	cfg.FloodWaitPolicy = c.config.FloodWaitPolicy
+	cfg.Mode = c.config.TransportMode
+	cfg.Connection = c.config.Connection
+	cfg.ReconnectPolicy = c.config.ReconnectPolicy
+	cfg.GzipThreshold = c.config.GzipThreshold
+	cfg.TempAuthKeyTTL = c.config.TempAuthKeyTTL
+	cfg.LegacyRSAEncryption = c.config.LegacyRSAEncryption
+	// all DCs of client belong to same environment
+	cfg.TestDC = c.config.TestDC
+	// migrations are handled by client itself, cause it holds connections to all DCs
+	cfg.DisableAutoMigrate = true
+
+	m, err := mtproto.NewMTProto(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.config.InitWarnChannel {
+		m.Warnings = make(chan error, warnChannelDefaultCapacity)
+	}
+
+	return m, nil
+}
+
 func SessionFromJSON(src string) (*session.Session, error) {
 	sessionParsed := &session.Session{}
 
@@ -156,6 +239,18 @@ func SessionFromJSON(src string) (*session.Session, error) {
 	return sessionParsed, nil
 }
 
+// sessionInitRequest returns initConnection, which is sent again in every new session of connection (e.g.
+// after binding of new temporary key), cause server forgets layer and client info of old session.
+func (m *Client) sessionInitRequest() mtproto.Object {
+	params := *m.initConnectionParams
+	params.Query = &HelpGetConfigParams{}
+
+	return &InvokeWithLayerParams{
+		Layer: ApiVersion,
+		Query: &params,
+	}
+}
+
 func (m *Client) RefreshServerConfig() error {
 	//如果服务器地址重定向,则更新配置
 	resp, err := m.InvokeWithLayer(ApiVersion, m.initConnectionParams)
@@ -193,10 +288,10 @@ func (c *Client) handleSpecialRequests() func(any) bool {
 	return func(i any) bool {
 		switch msg := i.(type) {
 		case *UpdatesObj:
-			pp.Println(msg, "UPDATE")
+			c.Logger().Log(mtproto.LevelDebug, "update", mtproto.Field{Key: "update", Value: msg})
 			return true
 		case *UpdateShort:
-			pp.Println(msg, "SHORT UPDATE")
+			c.Logger().Log(mtproto.LevelDebug, "short update", mtproto.Field{Key: "update", Value: msg})
 			return true
 		}
 
@@ -0,0 +1,361 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/umesproject/mtproto"
+	"github.com/umesproject/mtproto/internal/encoding/tl"
+)
+
+// maxRedirects limits count of *_MIGRATE_X errors, which are handled for single request, so DCs, which
+// redirect to each other, can't loop request forever
+const maxRedirects = 5
+
+// dcPool holds connections to all datacenters, which client ever used. Other connections are authorized
+// by exporting authorization from primary (home) DC.
+type dcPool struct {
+	mutex     sync.Mutex
+	primaryDC int
+	primary   *mtproto.MTProto
+	conns     map[int]*mtproto.MTProto
+	// connections, which already imported authorization of primary DC
+	authorized map[int]bool
+	// datacenters, which are connecting right now. channel is closed, when connecting is finished
+	dialing map[int]chan struct{}
+	// middlewares of all connections, new connections receive them too
+	middlewares []mtproto.Middleware
+}
+
+func newDCPool() *dcPool {
+	return &dcPool{
+		conns:      make(map[int]*mtproto.MTProto),
+		authorized: make(map[int]bool),
+		dialing:    make(map[int]chan struct{}),
+	}
+}
+
+// authorizedConn returns connection to dc, if it's ready for requests.
+func (p *dcPool) authorizedConn(dc int) (*mtproto.MTProto, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if dc == p.primaryDC && p.primary != nil {
+		return p.primary, true
+	}
+	conn, ok := p.conns[dc]
+	return conn, ok && p.authorized[dc]
+}
+
+// startDialing marks dc as connecting, so only one caller dials it, and others wait for result. If dc is
+// already connecting, startDialing waits until it's finished. Returned function must be called, when
+// connecting is finished (successfully or not).
+func (p *dcPool) startDialing(ctx context.Context, dc int) (func(), error) {
+	for {
+		p.mutex.Lock()
+		wait, busy := p.dialing[dc]
+		if !busy {
+			done := make(chan struct{})
+			p.dialing[dc] = done
+			p.mutex.Unlock()
+
+			return func() {
+				p.mutex.Lock()
+				delete(p.dialing, dc)
+				p.mutex.Unlock()
+				close(done)
+			}, nil
+		}
+		p.mutex.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// PrimaryDC returns id of datacenter, which client is using by default.
+func (c *Client) PrimaryDC() int {
+	c.dcs.mutex.Lock()
+	defer c.dcs.mutex.Unlock()
+	return c.dcs.primaryDC
+}
+
+// Use adds middlewares to connections of all datacenters, including connections, which will be opened
+// later. Request, which was redirected to other DC (*_MIGRATE_X errors), passes through middlewares of
+// both connections, cause it's sent by both of them. See mtproto.MTProto.Use and mtproto.Middleware for
+// details.
+func (c *Client) Use(middlewares ...mtproto.Middleware) {
+	c.dcs.mutex.Lock()
+	defer c.dcs.mutex.Unlock()
+
+	c.dcs.middlewares = append(c.dcs.middlewares, middlewares...)
+	c.MTProto.Use(middlewares...)
+	for _, conn := range c.dcs.conns {
+		if conn != c.MTProto {
+			conn.Use(middlewares...)
+		}
+	}
+}
+
+// primary returns connection to primary DC. It could be changed by migration, so Client.MTProto must not
+// be used for requests.
+func (c *Client) primary() *mtproto.MTProto {
+	c.dcs.mutex.Lock()
+	defer c.dcs.mutex.Unlock()
+	return c.dcs.primary
+}
+
+// GetSessionJSON returns session of primary DC connection, which could be changed by migration.
+func (c *Client) GetSessionJSON() string {
+	return c.primary().GetSessionJSON()
+}
+
+func (c *Client) MakeRequest(msg tl.Object) (any, error) {
+	return c.MakeRequestContext(context.Background(), msg)
+}
+
+// MakeRequestContext sends request to primary DC. If server says that request must be processed by
+// other DC (*_MIGRATE_X errors), request is sent again to the right one.
+func (c *Client) MakeRequestContext(ctx context.Context, msg tl.Object) (any, error) {
+	return c.invoke(ctx, c.primary(), msg)
+}
+
+func (c *Client) MakeRequestWithHintToDecoder(msg tl.Object, expectedTypes ...reflect.Type) (any, error) {
+	return c.MakeRequestWithHintToDecoderContext(context.Background(), msg, expectedTypes...)
+}
+
+func (c *Client) MakeRequestWithHintToDecoderContext(ctx context.Context, msg tl.Object, expectedTypes ...reflect.Type) (any, error) {
+	if len(expectedTypes) == 0 {
+		return nil, errors.New("expected a few hints. If you don't need it, use c.MakeRequest")
+	}
+	return c.invoke(ctx, c.primary(), msg, expectedTypes...)
+}
+
+// InvokeOnDC sends request to specified datacenter (e.g. for downloading files, which are stored in
+// other DC). Connection is authorized with exported authorization of primary DC.
+func (c *Client) InvokeOnDC(ctx context.Context, dc int, msg tl.Object) (any, error) {
+	conn, err := c.connectionToDC(ctx, dc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "connecting to DC %v", dc)
+	}
+
+	return c.invoke(ctx, conn, msg)
+}
+
+func (c *Client) invoke(ctx context.Context, conn *mtproto.MTProto, msg tl.Object, expectedTypes ...reflect.Type) (any, error) {
+	for redirects := 0; ; redirects++ {
+		var (
+			resp any
+			err  error
+		)
+		if len(expectedTypes) > 0 {
+			resp, err = conn.MakeRequestWithHintToDecoderContext(ctx, msg, expectedTypes...)
+		} else {
+			resp, err = conn.MakeRequestContext(ctx, msg)
+		}
+
+		var errCode *mtproto.ErrResponseCode
+		if err == nil || !errors.As(err, &errCode) {
+			return resp, err
+		}
+
+		dc, ok := errCode.AdditionalInfo.(int)
+		if !ok || redirects >= maxRedirects {
+			return nil, err
+		}
+
+		var migrateErr error
+		switch errCode.Message {
+		case "PHONE_MIGRATE_X", "USER_MIGRATE_X", "NETWORK_MIGRATE_X":
+			// user is tied to other DC, so it must become primary
+			conn, migrateErr = c.migratePrimary(ctx, dc)
+		case "FILE_MIGRATE_X", "STATS_MIGRATE_X":
+			// only this request must be sent to other DC
+			conn, migrateErr = c.connectionToDC(ctx, dc)
+		default:
+			return nil, err
+		}
+		if migrateErr != nil {
+			return nil, errors.Wrapf(migrateErr, "migrating to DC %v", dc)
+		}
+	}
+}
+
+// migratePrimary makes dc primary. Connection to previous primary DC is not closed, it could be used
+// later for requests to that DC.
+func (c *Client) migratePrimary(ctx context.Context, dc int) (*mtproto.MTProto, error) {
+	done, err := c.dcs.startDialing(ctx, dc)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	c.dcs.mutex.Lock()
+	if c.dcs.primaryDC == dc {
+		conn := c.dcs.primary
+		c.dcs.mutex.Unlock()
+		return conn, nil
+	}
+	conn, ok := c.dcs.conns[dc]
+	c.dcs.mutex.Unlock()
+
+	if !ok {
+		conn, err = c.dialDC(ctx, dc, &HelpGetConfigParams{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.dcs.mutex.Lock()
+	// user signs in on new primary DC, so sessions of other DCs (including previous primary one) must import
+	// authorization of new DC again
+	c.dcs.authorized = map[int]bool{dc: true}
+	c.dcs.primaryDC = dc
+	c.dcs.primary = conn
+	c.dcs.mutex.Unlock()
+
+	if c.config.LiveUpdates {
+		conn.AddCustomServerRequestHandler(c.handleSpecialRequests())
+	}
+
+	return conn, nil
+}
+
+// connectionToDC returns connection to dc, which is authorized as primary one.
+func (c *Client) connectionToDC(ctx context.Context, dc int) (*mtproto.MTProto, error) {
+	if conn, ok := c.dcs.authorizedConn(dc); ok {
+		return conn, nil
+	}
+
+	done, err := c.dcs.startDialing(ctx, dc)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	// connection could be authorized by other caller, while this one was waiting
+	if conn, ok := c.dcs.authorizedConn(dc); ok {
+		return conn, nil
+	}
+
+	// requests to primary DC must not be redirected, so c.AuthExportAuthorization is not used
+	exported, err := c.primary().MakeRequestContext(ctx, &AuthExportAuthorizationParams{DcID: int32(dc)})
+	if err != nil {
+		return nil, errors.Wrap(err, "exporting authorization")
+	}
+	auth, ok := exported.(*AuthExportedAuthorization)
+	if !ok {
+		return nil, errors.New("got wrong response: " + reflect.TypeOf(exported).String())
+	}
+
+	importQuery := &AuthImportAuthorizationParams{ID: auth.ID, Bytes: auth.Bytes}
+
+	c.dcs.mutex.Lock()
+	conn, ok := c.dcs.conns[dc]
+	c.dcs.mutex.Unlock()
+
+	if ok {
+		_, err = conn.MakeRequestContext(ctx, importQuery)
+	} else {
+		conn, err = c.dialDC(ctx, dc, importQuery)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "importing authorization")
+	}
+
+	c.dcs.mutex.Lock()
+	c.dcs.authorized[dc] = true
+	c.dcs.mutex.Unlock()
+
+	return conn, nil
+}
+
+// dialDC creates new connection to dc and initializes it with query (wrapped into initConnection). Connection
+// is added to pool, but it's not marked as authorized. Caller must reserve dc with startDialing.
+func (c *Client) dialDC(ctx context.Context, dc int, query tl.Object) (*mtproto.MTProto, error) {
+	addr, ok := c.MTProto.DCAddress(dc)
+	if !ok {
+		return nil, fmt.Errorf("address of DC %v not found", dc)
+	}
+
+	conn, err := c.newMTProto(mtproto.Config{ServerHost: addr, DC: dc})
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDCList(c.dcList)
+
+	// connection is added to pool right now, so it receives middlewares, which are added while it's
+	// connecting, and it's closed by Client.Close
+	c.dcs.mutex.Lock()
+	conn.Use(c.dcs.middlewares...)
+	c.dcs.conns[dc] = conn
+	c.dcs.mutex.Unlock()
+
+	err = c.initConnection(ctx, conn, query)
+	if err != nil {
+		c.dcs.mutex.Lock()
+		delete(c.dcs.conns, dc)
+		c.dcs.mutex.Unlock()
+		_ = conn.Close(context.Background())
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// initConnection connects conn to server and sends first query wrapped into initConnection.
+func (c *Client) initConnection(ctx context.Context, conn *mtproto.MTProto, query tl.Object) error {
+	err := conn.CreateConnection()
+	if err != nil {
+		return errors.Wrap(err, "creating connection")
+	}
+
+	params := *c.initConnectionParams
+	params.Query = query
+
+	_, err = conn.MakeRequestContext(ctx, &InvokeWithLayerParams{
+		Layer: ApiVersion,
+		Query: &params,
+	})
+	if err != nil {
+		return errors.Wrap(err, "initializing connection")
+	}
+
+	conn.SetSessionInitRequest(c.sessionInitRequest())
+	return nil
+}
+
+// Close closes connections to all datacenters. See mtproto.MTProto.Close for details.
+func (c *Client) Close(ctx context.Context) error {
+	c.dcs.mutex.Lock()
+	conns := make([]*mtproto.MTProto, 0, len(c.dcs.conns)+1)
+	conns = append(conns, c.MTProto)
+	for _, conn := range c.dcs.conns {
+		if conn != c.MTProto {
+			conns = append(conns, conn)
+		}
+	}
+	c.dcs.mutex.Unlock()
+
+	var firstErr error
+	for _, conn := range conns {
+		err := conn.Close(ctx)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2020-2021 KHS Films
+//
+// This file is a part of mtproto package.
+// See https://github.com/umesproject/mtproto/blob/master/LICENSE for details
+
+package telegram
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umesproject/mtproto"
+	"github.com/umesproject/mtproto/mtprototest"
+)
+
+// dcsLogger remembers datacenters of all log messages.
+type dcsLogger struct {
+	mu  sync.Mutex
+	dcs map[interface{}]bool
+}
+
+func (l *dcsLogger) Log(_ mtproto.LogLevel, _ string, fields ...mtproto.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, f := range fields {
+		if f.Key == "dc" {
+			l.dcs[f.Value] = true
+		}
+	}
+}
+
+// fakeDC is fake server of single datacenter, which counts imports of authorization.
+type fakeDC struct {
+	*mtprototest.Server
+	id      int
+	imports int32
+}
+
+func newFakeDCs(t *testing.T, ids ...int) map[int]*fakeDC {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	dcs := make(map[int]*fakeDC)
+	options := make([]*DcOption, 0, len(ids))
+	for _, id := range ids {
+		s, err := mtprototest.NewServer(mtprototest.Config{Key: key})
+		require.NoError(t, err)
+		t.Cleanup(func() { s.Close() })
+
+		host, port, err := net.SplitHostPort(s.Addr())
+		require.NoError(t, err)
+		p, err := strconv.Atoi(port)
+		require.NoError(t, err)
+
+		dcs[id] = &fakeDC{Server: s, id: id}
+		options = append(options, &DcOption{ID: int32(id), IpAddress: host, Port: int32(p)})
+	}
+
+	for _, dc := range dcs {
+		dc := dc
+		dc.Handle(&HelpGetConfigParams{}, func(mtprototest.Object) (mtprototest.Object, error) {
+			return &Config{ThisDc: int32(dc.id), DcOptions: options}, nil
+		})
+		dc.Handle(&HelpGetNearestDcParams{}, func(mtprototest.Object) (mtprototest.Object, error) {
+			return &NearestDc{ThisDc: int32(dc.id)}, nil
+		})
+		dc.Handle(&AuthExportAuthorizationParams{}, func(mtprototest.Object) (mtprototest.Object, error) {
+			return &AuthExportedAuthorization{ID: int32(dc.id), Bytes: []byte{1}}, nil
+		})
+		dc.Handle(&AuthImportAuthorizationParams{}, func(mtprototest.Object) (mtprototest.Object, error) {
+			atomic.AddInt32(&dc.imports, 1)
+			return &AuthAuthorizationSignUpRequired{}, nil
+		})
+	}
+
+	return dcs
+}
+
+func nearestDC(t *testing.T, c *Client) int {
+	t.Helper()
+
+	resp, err := c.MakeRequest(&HelpGetNearestDcParams{})
+	require.NoError(t, err)
+	return int(resp.(*NearestDc).ThisDc)
+}
+
+func TestMigrationResetsAuthorizationOfOldPrimary(t *testing.T) {
+	dcs := newFakeDCs(t, 2, 4)
+
+	logger := &dcsLogger{dcs: make(map[interface{}]bool)}
+	c, err := NewClient(ClientConfig{
+		ServerHost: dcs[2].Addr(),
+		DC:         2,
+		PublicKeys: []*rsa.PublicKey{dcs[2].PublicKey()},
+		Logger:     logger,
+	})
+	require.NoError(t, err)
+	defer c.Close(context.Background())
+
+	dcs[2].Migrate("USER", 4)
+	assert.Equal(t, 4, nearestDC(t, c))
+	assert.Equal(t, 4, c.PrimaryDC())
+
+	// user is signed in on dc 4 now, so old session of dc 2 must import authorization
+	dcs[4].Migrate("FILE", 2)
+	assert.Equal(t, 2, nearestDC(t, c))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&dcs[2].imports))
+	assert.Equal(t, 4, c.PrimaryDC())
+
+	// connections write logs with their own dc
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	assert.Equal(t, map[interface{}]bool{2: true, 4: true}, logger.dcs)
+}
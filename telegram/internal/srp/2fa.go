@@ -18,6 +18,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/xelaj/go-dry"
 	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/umesproject/mtproto/internal/math"
 )
 
 const (
@@ -180,15 +182,9 @@ func bigExp(x, y, m *big.Int) *big.Int {
 	return new(big.Int).Exp(x, y, m)
 }
 
+// dhHandshakeCheckConfigIsError checks that p is safe 2048-bit prime and g is generator of it's subgroup,
+// as server parameters of key exchange are checked.
+// https://core.telegram.org/api/srp#checking-the-password-with-srp
 func dhHandshakeCheckConfigIsError(gInt int32, primeStr []byte) bool {
-	//prime := new(big.Int).SetBytes(primeStr)
-	//_ = prime
-
-	// Функция описана здесь, и что-то проверяет.
-	// Реализовывать пока что лень.
-	// TODO: запилить
-	//       или болт положить, ¯\_(ツ)_/¯
-	// https://github.com/tdlib/td/blob/f9009cbc01e9c4c77d31120a61feb9c639c6aeda/td/mtproto/DhHandshake.cpp
-
-	return false
+	return math.CheckDHParams(gInt, bytesToBig(primeStr)) != nil
 }
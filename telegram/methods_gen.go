@@ -6,6 +6,7 @@
 package telegram
 
 import (
+	"context"
 	"reflect"
 
 	errors "github.com/pkg/errors"
@@ -26,7 +27,12 @@ func (*AccountAcceptAuthorizationParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountAcceptAuthorization(botID int32, scope, publicKey string, valueHashes []*SecureValueHash, credentials *SecureCredentialsEncrypted) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountAcceptAuthorizationParams{
+	return c.AccountAcceptAuthorizationContext(context.Background(), botID, scope, publicKey, valueHashes, credentials)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountAcceptAuthorizationContext(ctx context.Context, botID int32, scope, publicKey string, valueHashes []*SecureValueHash, credentials *SecureCredentialsEncrypted) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountAcceptAuthorizationParams{
 		BotID:       botID,
 		Credentials: credentials,
 		PublicKey:   publicKey,
@@ -53,7 +59,12 @@ func (*AccountCancelPasswordEmailParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountCancelPasswordEmail() (bool, error) {
-	responseData, err := c.MakeRequest(&AccountCancelPasswordEmailParams{})
+	return c.AccountCancelPasswordEmailContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountCancelPasswordEmailContext(ctx context.Context) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountCancelPasswordEmailParams{})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountCancelPasswordEmail")
 	}
@@ -78,7 +89,12 @@ func (*AccountChangePhoneParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountChangePhone(phoneNumber, phoneCodeHash, phoneCode string) (User, error) {
-	responseData, err := c.MakeRequest(&AccountChangePhoneParams{
+	return c.AccountChangePhoneContext(context.Background(), phoneNumber, phoneCodeHash, phoneCode)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountChangePhoneContext(ctx context.Context, phoneNumber, phoneCodeHash, phoneCode string) (User, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountChangePhoneParams{
 		PhoneCode:     phoneCode,
 		PhoneCodeHash: phoneCodeHash,
 		PhoneNumber:   phoneNumber,
@@ -105,7 +121,12 @@ func (*AccountCheckUsernameParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountCheckUsername(username string) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountCheckUsernameParams{Username: username})
+	return c.AccountCheckUsernameContext(context.Background(), username)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountCheckUsernameContext(ctx context.Context, username string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountCheckUsernameParams{Username: username})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountCheckUsername")
 	}
@@ -128,7 +149,12 @@ func (*AccountConfirmPasswordEmailParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountConfirmPasswordEmail(code string) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountConfirmPasswordEmailParams{Code: code})
+	return c.AccountConfirmPasswordEmailContext(context.Background(), code)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountConfirmPasswordEmailContext(ctx context.Context, code string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountConfirmPasswordEmailParams{Code: code})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountConfirmPasswordEmail")
 	}
@@ -152,7 +178,12 @@ func (*AccountConfirmPhoneParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountConfirmPhone(phoneCodeHash, phoneCode string) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountConfirmPhoneParams{
+	return c.AccountConfirmPhoneContext(context.Background(), phoneCodeHash, phoneCode)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountConfirmPhoneContext(ctx context.Context, phoneCodeHash, phoneCode string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountConfirmPhoneParams{
 		PhoneCode:     phoneCode,
 		PhoneCodeHash: phoneCodeHash,
 	})
@@ -185,7 +216,12 @@ func (*AccountCreateThemeParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountCreateTheme(slug, title string, document InputDocument, settings *InputThemeSettings) (*Theme, error) {
-	responseData, err := c.MakeRequest(&AccountCreateThemeParams{
+	return c.AccountCreateThemeContext(context.Background(), slug, title, document, settings)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountCreateThemeContext(ctx context.Context, slug, title string, document InputDocument, settings *InputThemeSettings) (*Theme, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountCreateThemeParams{
 		Document: document,
 		Settings: settings,
 		Slug:     slug,
@@ -213,7 +249,12 @@ func (*AccountDeleteAccountParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountDeleteAccount(reason string) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountDeleteAccountParams{Reason: reason})
+	return c.AccountDeleteAccountContext(context.Background(), reason)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountDeleteAccountContext(ctx context.Context, reason string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountDeleteAccountParams{Reason: reason})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountDeleteAccount")
 	}
@@ -236,7 +277,12 @@ func (*AccountDeleteSecureValueParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountDeleteSecureValue(types []SecureValueType) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountDeleteSecureValueParams{Types: types})
+	return c.AccountDeleteSecureValueContext(context.Background(), types)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountDeleteSecureValueContext(ctx context.Context, types []SecureValueType) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountDeleteSecureValueParams{Types: types})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountDeleteSecureValue")
 	}
@@ -263,7 +309,12 @@ func (*AccountFinishTakeoutSessionParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountFinishTakeoutSession(success bool) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountFinishTakeoutSessionParams{Success: success})
+	return c.AccountFinishTakeoutSessionContext(context.Background(), success)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountFinishTakeoutSessionContext(ctx context.Context, success bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountFinishTakeoutSessionParams{Success: success})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountFinishTakeoutSession")
 	}
@@ -284,7 +335,12 @@ func (*AccountGetAccountTtlParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetAccountTtl() (*AccountDaysTtl, error) {
-	responseData, err := c.MakeRequest(&AccountGetAccountTtlParams{})
+	return c.AccountGetAccountTtlContext(context.Background())
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetAccountTtlContext(ctx context.Context) (*AccountDaysTtl, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetAccountTtlParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetAccountTtl")
 	}
@@ -305,7 +361,12 @@ func (*AccountGetAllSecureValuesParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetAllSecureValues() ([]*SecureValue, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&AccountGetAllSecureValuesParams{}, reflect.TypeOf([]*SecureValue{}))
+	return c.AccountGetAllSecureValuesContext(context.Background())
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetAllSecureValuesContext(ctx context.Context) ([]*SecureValue, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &AccountGetAllSecureValuesParams{}, reflect.TypeOf([]*SecureValue{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetAllSecureValues")
 	}
@@ -330,7 +391,12 @@ func (*AccountGetAuthorizationFormParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetAuthorizationForm(botID int32, scope, publicKey string) (*AccountAuthorizationForm, error) {
-	responseData, err := c.MakeRequest(&AccountGetAuthorizationFormParams{
+	return c.AccountGetAuthorizationFormContext(context.Background(), botID, scope, publicKey)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetAuthorizationFormContext(ctx context.Context, botID int32, scope, publicKey string) (*AccountAuthorizationForm, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetAuthorizationFormParams{
 		BotID:     botID,
 		PublicKey: publicKey,
 		Scope:     scope,
@@ -355,7 +421,12 @@ func (*AccountGetAuthorizationsParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetAuthorizations() (*AccountAuthorizations, error) {
-	responseData, err := c.MakeRequest(&AccountGetAuthorizationsParams{})
+	return c.AccountGetAuthorizationsContext(context.Background())
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetAuthorizationsContext(ctx context.Context) (*AccountAuthorizations, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetAuthorizationsParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetAuthorizations")
 	}
@@ -376,7 +447,12 @@ func (*AccountGetAutoDownloadSettingsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountGetAutoDownloadSettings() (*AccountAutoDownloadSettings, error) {
-	responseData, err := c.MakeRequest(&AccountGetAutoDownloadSettingsParams{})
+	return c.AccountGetAutoDownloadSettingsContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountGetAutoDownloadSettingsContext(ctx context.Context) (*AccountAutoDownloadSettings, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetAutoDownloadSettingsParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetAutoDownloadSettings")
 	}
@@ -397,7 +473,12 @@ func (*AccountGetContactSignUpNotificationParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountGetContactSignUpNotification() (bool, error) {
-	responseData, err := c.MakeRequest(&AccountGetContactSignUpNotificationParams{})
+	return c.AccountGetContactSignUpNotificationContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountGetContactSignUpNotificationContext(ctx context.Context) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetContactSignUpNotificationParams{})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountGetContactSignUpNotification")
 	}
@@ -418,7 +499,12 @@ func (*AccountGetContentSettingsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountGetContentSettings() (*AccountContentSettings, error) {
-	responseData, err := c.MakeRequest(&AccountGetContentSettingsParams{})
+	return c.AccountGetContentSettingsContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountGetContentSettingsContext(ctx context.Context) (*AccountContentSettings, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetContentSettingsParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetContentSettings")
 	}
@@ -439,7 +525,12 @@ func (*AccountGetGlobalPrivacySettingsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountGetGlobalPrivacySettings() (*GlobalPrivacySettings, error) {
-	responseData, err := c.MakeRequest(&AccountGetGlobalPrivacySettingsParams{})
+	return c.AccountGetGlobalPrivacySettingsContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountGetGlobalPrivacySettingsContext(ctx context.Context) (*GlobalPrivacySettings, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetGlobalPrivacySettingsParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetGlobalPrivacySettings")
 	}
@@ -462,7 +553,12 @@ func (*AccountGetMultiWallPapersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountGetMultiWallPapers(wallpapers []InputWallPaper) ([]WallPaper, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&AccountGetMultiWallPapersParams{Wallpapers: wallpapers}, reflect.TypeOf([]WallPaper{}))
+	return c.AccountGetMultiWallPapersContext(context.Background(), wallpapers)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountGetMultiWallPapersContext(ctx context.Context, wallpapers []InputWallPaper) ([]WallPaper, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &AccountGetMultiWallPapersParams{Wallpapers: wallpapers}, reflect.TypeOf([]WallPaper{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetMultiWallPapers")
 	}
@@ -490,7 +586,12 @@ func (*AccountGetNotifyExceptionsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountGetNotifyExceptions(compareSound bool, peer InputNotifyPeer) (Updates, error) {
-	responseData, err := c.MakeRequest(&AccountGetNotifyExceptionsParams{
+	return c.AccountGetNotifyExceptionsContext(context.Background(), compareSound, peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountGetNotifyExceptionsContext(ctx context.Context, compareSound bool, peer InputNotifyPeer) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetNotifyExceptionsParams{
 		CompareSound: compareSound,
 		Peer:         peer,
 	})
@@ -516,7 +617,12 @@ func (*AccountGetNotifySettingsParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetNotifySettings(peer InputNotifyPeer) (*PeerNotifySettings, error) {
-	responseData, err := c.MakeRequest(&AccountGetNotifySettingsParams{Peer: peer})
+	return c.AccountGetNotifySettingsContext(context.Background(), peer)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetNotifySettingsContext(ctx context.Context, peer InputNotifyPeer) (*PeerNotifySettings, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetNotifySettingsParams{Peer: peer})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetNotifySettings")
 	}
@@ -537,7 +643,12 @@ func (*AccountGetPasswordParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetPassword() (*AccountPassword, error) {
-	responseData, err := c.MakeRequest(&AccountGetPasswordParams{})
+	return c.AccountGetPasswordContext(context.Background())
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetPasswordContext(ctx context.Context) (*AccountPassword, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetPasswordParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetPassword")
 	}
@@ -560,7 +671,12 @@ func (*AccountGetPasswordSettingsParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetPasswordSettings(password InputCheckPasswordSRP) (*AccountPasswordSettings, error) {
-	responseData, err := c.MakeRequest(&AccountGetPasswordSettingsParams{Password: password})
+	return c.AccountGetPasswordSettingsContext(context.Background(), password)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetPasswordSettingsContext(ctx context.Context, password InputCheckPasswordSRP) (*AccountPasswordSettings, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetPasswordSettingsParams{Password: password})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetPasswordSettings")
 	}
@@ -583,7 +699,12 @@ func (*AccountGetPrivacyParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetPrivacy(key InputPrivacyKey) (*AccountPrivacyRules, error) {
-	responseData, err := c.MakeRequest(&AccountGetPrivacyParams{Key: key})
+	return c.AccountGetPrivacyContext(context.Background(), key)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetPrivacyContext(ctx context.Context, key InputPrivacyKey) (*AccountPrivacyRules, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetPrivacyParams{Key: key})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetPrivacy")
 	}
@@ -606,7 +727,12 @@ func (*AccountGetSecureValueParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetSecureValue(types []SecureValueType) ([]*SecureValue, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&AccountGetSecureValueParams{Types: types}, reflect.TypeOf([]*SecureValue{}))
+	return c.AccountGetSecureValueContext(context.Background(), types)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetSecureValueContext(ctx context.Context, types []SecureValueType) ([]*SecureValue, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &AccountGetSecureValueParams{Types: types}, reflect.TypeOf([]*SecureValue{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetSecureValue")
 	}
@@ -631,7 +757,12 @@ func (*AccountGetThemeParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountGetTheme(format string, theme InputTheme, documentID int64) (*Theme, error) {
-	responseData, err := c.MakeRequest(&AccountGetThemeParams{
+	return c.AccountGetThemeContext(context.Background(), format, theme, documentID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountGetThemeContext(ctx context.Context, format string, theme InputTheme, documentID int64) (*Theme, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetThemeParams{
 		DocumentID: documentID,
 		Format:     format,
 		Theme:      theme,
@@ -659,7 +790,12 @@ func (*AccountGetThemesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountGetThemes(format string, hash int32) (AccountThemes, error) {
-	responseData, err := c.MakeRequest(&AccountGetThemesParams{
+	return c.AccountGetThemesContext(context.Background(), format, hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountGetThemesContext(ctx context.Context, format string, hash int32) (AccountThemes, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetThemesParams{
 		Format: format,
 		Hash:   hash,
 	})
@@ -686,7 +822,12 @@ func (*AccountGetTmpPasswordParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetTmpPassword(password InputCheckPasswordSRP, period int32) (*AccountTmpPassword, error) {
-	responseData, err := c.MakeRequest(&AccountGetTmpPasswordParams{
+	return c.AccountGetTmpPasswordContext(context.Background(), password, period)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetTmpPasswordContext(ctx context.Context, password InputCheckPasswordSRP, period int32) (*AccountTmpPassword, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetTmpPasswordParams{
 		Password: password,
 		Period:   period,
 	})
@@ -712,7 +853,12 @@ func (*AccountGetWallPaperParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountGetWallPaper(wallpaper InputWallPaper) (WallPaper, error) {
-	responseData, err := c.MakeRequest(&AccountGetWallPaperParams{Wallpaper: wallpaper})
+	return c.AccountGetWallPaperContext(context.Background(), wallpaper)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountGetWallPaperContext(ctx context.Context, wallpaper InputWallPaper) (WallPaper, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetWallPaperParams{Wallpaper: wallpaper})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetWallPaper")
 	}
@@ -735,7 +881,12 @@ func (*AccountGetWallPapersParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetWallPapers(hash int32) (AccountWallPapers, error) {
-	responseData, err := c.MakeRequest(&AccountGetWallPapersParams{Hash: hash})
+	return c.AccountGetWallPapersContext(context.Background(), hash)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetWallPapersContext(ctx context.Context, hash int32) (AccountWallPapers, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetWallPapersParams{Hash: hash})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetWallPapers")
 	}
@@ -756,7 +907,12 @@ func (*AccountGetWebAuthorizationsParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountGetWebAuthorizations() (*AccountWebAuthorizations, error) {
-	responseData, err := c.MakeRequest(&AccountGetWebAuthorizationsParams{})
+	return c.AccountGetWebAuthorizationsContext(context.Background())
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountGetWebAuthorizationsContext(ctx context.Context) (*AccountWebAuthorizations, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountGetWebAuthorizationsParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountGetWebAuthorizations")
 	}
@@ -789,7 +945,12 @@ func (*AccountInitTakeoutSessionParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountInitTakeoutSession(params *AccountInitTakeoutSessionParams) (*AccountTakeout, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.AccountInitTakeoutSessionContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountInitTakeoutSessionContext(ctx context.Context, params *AccountInitTakeoutSessionParams) (*AccountTakeout, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountInitTakeoutSession")
 	}
@@ -818,7 +979,12 @@ func (*AccountInstallThemeParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountInstallTheme(dark bool, format string, theme InputTheme) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountInstallThemeParams{
+	return c.AccountInstallThemeContext(context.Background(), dark, format, theme)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountInstallThemeContext(ctx context.Context, dark bool, format string, theme InputTheme) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountInstallThemeParams{
 		Dark:   dark,
 		Format: format,
 		Theme:  theme,
@@ -846,7 +1012,12 @@ func (*AccountInstallWallPaperParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountInstallWallPaper(wallpaper InputWallPaper, settings *WallPaperSettings) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountInstallWallPaperParams{
+	return c.AccountInstallWallPaperContext(context.Background(), wallpaper, settings)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountInstallWallPaperContext(ctx context.Context, wallpaper InputWallPaper, settings *WallPaperSettings) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountInstallWallPaperParams{
 		Settings:  settings,
 		Wallpaper: wallpaper,
 	})
@@ -881,7 +1052,12 @@ func (*AccountRegisterDeviceParams) FlagIndex() int {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountRegisterDevice(params *AccountRegisterDeviceParams) (bool, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.AccountRegisterDeviceContext(context.Background(), params)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountRegisterDeviceContext(ctx context.Context, params *AccountRegisterDeviceParams) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountRegisterDevice")
 	}
@@ -905,7 +1081,12 @@ func (*AccountReportPeerParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountReportPeer(peer InputPeer, reason ReportReason) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountReportPeerParams{
+	return c.AccountReportPeerContext(context.Background(), peer, reason)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountReportPeerContext(ctx context.Context, peer InputPeer, reason ReportReason) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountReportPeerParams{
 		Peer:   peer,
 		Reason: reason,
 	})
@@ -929,7 +1110,12 @@ func (*AccountResendPasswordEmailParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountResendPasswordEmail() (bool, error) {
-	responseData, err := c.MakeRequest(&AccountResendPasswordEmailParams{})
+	return c.AccountResendPasswordEmailContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountResendPasswordEmailContext(ctx context.Context) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountResendPasswordEmailParams{})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountResendPasswordEmail")
 	}
@@ -952,7 +1138,12 @@ func (*AccountResetAuthorizationParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountResetAuthorization(hash int64) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountResetAuthorizationParams{Hash: hash})
+	return c.AccountResetAuthorizationContext(context.Background(), hash)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountResetAuthorizationContext(ctx context.Context, hash int64) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountResetAuthorizationParams{Hash: hash})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountResetAuthorization")
 	}
@@ -973,7 +1164,12 @@ func (*AccountResetNotifySettingsParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountResetNotifySettings() (bool, error) {
-	responseData, err := c.MakeRequest(&AccountResetNotifySettingsParams{})
+	return c.AccountResetNotifySettingsContext(context.Background())
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountResetNotifySettingsContext(ctx context.Context) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountResetNotifySettingsParams{})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountResetNotifySettings")
 	}
@@ -994,7 +1190,12 @@ func (*AccountResetWallPapersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountResetWallPapers() (bool, error) {
-	responseData, err := c.MakeRequest(&AccountResetWallPapersParams{})
+	return c.AccountResetWallPapersContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountResetWallPapersContext(ctx context.Context) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountResetWallPapersParams{})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountResetWallPapers")
 	}
@@ -1017,7 +1218,12 @@ func (*AccountResetWebAuthorizationParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountResetWebAuthorization(hash int64) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountResetWebAuthorizationParams{Hash: hash})
+	return c.AccountResetWebAuthorizationContext(context.Background(), hash)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountResetWebAuthorizationContext(ctx context.Context, hash int64) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountResetWebAuthorizationParams{Hash: hash})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountResetWebAuthorization")
 	}
@@ -1038,7 +1244,12 @@ func (*AccountResetWebAuthorizationsParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountResetWebAuthorizations() (bool, error) {
-	responseData, err := c.MakeRequest(&AccountResetWebAuthorizationsParams{})
+	return c.AccountResetWebAuthorizationsContext(context.Background())
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountResetWebAuthorizationsContext(ctx context.Context) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountResetWebAuthorizationsParams{})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountResetWebAuthorizations")
 	}
@@ -1067,7 +1278,12 @@ func (*AccountSaveAutoDownloadSettingsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountSaveAutoDownloadSettings(low, high bool, settings *AutoDownloadSettings) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountSaveAutoDownloadSettingsParams{
+	return c.AccountSaveAutoDownloadSettingsContext(context.Background(), low, high, settings)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountSaveAutoDownloadSettingsContext(ctx context.Context, low, high bool, settings *AutoDownloadSettings) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSaveAutoDownloadSettingsParams{
 		High:     high,
 		Low:      low,
 		Settings: settings,
@@ -1095,7 +1311,12 @@ func (*AccountSaveSecureValueParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountSaveSecureValue(value *InputSecureValue, secureSecretID int64) (*SecureValue, error) {
-	responseData, err := c.MakeRequest(&AccountSaveSecureValueParams{
+	return c.AccountSaveSecureValueContext(context.Background(), value, secureSecretID)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountSaveSecureValueContext(ctx context.Context, value *InputSecureValue, secureSecretID int64) (*SecureValue, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSaveSecureValueParams{
 		SecureSecretID: secureSecretID,
 		Value:          value,
 	})
@@ -1122,7 +1343,12 @@ func (*AccountSaveThemeParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountSaveTheme(theme InputTheme, unsave bool) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountSaveThemeParams{
+	return c.AccountSaveThemeContext(context.Background(), theme, unsave)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountSaveThemeContext(ctx context.Context, theme InputTheme, unsave bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSaveThemeParams{
 		Theme:  theme,
 		Unsave: unsave,
 	})
@@ -1150,7 +1376,12 @@ func (*AccountSaveWallPaperParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountSaveWallPaper(wallpaper InputWallPaper, unsave bool, settings *WallPaperSettings) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountSaveWallPaperParams{
+	return c.AccountSaveWallPaperContext(context.Background(), wallpaper, unsave, settings)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountSaveWallPaperContext(ctx context.Context, wallpaper InputWallPaper, unsave bool, settings *WallPaperSettings) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSaveWallPaperParams{
 		Settings:  settings,
 		Unsave:    unsave,
 		Wallpaper: wallpaper,
@@ -1178,7 +1409,12 @@ func (*AccountSendChangePhoneCodeParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountSendChangePhoneCode(phoneNumber string, settings *CodeSettings) (*AuthSentCode, error) {
-	responseData, err := c.MakeRequest(&AccountSendChangePhoneCodeParams{
+	return c.AccountSendChangePhoneCodeContext(context.Background(), phoneNumber, settings)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountSendChangePhoneCodeContext(ctx context.Context, phoneNumber string, settings *CodeSettings) (*AuthSentCode, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSendChangePhoneCodeParams{
 		PhoneNumber: phoneNumber,
 		Settings:    settings,
 	})
@@ -1205,7 +1441,12 @@ func (*AccountSendConfirmPhoneCodeParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountSendConfirmPhoneCode(hash string, settings *CodeSettings) (*AuthSentCode, error) {
-	responseData, err := c.MakeRequest(&AccountSendConfirmPhoneCodeParams{
+	return c.AccountSendConfirmPhoneCodeContext(context.Background(), hash, settings)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountSendConfirmPhoneCodeContext(ctx context.Context, hash string, settings *CodeSettings) (*AuthSentCode, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSendConfirmPhoneCodeParams{
 		Hash:     hash,
 		Settings: settings,
 	})
@@ -1231,7 +1472,12 @@ func (*AccountSendVerifyEmailCodeParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountSendVerifyEmailCode(email string) (*AccountSentEmailCode, error) {
-	responseData, err := c.MakeRequest(&AccountSendVerifyEmailCodeParams{Email: email})
+	return c.AccountSendVerifyEmailCodeContext(context.Background(), email)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountSendVerifyEmailCodeContext(ctx context.Context, email string) (*AccountSentEmailCode, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSendVerifyEmailCodeParams{Email: email})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountSendVerifyEmailCode")
 	}
@@ -1255,7 +1501,12 @@ func (*AccountSendVerifyPhoneCodeParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountSendVerifyPhoneCode(phoneNumber string, settings *CodeSettings) (*AuthSentCode, error) {
-	responseData, err := c.MakeRequest(&AccountSendVerifyPhoneCodeParams{
+	return c.AccountSendVerifyPhoneCodeContext(context.Background(), phoneNumber, settings)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountSendVerifyPhoneCodeContext(ctx context.Context, phoneNumber string, settings *CodeSettings) (*AuthSentCode, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSendVerifyPhoneCodeParams{
 		PhoneNumber: phoneNumber,
 		Settings:    settings,
 	})
@@ -1281,7 +1532,12 @@ func (*AccountSetAccountTtlParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountSetAccountTtl(ttl *AccountDaysTtl) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountSetAccountTtlParams{Ttl: ttl})
+	return c.AccountSetAccountTtlContext(context.Background(), ttl)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountSetAccountTtlContext(ctx context.Context, ttl *AccountDaysTtl) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSetAccountTtlParams{Ttl: ttl})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountSetAccountTtl")
 	}
@@ -1304,7 +1560,12 @@ func (*AccountSetContactSignUpNotificationParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountSetContactSignUpNotification(silent bool) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountSetContactSignUpNotificationParams{Silent: silent})
+	return c.AccountSetContactSignUpNotificationContext(context.Background(), silent)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountSetContactSignUpNotificationContext(ctx context.Context, silent bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSetContactSignUpNotificationParams{Silent: silent})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountSetContactSignUpNotification")
 	}
@@ -1331,7 +1592,12 @@ func (*AccountSetContentSettingsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountSetContentSettings(sensitiveEnabled bool) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountSetContentSettingsParams{SensitiveEnabled: sensitiveEnabled})
+	return c.AccountSetContentSettingsContext(context.Background(), sensitiveEnabled)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountSetContentSettingsContext(ctx context.Context, sensitiveEnabled bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSetContentSettingsParams{SensitiveEnabled: sensitiveEnabled})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountSetContentSettings")
 	}
@@ -1354,7 +1620,12 @@ func (*AccountSetGlobalPrivacySettingsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountSetGlobalPrivacySettings(settings *GlobalPrivacySettings) (*GlobalPrivacySettings, error) {
-	responseData, err := c.MakeRequest(&AccountSetGlobalPrivacySettingsParams{Settings: settings})
+	return c.AccountSetGlobalPrivacySettingsContext(context.Background(), settings)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountSetGlobalPrivacySettingsContext(ctx context.Context, settings *GlobalPrivacySettings) (*GlobalPrivacySettings, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSetGlobalPrivacySettingsParams{Settings: settings})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountSetGlobalPrivacySettings")
 	}
@@ -1378,7 +1649,12 @@ func (*AccountSetPrivacyParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountSetPrivacy(key InputPrivacyKey, rules []InputPrivacyRule) (*AccountPrivacyRules, error) {
-	responseData, err := c.MakeRequest(&AccountSetPrivacyParams{
+	return c.AccountSetPrivacyContext(context.Background(), key, rules)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountSetPrivacyContext(ctx context.Context, key InputPrivacyKey, rules []InputPrivacyRule) (*AccountPrivacyRules, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountSetPrivacyParams{
 		Key:   key,
 		Rules: rules,
 	})
@@ -1406,7 +1682,12 @@ func (*AccountUnregisterDeviceParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountUnregisterDevice(tokenType int32, token string, otherUids []int32) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountUnregisterDeviceParams{
+	return c.AccountUnregisterDeviceContext(context.Background(), tokenType, token, otherUids)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountUnregisterDeviceContext(ctx context.Context, tokenType int32, token string, otherUids []int32) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountUnregisterDeviceParams{
 		OtherUids: otherUids,
 		Token:     token,
 		TokenType: tokenType,
@@ -1433,7 +1714,12 @@ func (*AccountUpdateDeviceLockedParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountUpdateDeviceLocked(period int32) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountUpdateDeviceLockedParams{Period: period})
+	return c.AccountUpdateDeviceLockedContext(context.Background(), period)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountUpdateDeviceLockedContext(ctx context.Context, period int32) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountUpdateDeviceLockedParams{Period: period})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountUpdateDeviceLocked")
 	}
@@ -1457,7 +1743,12 @@ func (*AccountUpdateNotifySettingsParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountUpdateNotifySettings(peer InputNotifyPeer, settings *InputPeerNotifySettings) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountUpdateNotifySettingsParams{
+	return c.AccountUpdateNotifySettingsContext(context.Background(), peer, settings)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountUpdateNotifySettingsContext(ctx context.Context, peer InputNotifyPeer, settings *InputPeerNotifySettings) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountUpdateNotifySettingsParams{
 		Peer:     peer,
 		Settings: settings,
 	})
@@ -1484,7 +1775,12 @@ func (*AccountUpdatePasswordSettingsParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountUpdatePasswordSettings(password InputCheckPasswordSRP, newSettings *AccountPasswordInputSettings) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountUpdatePasswordSettingsParams{
+	return c.AccountUpdatePasswordSettingsContext(context.Background(), password, newSettings)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountUpdatePasswordSettingsContext(ctx context.Context, password InputCheckPasswordSRP, newSettings *AccountPasswordInputSettings) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountUpdatePasswordSettingsParams{
 		NewSettings: newSettings,
 		Password:    password,
 	})
@@ -1516,7 +1812,12 @@ func (*AccountUpdateProfileParams) FlagIndex() int {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountUpdateProfile(firstName, lastName, about string) (User, error) {
-	responseData, err := c.MakeRequest(&AccountUpdateProfileParams{
+	return c.AccountUpdateProfileContext(context.Background(), firstName, lastName, about)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountUpdateProfileContext(ctx context.Context, firstName, lastName, about string) (User, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountUpdateProfileParams{
 		About:     about,
 		FirstName: firstName,
 		LastName:  lastName,
@@ -1543,7 +1844,12 @@ func (*AccountUpdateStatusParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountUpdateStatus(offline bool) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountUpdateStatusParams{Offline: offline})
+	return c.AccountUpdateStatusContext(context.Background(), offline)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountUpdateStatusContext(ctx context.Context, offline bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountUpdateStatusParams{Offline: offline})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AccountUpdateStatus")
 	}
@@ -1575,7 +1881,12 @@ func (*AccountUpdateThemeParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountUpdateTheme(params *AccountUpdateThemeParams) (*Theme, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.AccountUpdateThemeContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountUpdateThemeContext(ctx context.Context, params *AccountUpdateThemeParams) (*Theme, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountUpdateTheme")
 	}
@@ -1598,7 +1909,12 @@ func (*AccountUpdateUsernameParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AccountUpdateUsername(username string) (User, error) {
-	responseData, err := c.MakeRequest(&AccountUpdateUsernameParams{Username: username})
+	return c.AccountUpdateUsernameContext(context.Background(), username)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AccountUpdateUsernameContext(ctx context.Context, username string) (User, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountUpdateUsernameParams{Username: username})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AccountUpdateUsername")
 	}
@@ -1628,7 +1944,12 @@ func (*AccountUploadThemeParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountUploadTheme(file, thumb InputFile, fileName, mimeType string) (Document, error) {
-	responseData, err := c.MakeRequest(&AccountUploadThemeParams{
+	return c.AccountUploadThemeContext(context.Background(), file, thumb, fileName, mimeType)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountUploadThemeContext(ctx context.Context, file, thumb InputFile, fileName, mimeType string) (Document, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountUploadThemeParams{
 		File:     file,
 		FileName: fileName,
 		MimeType: mimeType,
@@ -1658,7 +1979,12 @@ func (*AccountUploadWallPaperParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountUploadWallPaper(file InputFile, mimeType string, settings *WallPaperSettings) (WallPaper, error) {
-	responseData, err := c.MakeRequest(&AccountUploadWallPaperParams{
+	return c.AccountUploadWallPaperContext(context.Background(), file, mimeType, settings)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountUploadWallPaperContext(ctx context.Context, file InputFile, mimeType string, settings *WallPaperSettings) (WallPaper, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountUploadWallPaperParams{
 		File:     file,
 		MimeType: mimeType,
 		Settings: settings,
@@ -1686,7 +2012,12 @@ func (*AccountVerifyEmailParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountVerifyEmail(email, code string) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountVerifyEmailParams{
+	return c.AccountVerifyEmailContext(context.Background(), email, code)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountVerifyEmailContext(ctx context.Context, email, code string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountVerifyEmailParams{
 		Code:  code,
 		Email: email,
 	})
@@ -1714,7 +2045,12 @@ func (*AccountVerifyPhoneParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) AccountVerifyPhone(phoneNumber, phoneCodeHash, phoneCode string) (bool, error) {
-	responseData, err := c.MakeRequest(&AccountVerifyPhoneParams{
+	return c.AccountVerifyPhoneContext(context.Background(), phoneNumber, phoneCodeHash, phoneCode)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) AccountVerifyPhoneContext(ctx context.Context, phoneNumber, phoneCodeHash, phoneCode string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AccountVerifyPhoneParams{
 		PhoneCode:     phoneCode,
 		PhoneCodeHash: phoneCodeHash,
 		PhoneNumber:   phoneNumber,
@@ -1741,7 +2077,12 @@ func (*AuthAcceptLoginTokenParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthAcceptLoginToken(token []byte) (*Authorization, error) {
-	responseData, err := c.MakeRequest(&AuthAcceptLoginTokenParams{Token: token})
+	return c.AuthAcceptLoginTokenContext(context.Background(), token)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthAcceptLoginTokenContext(ctx context.Context, token []byte) (*Authorization, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthAcceptLoginTokenParams{Token: token})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AuthAcceptLoginToken")
 	}
@@ -1767,7 +2108,12 @@ func (*AuthBindTempAuthKeyParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthBindTempAuthKey(permAuthKeyID, nonce int64, expiresAt int32, encryptedMessage []byte) (bool, error) {
-	responseData, err := c.MakeRequest(&AuthBindTempAuthKeyParams{
+	return c.AuthBindTempAuthKeyContext(context.Background(), permAuthKeyID, nonce, expiresAt, encryptedMessage)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthBindTempAuthKeyContext(ctx context.Context, permAuthKeyID, nonce int64, expiresAt int32, encryptedMessage []byte) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthBindTempAuthKeyParams{
 		EncryptedMessage: encryptedMessage,
 		ExpiresAt:        expiresAt,
 		Nonce:            nonce,
@@ -1796,7 +2142,12 @@ func (*AuthCancelCodeParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthCancelCode(phoneNumber, phoneCodeHash string) (bool, error) {
-	responseData, err := c.MakeRequest(&AuthCancelCodeParams{
+	return c.AuthCancelCodeContext(context.Background(), phoneNumber, phoneCodeHash)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthCancelCodeContext(ctx context.Context, phoneNumber, phoneCodeHash string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthCancelCodeParams{
 		PhoneCodeHash: phoneCodeHash,
 		PhoneNumber:   phoneNumber,
 	})
@@ -1822,7 +2173,12 @@ func (*AuthCheckPasswordParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthCheckPassword(password InputCheckPasswordSRP) (AuthAuthorization, error) {
-	responseData, err := c.MakeRequest(&AuthCheckPasswordParams{Password: password})
+	return c.AuthCheckPasswordContext(context.Background(), password)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthCheckPasswordContext(ctx context.Context, password InputCheckPasswordSRP) (AuthAuthorization, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthCheckPasswordParams{Password: password})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AuthCheckPassword")
 	}
@@ -1845,7 +2201,12 @@ func (*AuthDropTempAuthKeysParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthDropTempAuthKeys(exceptAuthKeys []int64) (bool, error) {
-	responseData, err := c.MakeRequest(&AuthDropTempAuthKeysParams{ExceptAuthKeys: exceptAuthKeys})
+	return c.AuthDropTempAuthKeysContext(context.Background(), exceptAuthKeys)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthDropTempAuthKeysContext(ctx context.Context, exceptAuthKeys []int64) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthDropTempAuthKeysParams{ExceptAuthKeys: exceptAuthKeys})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AuthDropTempAuthKeys")
 	}
@@ -1868,7 +2229,12 @@ func (*AuthExportAuthorizationParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthExportAuthorization(dcID int32) (*AuthExportedAuthorization, error) {
-	responseData, err := c.MakeRequest(&AuthExportAuthorizationParams{DcID: dcID})
+	return c.AuthExportAuthorizationContext(context.Background(), dcID)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthExportAuthorizationContext(ctx context.Context, dcID int32) (*AuthExportedAuthorization, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthExportAuthorizationParams{DcID: dcID})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AuthExportAuthorization")
 	}
@@ -1893,7 +2259,12 @@ func (*AuthExportLoginTokenParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthExportLoginToken(apiID int32, apiHash string, exceptIds []int32) (AuthLoginToken, error) {
-	responseData, err := c.MakeRequest(&AuthExportLoginTokenParams{
+	return c.AuthExportLoginTokenContext(context.Background(), apiID, apiHash, exceptIds)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthExportLoginTokenContext(ctx context.Context, apiID int32, apiHash string, exceptIds []int32) (AuthLoginToken, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthExportLoginTokenParams{
 		APIHash:   apiHash,
 		APIID:     apiID,
 		ExceptIds: exceptIds,
@@ -1921,7 +2292,12 @@ func (*AuthImportAuthorizationParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthImportAuthorization(id int32, bytes []byte) (AuthAuthorization, error) {
-	responseData, err := c.MakeRequest(&AuthImportAuthorizationParams{
+	return c.AuthImportAuthorizationContext(context.Background(), id, bytes)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthImportAuthorizationContext(ctx context.Context, id int32, bytes []byte) (AuthAuthorization, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthImportAuthorizationParams{
 		Bytes: bytes,
 		ID:    id,
 	})
@@ -1950,7 +2326,12 @@ func (*AuthImportBotAuthorizationParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthImportBotAuthorization(flags, apiID int32, apiHash, botAuthToken string) (AuthAuthorization, error) {
-	responseData, err := c.MakeRequest(&AuthImportBotAuthorizationParams{
+	return c.AuthImportBotAuthorizationContext(context.Background(), flags, apiID, apiHash, botAuthToken)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthImportBotAuthorizationContext(ctx context.Context, flags, apiID int32, apiHash, botAuthToken string) (AuthAuthorization, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthImportBotAuthorizationParams{
 		APIHash:      apiHash,
 		APIID:        apiID,
 		BotAuthToken: botAuthToken,
@@ -1978,7 +2359,12 @@ func (*AuthImportLoginTokenParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthImportLoginToken(token []byte) (AuthLoginToken, error) {
-	responseData, err := c.MakeRequest(&AuthImportLoginTokenParams{Token: token})
+	return c.AuthImportLoginTokenContext(context.Background(), token)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthImportLoginTokenContext(ctx context.Context, token []byte) (AuthLoginToken, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthImportLoginTokenParams{Token: token})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AuthImportLoginToken")
 	}
@@ -1999,7 +2385,12 @@ func (*AuthLogOutParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthLogOut() (bool, error) {
-	responseData, err := c.MakeRequest(&AuthLogOutParams{})
+	return c.AuthLogOutContext(context.Background())
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthLogOutContext(ctx context.Context) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthLogOutParams{})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AuthLogOut")
 	}
@@ -2022,7 +2413,12 @@ func (*AuthRecoverPasswordParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthRecoverPassword(code string) (AuthAuthorization, error) {
-	responseData, err := c.MakeRequest(&AuthRecoverPasswordParams{Code: code})
+	return c.AuthRecoverPasswordContext(context.Background(), code)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthRecoverPasswordContext(ctx context.Context, code string) (AuthAuthorization, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthRecoverPasswordParams{Code: code})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AuthRecoverPassword")
 	}
@@ -2043,7 +2439,12 @@ func (*AuthRequestPasswordRecoveryParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthRequestPasswordRecovery() (*AuthPasswordRecovery, error) {
-	responseData, err := c.MakeRequest(&AuthRequestPasswordRecoveryParams{})
+	return c.AuthRequestPasswordRecoveryContext(context.Background())
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthRequestPasswordRecoveryContext(ctx context.Context) (*AuthPasswordRecovery, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthRequestPasswordRecoveryParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending AuthRequestPasswordRecovery")
 	}
@@ -2067,7 +2468,12 @@ func (*AuthResendCodeParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthResendCode(phoneNumber, phoneCodeHash string) (*AuthSentCode, error) {
-	responseData, err := c.MakeRequest(&AuthResendCodeParams{
+	return c.AuthResendCodeContext(context.Background(), phoneNumber, phoneCodeHash)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthResendCodeContext(ctx context.Context, phoneNumber, phoneCodeHash string) (*AuthSentCode, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthResendCodeParams{
 		PhoneCodeHash: phoneCodeHash,
 		PhoneNumber:   phoneNumber,
 	})
@@ -2091,7 +2497,12 @@ func (*AuthResetAuthorizationsParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthResetAuthorizations() (bool, error) {
-	responseData, err := c.MakeRequest(&AuthResetAuthorizationsParams{})
+	return c.AuthResetAuthorizationsContext(context.Background())
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthResetAuthorizationsContext(ctx context.Context) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthResetAuthorizationsParams{})
 	if err != nil {
 		return false, errors.Wrap(err, "sending AuthResetAuthorizations")
 	}
@@ -2117,7 +2528,12 @@ func (*AuthSendCodeParams) CRC() uint32 {
 
 // Send the verification code for login
 func (c *Client) AuthSendCode(phoneNumber string, apiID int32, apiHash string, settings *CodeSettings) (*AuthSentCode, error) {
-	responseData, err := c.MakeRequest(&AuthSendCodeParams{
+	return c.AuthSendCodeContext(context.Background(), phoneNumber, apiID, apiHash, settings)
+}
+
+// Send the verification code for login
+func (c *Client) AuthSendCodeContext(ctx context.Context, phoneNumber string, apiID int32, apiHash string, settings *CodeSettings) (*AuthSentCode, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthSendCodeParams{
 		APIHash:     apiHash,
 		APIID:       apiID,
 		PhoneNumber: phoneNumber,
@@ -2147,7 +2563,12 @@ func (*AuthSignInParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthSignIn(phoneNumber, phoneCodeHash, phoneCode string) (AuthAuthorization, error) {
-	responseData, err := c.MakeRequest(&AuthSignInParams{
+	return c.AuthSignInContext(context.Background(), phoneNumber, phoneCodeHash, phoneCode)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthSignInContext(ctx context.Context, phoneNumber, phoneCodeHash, phoneCode string) (AuthAuthorization, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthSignInParams{
 		PhoneCode:     phoneCode,
 		PhoneCodeHash: phoneCodeHash,
 		PhoneNumber:   phoneNumber,
@@ -2177,7 +2598,12 @@ func (*AuthSignUpParams) CRC() uint32 {
 
 // Registers a validated phone number in the system.
 func (c *Client) AuthSignUp(phoneNumber, phoneCodeHash, firstName, lastName string) (AuthAuthorization, error) {
-	responseData, err := c.MakeRequest(&AuthSignUpParams{
+	return c.AuthSignUpContext(context.Background(), phoneNumber, phoneCodeHash, firstName, lastName)
+}
+
+// Registers a validated phone number in the system.
+func (c *Client) AuthSignUpContext(ctx context.Context, phoneNumber, phoneCodeHash, firstName, lastName string) (AuthAuthorization, error) {
+	responseData, err := c.MakeRequestContext(ctx, &AuthSignUpParams{
 		FirstName:     firstName,
 		LastName:      lastName,
 		PhoneCodeHash: phoneCodeHash,
@@ -2206,8 +2632,13 @@ func (*BotsAnswerWebhookJsonQueryParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) BotsAnswerWebhookJsonQuery(queryID int64, data *DataJson) (bool, error) {
-	responseData, err := c.MakeRequest(&BotsAnswerWebhookJsonQueryParams{
-		Data:    data,
+	return c.BotsAnswerWebhookJsonQueryContext(context.Background(), queryID, data)
+}
+
+// Get the participants of a channel
+func (c *Client) BotsAnswerWebhookJsonQueryContext(ctx context.Context, queryID int64, data *DataJson) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &BotsAnswerWebhookJsonQueryParams{
+		Data:    data,
 		QueryID: queryID,
 	})
 	if err != nil {
@@ -2233,7 +2664,12 @@ func (*BotsSendCustomRequestParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) BotsSendCustomRequest(customMethod string, params *DataJson) (*DataJson, error) {
-	responseData, err := c.MakeRequest(&BotsSendCustomRequestParams{
+	return c.BotsSendCustomRequestContext(context.Background(), customMethod, params)
+}
+
+// Get the participants of a channel
+func (c *Client) BotsSendCustomRequestContext(ctx context.Context, customMethod string, params *DataJson) (*DataJson, error) {
+	responseData, err := c.MakeRequestContext(ctx, &BotsSendCustomRequestParams{
 		CustomMethod: customMethod,
 		Params:       params,
 	})
@@ -2259,7 +2695,12 @@ func (*BotsSetBotCommandsParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) BotsSetBotCommands(commands []*BotCommand) (bool, error) {
-	responseData, err := c.MakeRequest(&BotsSetBotCommandsParams{Commands: commands})
+	return c.BotsSetBotCommandsContext(context.Background(), commands)
+}
+
+// Get the participants of a channel
+func (c *Client) BotsSetBotCommandsContext(ctx context.Context, commands []*BotCommand) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &BotsSetBotCommandsParams{Commands: commands})
 	if err != nil {
 		return false, errors.Wrap(err, "sending BotsSetBotCommands")
 	}
@@ -2283,7 +2724,12 @@ func (*ChannelsCheckUsernameParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsCheckUsername(channel InputChannel, username string) (bool, error) {
-	responseData, err := c.MakeRequest(&ChannelsCheckUsernameParams{
+	return c.ChannelsCheckUsernameContext(context.Background(), channel, username)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsCheckUsernameContext(ctx context.Context, channel InputChannel, username string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsCheckUsernameParams{
 		Channel:  channel,
 		Username: username,
 	})
@@ -2318,7 +2764,12 @@ func (*ChannelsCreateChannelParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) ChannelsCreateChannel(params *ChannelsCreateChannelParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.ChannelsCreateChannelContext(context.Background(), params)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsCreateChannelContext(ctx context.Context, params *ChannelsCreateChannelParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ChannelsCreateChannel")
 	}
@@ -2341,7 +2792,12 @@ func (*ChannelsDeleteChannelParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsDeleteChannel(channel InputChannel) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsDeleteChannelParams{Channel: channel})
+	return c.ChannelsDeleteChannelContext(context.Background(), channel)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsDeleteChannelContext(ctx context.Context, channel InputChannel) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsDeleteChannelParams{Channel: channel})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ChannelsDeleteChannel")
 	}
@@ -2365,7 +2821,12 @@ func (*ChannelsDeleteHistoryParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsDeleteHistory(channel InputChannel, maxID int32) (bool, error) {
-	responseData, err := c.MakeRequest(&ChannelsDeleteHistoryParams{
+	return c.ChannelsDeleteHistoryContext(context.Background(), channel, maxID)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsDeleteHistoryContext(ctx context.Context, channel InputChannel, maxID int32) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsDeleteHistoryParams{
 		Channel: channel,
 		MaxID:   maxID,
 	})
@@ -2392,7 +2853,12 @@ func (*ChannelsDeleteMessagesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ChannelsDeleteMessages(channel InputChannel, id []int32) (*MessagesAffectedMessages, error) {
-	responseData, err := c.MakeRequest(&ChannelsDeleteMessagesParams{
+	return c.ChannelsDeleteMessagesContext(context.Background(), channel, id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ChannelsDeleteMessagesContext(ctx context.Context, channel InputChannel, id []int32) (*MessagesAffectedMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsDeleteMessagesParams{
 		Channel: channel,
 		ID:      id,
 	})
@@ -2419,7 +2885,12 @@ func (*ChannelsDeleteUserHistoryParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ChannelsDeleteUserHistory(channel InputChannel, userID InputUser) (*MessagesAffectedHistory, error) {
-	responseData, err := c.MakeRequest(&ChannelsDeleteUserHistoryParams{
+	return c.ChannelsDeleteUserHistoryContext(context.Background(), channel, userID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ChannelsDeleteUserHistoryContext(ctx context.Context, channel InputChannel, userID InputUser) (*MessagesAffectedHistory, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsDeleteUserHistoryParams{
 		Channel: channel,
 		UserID:  userID,
 	})
@@ -2448,7 +2919,12 @@ func (*ChannelsEditAdminParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsEditAdmin(channel InputChannel, userID InputUser, adminRights *ChatAdminRights, rank string) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsEditAdminParams{
+	return c.ChannelsEditAdminContext(context.Background(), channel, userID, adminRights, rank)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsEditAdminContext(ctx context.Context, channel InputChannel, userID InputUser, adminRights *ChatAdminRights, rank string) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsEditAdminParams{
 		AdminRights: adminRights,
 		Channel:     channel,
 		Rank:        rank,
@@ -2478,7 +2954,12 @@ func (*ChannelsEditBannedParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsEditBanned(channel InputChannel, userID InputUser, bannedRights *ChatBannedRights) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsEditBannedParams{
+	return c.ChannelsEditBannedContext(context.Background(), channel, userID, bannedRights)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsEditBannedContext(ctx context.Context, channel InputChannel, userID InputUser, bannedRights *ChatBannedRights) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsEditBannedParams{
 		BannedRights: bannedRights,
 		Channel:      channel,
 		UserID:       userID,
@@ -2507,7 +2988,12 @@ func (*ChannelsEditCreatorParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsEditCreator(channel InputChannel, userID InputUser, password InputCheckPasswordSRP) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsEditCreatorParams{
+	return c.ChannelsEditCreatorContext(context.Background(), channel, userID, password)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsEditCreatorContext(ctx context.Context, channel InputChannel, userID InputUser, password InputCheckPasswordSRP) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsEditCreatorParams{
 		Channel:  channel,
 		Password: password,
 		UserID:   userID,
@@ -2536,7 +3022,12 @@ func (*ChannelsEditLocationParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsEditLocation(channel InputChannel, geoPoint InputGeoPoint, address string) (bool, error) {
-	responseData, err := c.MakeRequest(&ChannelsEditLocationParams{
+	return c.ChannelsEditLocationContext(context.Background(), channel, geoPoint, address)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsEditLocationContext(ctx context.Context, channel InputChannel, geoPoint InputGeoPoint, address string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsEditLocationParams{
 		Address:  address,
 		Channel:  channel,
 		GeoPoint: geoPoint,
@@ -2564,7 +3055,12 @@ func (*ChannelsEditPhotoParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsEditPhoto(channel InputChannel, photo InputChatPhoto) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsEditPhotoParams{
+	return c.ChannelsEditPhotoContext(context.Background(), channel, photo)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsEditPhotoContext(ctx context.Context, channel InputChannel, photo InputChatPhoto) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsEditPhotoParams{
 		Channel: channel,
 		Photo:   photo,
 	})
@@ -2591,7 +3087,12 @@ func (*ChannelsEditTitleParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsEditTitle(channel InputChannel, title string) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsEditTitleParams{
+	return c.ChannelsEditTitleContext(context.Background(), channel, title)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsEditTitleContext(ctx context.Context, channel InputChannel, title string) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsEditTitleParams{
 		Channel: channel,
 		Title:   title,
 	})
@@ -2624,7 +3125,12 @@ func (*ChannelsExportMessageLinkParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) ChannelsExportMessageLink(grouped, thread bool, channel InputChannel, id int32) (*ExportedMessageLink, error) {
-	responseData, err := c.MakeRequest(&ChannelsExportMessageLinkParams{
+	return c.ChannelsExportMessageLinkContext(context.Background(), grouped, thread, channel, id)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsExportMessageLinkContext(ctx context.Context, grouped, thread bool, channel InputChannel, id int32) (*ExportedMessageLink, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsExportMessageLinkParams{
 		Channel: channel,
 		Grouped: grouped,
 		ID:      id,
@@ -2662,7 +3168,12 @@ func (*ChannelsGetAdminLogParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) ChannelsGetAdminLog(params *ChannelsGetAdminLogParams) (*ChannelsAdminLogResults, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.ChannelsGetAdminLogContext(context.Background(), params)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsGetAdminLogContext(ctx context.Context, params *ChannelsGetAdminLogParams) (*ChannelsAdminLogResults, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ChannelsGetAdminLog")
 	}
@@ -2690,7 +3201,12 @@ func (*ChannelsGetAdminedPublicChannelsParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) ChannelsGetAdminedPublicChannels(byLocation, checkLimit bool) (MessagesChats, error) {
-	responseData, err := c.MakeRequest(&ChannelsGetAdminedPublicChannelsParams{
+	return c.ChannelsGetAdminedPublicChannelsContext(context.Background(), byLocation, checkLimit)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsGetAdminedPublicChannelsContext(ctx context.Context, byLocation, checkLimit bool) (MessagesChats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsGetAdminedPublicChannelsParams{
 		ByLocation: byLocation,
 		CheckLimit: checkLimit,
 	})
@@ -2716,7 +3232,12 @@ func (*ChannelsGetChannelsParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsGetChannels(id []InputChannel) (MessagesChats, error) {
-	responseData, err := c.MakeRequest(&ChannelsGetChannelsParams{ID: id})
+	return c.ChannelsGetChannelsContext(context.Background(), id)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsGetChannelsContext(ctx context.Context, id []InputChannel) (MessagesChats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsGetChannelsParams{ID: id})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ChannelsGetChannels")
 	}
@@ -2739,7 +3260,12 @@ func (*ChannelsGetFullChannelParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsGetFullChannel(channel InputChannel) (*MessagesChatFull, error) {
-	responseData, err := c.MakeRequest(&ChannelsGetFullChannelParams{Channel: channel})
+	return c.ChannelsGetFullChannelContext(context.Background(), channel)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsGetFullChannelContext(ctx context.Context, channel InputChannel) (*MessagesChatFull, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsGetFullChannelParams{Channel: channel})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ChannelsGetFullChannel")
 	}
@@ -2760,7 +3286,12 @@ func (*ChannelsGetGroupsForDiscussionParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsGetGroupsForDiscussion() (MessagesChats, error) {
-	responseData, err := c.MakeRequest(&ChannelsGetGroupsForDiscussionParams{})
+	return c.ChannelsGetGroupsForDiscussionContext(context.Background())
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsGetGroupsForDiscussionContext(ctx context.Context) (MessagesChats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsGetGroupsForDiscussionParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ChannelsGetGroupsForDiscussion")
 	}
@@ -2781,7 +3312,12 @@ func (*ChannelsGetInactiveChannelsParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsGetInactiveChannels() (*MessagesInactiveChats, error) {
-	responseData, err := c.MakeRequest(&ChannelsGetInactiveChannelsParams{})
+	return c.ChannelsGetInactiveChannelsContext(context.Background())
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsGetInactiveChannelsContext(ctx context.Context) (*MessagesInactiveChats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsGetInactiveChannelsParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ChannelsGetInactiveChannels")
 	}
@@ -2804,7 +3340,12 @@ func (*ChannelsGetLeftChannelsParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsGetLeftChannels(offset int32) (MessagesChats, error) {
-	responseData, err := c.MakeRequest(&ChannelsGetLeftChannelsParams{Offset: offset})
+	return c.ChannelsGetLeftChannelsContext(context.Background(), offset)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsGetLeftChannelsContext(ctx context.Context, offset int32) (MessagesChats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsGetLeftChannelsParams{Offset: offset})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ChannelsGetLeftChannels")
 	}
@@ -2828,7 +3369,12 @@ func (*ChannelsGetMessagesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ChannelsGetMessages(channel InputChannel, id []InputMessage) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(&ChannelsGetMessagesParams{
+	return c.ChannelsGetMessagesContext(context.Background(), channel, id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ChannelsGetMessagesContext(ctx context.Context, channel InputChannel, id []InputMessage) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsGetMessagesParams{
 		Channel: channel,
 		ID:      id,
 	})
@@ -2855,7 +3401,12 @@ func (*ChannelsGetParticipantParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsGetParticipant(channel InputChannel, userID InputUser) (*ChannelsChannelParticipant, error) {
-	responseData, err := c.MakeRequest(&ChannelsGetParticipantParams{
+	return c.ChannelsGetParticipantContext(context.Background(), channel, userID)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsGetParticipantContext(ctx context.Context, channel InputChannel, userID InputUser) (*ChannelsChannelParticipant, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsGetParticipantParams{
 		Channel: channel,
 		UserID:  userID,
 	})
@@ -2885,7 +3436,12 @@ func (*ChannelsGetParticipantsParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsGetParticipants(channel InputChannel, filter ChannelParticipantsFilter, offset, limit, hash int32) (ChannelsChannelParticipants, error) {
-	responseData, err := c.MakeRequest(&ChannelsGetParticipantsParams{
+	return c.ChannelsGetParticipantsContext(context.Background(), channel, filter, offset, limit, hash)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsGetParticipantsContext(ctx context.Context, channel InputChannel, filter ChannelParticipantsFilter, offset, limit, hash int32) (ChannelsChannelParticipants, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsGetParticipantsParams{
 		Channel: channel,
 		Filter:  filter,
 		Hash:    hash,
@@ -2915,7 +3471,12 @@ func (*ChannelsInviteToChannelParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsInviteToChannel(channel InputChannel, users []InputUser) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsInviteToChannelParams{
+	return c.ChannelsInviteToChannelContext(context.Background(), channel, users)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsInviteToChannelContext(ctx context.Context, channel InputChannel, users []InputUser) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsInviteToChannelParams{
 		Channel: channel,
 		Users:   users,
 	})
@@ -2941,7 +3502,12 @@ func (*ChannelsJoinChannelParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsJoinChannel(channel InputChannel) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsJoinChannelParams{Channel: channel})
+	return c.ChannelsJoinChannelContext(context.Background(), channel)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsJoinChannelContext(ctx context.Context, channel InputChannel) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsJoinChannelParams{Channel: channel})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ChannelsJoinChannel")
 	}
@@ -2964,7 +3530,12 @@ func (*ChannelsLeaveChannelParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsLeaveChannel(channel InputChannel) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsLeaveChannelParams{Channel: channel})
+	return c.ChannelsLeaveChannelContext(context.Background(), channel)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsLeaveChannelContext(ctx context.Context, channel InputChannel) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsLeaveChannelParams{Channel: channel})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ChannelsLeaveChannel")
 	}
@@ -2988,7 +3559,12 @@ func (*ChannelsReadHistoryParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ChannelsReadHistory(channel InputChannel, maxID int32) (bool, error) {
-	responseData, err := c.MakeRequest(&ChannelsReadHistoryParams{
+	return c.ChannelsReadHistoryContext(context.Background(), channel, maxID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ChannelsReadHistoryContext(ctx context.Context, channel InputChannel, maxID int32) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsReadHistoryParams{
 		Channel: channel,
 		MaxID:   maxID,
 	})
@@ -3015,7 +3591,12 @@ func (*ChannelsReadMessageContentsParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsReadMessageContents(channel InputChannel, id []int32) (bool, error) {
-	responseData, err := c.MakeRequest(&ChannelsReadMessageContentsParams{
+	return c.ChannelsReadMessageContentsContext(context.Background(), channel, id)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsReadMessageContentsContext(ctx context.Context, channel InputChannel, id []int32) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsReadMessageContentsParams{
 		Channel: channel,
 		ID:      id,
 	})
@@ -3043,7 +3624,12 @@ func (*ChannelsReportSpamParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ChannelsReportSpam(channel InputChannel, userID InputUser, id []int32) (bool, error) {
-	responseData, err := c.MakeRequest(&ChannelsReportSpamParams{
+	return c.ChannelsReportSpamContext(context.Background(), channel, userID, id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ChannelsReportSpamContext(ctx context.Context, channel InputChannel, userID InputUser, id []int32) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsReportSpamParams{
 		Channel: channel,
 		ID:      id,
 		UserID:  userID,
@@ -3071,7 +3657,12 @@ func (*ChannelsSetDiscussionGroupParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsSetDiscussionGroup(broadcast, group InputChannel) (bool, error) {
-	responseData, err := c.MakeRequest(&ChannelsSetDiscussionGroupParams{
+	return c.ChannelsSetDiscussionGroupContext(context.Background(), broadcast, group)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsSetDiscussionGroupContext(ctx context.Context, broadcast, group InputChannel) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsSetDiscussionGroupParams{
 		Broadcast: broadcast,
 		Group:     group,
 	})
@@ -3098,7 +3689,12 @@ func (*ChannelsSetStickersParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsSetStickers(channel InputChannel, stickerset InputStickerSet) (bool, error) {
-	responseData, err := c.MakeRequest(&ChannelsSetStickersParams{
+	return c.ChannelsSetStickersContext(context.Background(), channel, stickerset)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsSetStickersContext(ctx context.Context, channel InputChannel, stickerset InputStickerSet) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsSetStickersParams{
 		Channel:    channel,
 		Stickerset: stickerset,
 	})
@@ -3125,7 +3721,12 @@ func (*ChannelsTogglePreHistoryHiddenParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsTogglePreHistoryHidden(channel InputChannel, enabled bool) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsTogglePreHistoryHiddenParams{
+	return c.ChannelsTogglePreHistoryHiddenContext(context.Background(), channel, enabled)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsTogglePreHistoryHiddenContext(ctx context.Context, channel InputChannel, enabled bool) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsTogglePreHistoryHiddenParams{
 		Channel: channel,
 		Enabled: enabled,
 	})
@@ -3152,7 +3753,12 @@ func (*ChannelsToggleSignaturesParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsToggleSignatures(channel InputChannel, enabled bool) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsToggleSignaturesParams{
+	return c.ChannelsToggleSignaturesContext(context.Background(), channel, enabled)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsToggleSignaturesContext(ctx context.Context, channel InputChannel, enabled bool) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsToggleSignaturesParams{
 		Channel: channel,
 		Enabled: enabled,
 	})
@@ -3179,7 +3785,12 @@ func (*ChannelsToggleSlowModeParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsToggleSlowMode(channel InputChannel, seconds int32) (Updates, error) {
-	responseData, err := c.MakeRequest(&ChannelsToggleSlowModeParams{
+	return c.ChannelsToggleSlowModeContext(context.Background(), channel, seconds)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsToggleSlowModeContext(ctx context.Context, channel InputChannel, seconds int32) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsToggleSlowModeParams{
 		Channel: channel,
 		Seconds: seconds,
 	})
@@ -3206,7 +3817,12 @@ func (*ChannelsUpdateUsernameParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) ChannelsUpdateUsername(channel InputChannel, username string) (bool, error) {
-	responseData, err := c.MakeRequest(&ChannelsUpdateUsernameParams{
+	return c.ChannelsUpdateUsernameContext(context.Background(), channel, username)
+}
+
+// Get the participants of a channel
+func (c *Client) ChannelsUpdateUsernameContext(ctx context.Context, channel InputChannel, username string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ChannelsUpdateUsernameParams{
 		Channel:  channel,
 		Username: username,
 	})
@@ -3232,7 +3848,12 @@ func (*ContactsAcceptContactParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsAcceptContact(id InputUser) (Updates, error) {
-	responseData, err := c.MakeRequest(&ContactsAcceptContactParams{ID: id})
+	return c.ContactsAcceptContactContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsAcceptContactContext(ctx context.Context, id InputUser) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsAcceptContactParams{ID: id})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ContactsAcceptContact")
 	}
@@ -3263,7 +3884,12 @@ func (*ContactsAddContactParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsAddContact(params *ContactsAddContactParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.ContactsAddContactContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsAddContactContext(ctx context.Context, params *ContactsAddContactParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ContactsAddContact")
 	}
@@ -3286,7 +3912,12 @@ func (*ContactsBlockParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsBlock(id InputPeer) (bool, error) {
-	responseData, err := c.MakeRequest(&ContactsBlockParams{ID: id})
+	return c.ContactsBlockContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsBlockContext(ctx context.Context, id InputPeer) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsBlockParams{ID: id})
 	if err != nil {
 		return false, errors.Wrap(err, "sending ContactsBlock")
 	}
@@ -3316,7 +3947,12 @@ func (*ContactsBlockFromRepliesParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsBlockFromReplies(deleteMessage, deleteHistory, reportSpam bool, msgID int32) (Updates, error) {
-	responseData, err := c.MakeRequest(&ContactsBlockFromRepliesParams{
+	return c.ContactsBlockFromRepliesContext(context.Background(), deleteMessage, deleteHistory, reportSpam, msgID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsBlockFromRepliesContext(ctx context.Context, deleteMessage, deleteHistory, reportSpam bool, msgID int32) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsBlockFromRepliesParams{
 		DeleteHistory: deleteHistory,
 		DeleteMessage: deleteMessage,
 		MsgID:         msgID,
@@ -3344,7 +3980,12 @@ func (*ContactsDeleteByPhonesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsDeleteByPhones(phones []string) (bool, error) {
-	responseData, err := c.MakeRequest(&ContactsDeleteByPhonesParams{Phones: phones})
+	return c.ContactsDeleteByPhonesContext(context.Background(), phones)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsDeleteByPhonesContext(ctx context.Context, phones []string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsDeleteByPhonesParams{Phones: phones})
 	if err != nil {
 		return false, errors.Wrap(err, "sending ContactsDeleteByPhones")
 	}
@@ -3367,7 +4008,12 @@ func (*ContactsDeleteContactsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsDeleteContacts(id []InputUser) (Updates, error) {
-	responseData, err := c.MakeRequest(&ContactsDeleteContactsParams{ID: id})
+	return c.ContactsDeleteContactsContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsDeleteContactsContext(ctx context.Context, id []InputUser) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsDeleteContactsParams{ID: id})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ContactsDeleteContacts")
 	}
@@ -3391,7 +4037,12 @@ func (*ContactsGetBlockedParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsGetBlocked(offset, limit int32) (ContactsBlocked, error) {
-	responseData, err := c.MakeRequest(&ContactsGetBlockedParams{
+	return c.ContactsGetBlockedContext(context.Background(), offset, limit)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsGetBlockedContext(ctx context.Context, offset, limit int32) (ContactsBlocked, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsGetBlockedParams{
 		Limit:  limit,
 		Offset: offset,
 	})
@@ -3417,7 +4068,12 @@ func (*ContactsGetContactIDsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsGetContactIDs(hash int32) ([]int32, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&ContactsGetContactIDsParams{Hash: hash}, reflect.TypeOf([]int32{}))
+	return c.ContactsGetContactIDsContext(context.Background(), hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsGetContactIDsContext(ctx context.Context, hash int32) ([]int32, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &ContactsGetContactIDsParams{Hash: hash}, reflect.TypeOf([]int32{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ContactsGetContactIDs")
 	}
@@ -3440,7 +4096,12 @@ func (*ContactsGetContactsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsGetContacts(hash int32) (ContactsContacts, error) {
-	responseData, err := c.MakeRequest(&ContactsGetContactsParams{Hash: hash})
+	return c.ContactsGetContactsContext(context.Background(), hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsGetContactsContext(ctx context.Context, hash int32) (ContactsContacts, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsGetContactsParams{Hash: hash})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ContactsGetContacts")
 	}
@@ -3469,7 +4130,12 @@ func (*ContactsGetLocatedParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsGetLocated(background bool, geoPoint InputGeoPoint, selfExpires int32) (Updates, error) {
-	responseData, err := c.MakeRequest(&ContactsGetLocatedParams{
+	return c.ContactsGetLocatedContext(context.Background(), background, geoPoint, selfExpires)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsGetLocatedContext(ctx context.Context, background bool, geoPoint InputGeoPoint, selfExpires int32) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsGetLocatedParams{
 		Background:  background,
 		GeoPoint:    geoPoint,
 		SelfExpires: selfExpires,
@@ -3494,7 +4160,12 @@ func (*ContactsGetSavedParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsGetSaved() ([]*SavedPhoneContact, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&ContactsGetSavedParams{}, reflect.TypeOf([]*SavedPhoneContact{}))
+	return c.ContactsGetSavedContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsGetSavedContext(ctx context.Context) ([]*SavedPhoneContact, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &ContactsGetSavedParams{}, reflect.TypeOf([]*SavedPhoneContact{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ContactsGetSaved")
 	}
@@ -3515,7 +4186,12 @@ func (*ContactsGetStatusesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsGetStatuses() ([]*ContactStatus, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&ContactsGetStatusesParams{}, reflect.TypeOf([]*ContactStatus{}))
+	return c.ContactsGetStatusesContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsGetStatusesContext(ctx context.Context) ([]*ContactStatus, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &ContactsGetStatusesParams{}, reflect.TypeOf([]*ContactStatus{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ContactsGetStatuses")
 	}
@@ -3552,7 +4228,12 @@ func (*ContactsGetTopPeersParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsGetTopPeers(params *ContactsGetTopPeersParams) (ContactsTopPeers, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.ContactsGetTopPeersContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsGetTopPeersContext(ctx context.Context, params *ContactsGetTopPeersParams) (ContactsTopPeers, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ContactsGetTopPeers")
 	}
@@ -3575,7 +4256,12 @@ func (*ContactsImportContactsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsImportContacts(contacts []*InputPhoneContact) (*ContactsImportedContacts, error) {
-	responseData, err := c.MakeRequest(&ContactsImportContactsParams{Contacts: contacts})
+	return c.ContactsImportContactsContext(context.Background(), contacts)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsImportContactsContext(ctx context.Context, contacts []*InputPhoneContact) (*ContactsImportedContacts, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsImportContactsParams{Contacts: contacts})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ContactsImportContacts")
 	}
@@ -3596,7 +4282,12 @@ func (*ContactsResetSavedParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsResetSaved() (bool, error) {
-	responseData, err := c.MakeRequest(&ContactsResetSavedParams{})
+	return c.ContactsResetSavedContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsResetSavedContext(ctx context.Context) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsResetSavedParams{})
 	if err != nil {
 		return false, errors.Wrap(err, "sending ContactsResetSaved")
 	}
@@ -3620,7 +4311,12 @@ func (*ContactsResetTopPeerRatingParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsResetTopPeerRating(category TopPeerCategory, peer InputPeer) (bool, error) {
-	responseData, err := c.MakeRequest(&ContactsResetTopPeerRatingParams{
+	return c.ContactsResetTopPeerRatingContext(context.Background(), category, peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsResetTopPeerRatingContext(ctx context.Context, category TopPeerCategory, peer InputPeer) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsResetTopPeerRatingParams{
 		Category: category,
 		Peer:     peer,
 	})
@@ -3646,7 +4342,12 @@ func (*ContactsResolveUsernameParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsResolveUsername(username string) (*ContactsResolvedPeer, error) {
-	responseData, err := c.MakeRequest(&ContactsResolveUsernameParams{Username: username})
+	return c.ContactsResolveUsernameContext(context.Background(), username)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsResolveUsernameContext(ctx context.Context, username string) (*ContactsResolvedPeer, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsResolveUsernameParams{Username: username})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending ContactsResolveUsername")
 	}
@@ -3670,7 +4371,12 @@ func (*ContactsSearchParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsSearch(q string, limit int32) (*ContactsFound, error) {
-	responseData, err := c.MakeRequest(&ContactsSearchParams{
+	return c.ContactsSearchContext(context.Background(), q, limit)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsSearchContext(ctx context.Context, q string, limit int32) (*ContactsFound, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsSearchParams{
 		Limit: limit,
 		Q:     q,
 	})
@@ -3696,7 +4402,12 @@ func (*ContactsToggleTopPeersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsToggleTopPeers(enabled bool) (bool, error) {
-	responseData, err := c.MakeRequest(&ContactsToggleTopPeersParams{Enabled: enabled})
+	return c.ContactsToggleTopPeersContext(context.Background(), enabled)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsToggleTopPeersContext(ctx context.Context, enabled bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsToggleTopPeersParams{Enabled: enabled})
 	if err != nil {
 		return false, errors.Wrap(err, "sending ContactsToggleTopPeers")
 	}
@@ -3719,7 +4430,12 @@ func (*ContactsUnblockParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) ContactsUnblock(id InputPeer) (bool, error) {
-	responseData, err := c.MakeRequest(&ContactsUnblockParams{ID: id})
+	return c.ContactsUnblockContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) ContactsUnblockContext(ctx context.Context, id InputPeer) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &ContactsUnblockParams{ID: id})
 	if err != nil {
 		return false, errors.Wrap(err, "sending ContactsUnblock")
 	}
@@ -3742,7 +4458,12 @@ func (*FoldersDeleteFolderParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) FoldersDeleteFolder(folderID int32) (Updates, error) {
-	responseData, err := c.MakeRequest(&FoldersDeleteFolderParams{FolderID: folderID})
+	return c.FoldersDeleteFolderContext(context.Background(), folderID)
+}
+
+// Get the participants of a channel
+func (c *Client) FoldersDeleteFolderContext(ctx context.Context, folderID int32) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &FoldersDeleteFolderParams{FolderID: folderID})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending FoldersDeleteFolder")
 	}
@@ -3765,7 +4486,12 @@ func (*FoldersEditPeerFoldersParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) FoldersEditPeerFolders(folderPeers []*InputFolderPeer) (Updates, error) {
-	responseData, err := c.MakeRequest(&FoldersEditPeerFoldersParams{FolderPeers: folderPeers})
+	return c.FoldersEditPeerFoldersContext(context.Background(), folderPeers)
+}
+
+// Get the participants of a channel
+func (c *Client) FoldersEditPeerFoldersContext(ctx context.Context, folderPeers []*InputFolderPeer) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &FoldersEditPeerFoldersParams{FolderPeers: folderPeers})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending FoldersEditPeerFolders")
 	}
@@ -3788,7 +4514,12 @@ func (*HelpAcceptTermsOfServiceParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpAcceptTermsOfService(id *DataJson) (bool, error) {
-	responseData, err := c.MakeRequest(&HelpAcceptTermsOfServiceParams{ID: id})
+	return c.HelpAcceptTermsOfServiceContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpAcceptTermsOfServiceContext(ctx context.Context, id *DataJson) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpAcceptTermsOfServiceParams{ID: id})
 	if err != nil {
 		return false, errors.Wrap(err, "sending HelpAcceptTermsOfService")
 	}
@@ -3811,7 +4542,12 @@ func (*HelpDismissSuggestionParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpDismissSuggestion(suggestion string) (bool, error) {
-	responseData, err := c.MakeRequest(&HelpDismissSuggestionParams{Suggestion: suggestion})
+	return c.HelpDismissSuggestionContext(context.Background(), suggestion)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpDismissSuggestionContext(ctx context.Context, suggestion string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpDismissSuggestionParams{Suggestion: suggestion})
 	if err != nil {
 		return false, errors.Wrap(err, "sending HelpDismissSuggestion")
 	}
@@ -3836,7 +4572,12 @@ func (*HelpEditUserInfoParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpEditUserInfo(userID InputUser, message string, entities []MessageEntity) (HelpUserInfo, error) {
-	responseData, err := c.MakeRequest(&HelpEditUserInfoParams{
+	return c.HelpEditUserInfoContext(context.Background(), userID, message, entities)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpEditUserInfoContext(ctx context.Context, userID InputUser, message string, entities []MessageEntity) (HelpUserInfo, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpEditUserInfoParams{
 		Entities: entities,
 		Message:  message,
 		UserID:   userID,
@@ -3863,7 +4604,12 @@ func (*HelpGetAppChangelogParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetAppChangelog(prevAppVersion string) (Updates, error) {
-	responseData, err := c.MakeRequest(&HelpGetAppChangelogParams{PrevAppVersion: prevAppVersion})
+	return c.HelpGetAppChangelogContext(context.Background(), prevAppVersion)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetAppChangelogContext(ctx context.Context, prevAppVersion string) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetAppChangelogParams{PrevAppVersion: prevAppVersion})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetAppChangelog")
 	}
@@ -3884,7 +4630,12 @@ func (*HelpGetAppConfigParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetAppConfig() (JsonValue, error) {
-	responseData, err := c.MakeRequest(&HelpGetAppConfigParams{})
+	return c.HelpGetAppConfigContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetAppConfigContext(ctx context.Context) (JsonValue, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetAppConfigParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetAppConfig")
 	}
@@ -3907,7 +4658,12 @@ func (*HelpGetAppUpdateParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetAppUpdate(source string) (HelpAppUpdate, error) {
-	responseData, err := c.MakeRequest(&HelpGetAppUpdateParams{Source: source})
+	return c.HelpGetAppUpdateContext(context.Background(), source)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetAppUpdateContext(ctx context.Context, source string) (HelpAppUpdate, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetAppUpdateParams{Source: source})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetAppUpdate")
 	}
@@ -3928,7 +4684,12 @@ func (*HelpGetCdnConfigParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetCdnConfig() (*CdnConfig, error) {
-	responseData, err := c.MakeRequest(&HelpGetCdnConfigParams{})
+	return c.HelpGetCdnConfigContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetCdnConfigContext(ctx context.Context) (*CdnConfig, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetCdnConfigParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetCdnConfig")
 	}
@@ -3949,7 +4710,12 @@ func (*HelpGetConfigParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetConfig() (*Config, error) {
-	responseData, err := c.MakeRequest(&HelpGetConfigParams{})
+	return c.HelpGetConfigContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetConfigContext(ctx context.Context) (*Config, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetConfigParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetConfig")
 	}
@@ -3973,7 +4739,12 @@ func (*HelpGetCountriesListParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetCountriesList(langCode string, hash int32) (HelpCountriesList, error) {
-	responseData, err := c.MakeRequest(&HelpGetCountriesListParams{
+	return c.HelpGetCountriesListContext(context.Background(), langCode, hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetCountriesListContext(ctx context.Context, langCode string, hash int32) (HelpCountriesList, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetCountriesListParams{
 		Hash:     hash,
 		LangCode: langCode,
 	})
@@ -3999,7 +4770,12 @@ func (*HelpGetDeepLinkInfoParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetDeepLinkInfo(path string) (HelpDeepLinkInfo, error) {
-	responseData, err := c.MakeRequest(&HelpGetDeepLinkInfoParams{Path: path})
+	return c.HelpGetDeepLinkInfoContext(context.Background(), path)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetDeepLinkInfoContext(ctx context.Context, path string) (HelpDeepLinkInfo, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetDeepLinkInfoParams{Path: path})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetDeepLinkInfo")
 	}
@@ -4020,7 +4796,12 @@ func (*HelpGetInviteTextParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetInviteText() (*HelpInviteText, error) {
-	responseData, err := c.MakeRequest(&HelpGetInviteTextParams{})
+	return c.HelpGetInviteTextContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetInviteTextContext(ctx context.Context) (*HelpInviteText, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetInviteTextParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetInviteText")
 	}
@@ -4041,7 +4822,12 @@ func (*HelpGetNearestDcParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetNearestDc() (*NearestDc, error) {
-	responseData, err := c.MakeRequest(&HelpGetNearestDcParams{})
+	return c.HelpGetNearestDcContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetNearestDcContext(ctx context.Context) (*NearestDc, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetNearestDcParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetNearestDc")
 	}
@@ -4064,7 +4850,12 @@ func (*HelpGetPassportConfigParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetPassportConfig(hash int32) (HelpPassportConfig, error) {
-	responseData, err := c.MakeRequest(&HelpGetPassportConfigParams{Hash: hash})
+	return c.HelpGetPassportConfigContext(context.Background(), hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetPassportConfigContext(ctx context.Context, hash int32) (HelpPassportConfig, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetPassportConfigParams{Hash: hash})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetPassportConfig")
 	}
@@ -4085,7 +4876,12 @@ func (*HelpGetPromoDataParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetPromoData() (HelpPromoData, error) {
-	responseData, err := c.MakeRequest(&HelpGetPromoDataParams{})
+	return c.HelpGetPromoDataContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetPromoDataContext(ctx context.Context) (HelpPromoData, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetPromoDataParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetPromoData")
 	}
@@ -4108,7 +4904,12 @@ func (*HelpGetRecentMeUrlsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetRecentMeUrls(referer string) (*HelpRecentMeUrls, error) {
-	responseData, err := c.MakeRequest(&HelpGetRecentMeUrlsParams{Referer: referer})
+	return c.HelpGetRecentMeUrlsContext(context.Background(), referer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetRecentMeUrlsContext(ctx context.Context, referer string) (*HelpRecentMeUrls, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetRecentMeUrlsParams{Referer: referer})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetRecentMeUrls")
 	}
@@ -4129,7 +4930,12 @@ func (*HelpGetSupportParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetSupport() (*HelpSupport, error) {
-	responseData, err := c.MakeRequest(&HelpGetSupportParams{})
+	return c.HelpGetSupportContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetSupportContext(ctx context.Context) (*HelpSupport, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetSupportParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetSupport")
 	}
@@ -4150,7 +4956,12 @@ func (*HelpGetSupportNameParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetSupportName() (*HelpSupportName, error) {
-	responseData, err := c.MakeRequest(&HelpGetSupportNameParams{})
+	return c.HelpGetSupportNameContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetSupportNameContext(ctx context.Context) (*HelpSupportName, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetSupportNameParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetSupportName")
 	}
@@ -4171,7 +4982,12 @@ func (*HelpGetTermsOfServiceUpdateParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetTermsOfServiceUpdate() (HelpTermsOfServiceUpdate, error) {
-	responseData, err := c.MakeRequest(&HelpGetTermsOfServiceUpdateParams{})
+	return c.HelpGetTermsOfServiceUpdateContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetTermsOfServiceUpdateContext(ctx context.Context) (HelpTermsOfServiceUpdate, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetTermsOfServiceUpdateParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetTermsOfServiceUpdate")
 	}
@@ -4194,7 +5010,12 @@ func (*HelpGetUserInfoParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpGetUserInfo(userID InputUser) (HelpUserInfo, error) {
-	responseData, err := c.MakeRequest(&HelpGetUserInfoParams{UserID: userID})
+	return c.HelpGetUserInfoContext(context.Background(), userID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpGetUserInfoContext(ctx context.Context, userID InputUser) (HelpUserInfo, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpGetUserInfoParams{UserID: userID})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending HelpGetUserInfo")
 	}
@@ -4217,7 +5038,12 @@ func (*HelpHidePromoDataParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpHidePromoData(peer InputPeer) (bool, error) {
-	responseData, err := c.MakeRequest(&HelpHidePromoDataParams{Peer: peer})
+	return c.HelpHidePromoDataContext(context.Background(), peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpHidePromoDataContext(ctx context.Context, peer InputPeer) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpHidePromoDataParams{Peer: peer})
 	if err != nil {
 		return false, errors.Wrap(err, "sending HelpHidePromoData")
 	}
@@ -4240,7 +5066,12 @@ func (*HelpSaveAppLogParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpSaveAppLog(events []*InputAppEvent) (bool, error) {
-	responseData, err := c.MakeRequest(&HelpSaveAppLogParams{Events: events})
+	return c.HelpSaveAppLogContext(context.Background(), events)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpSaveAppLogContext(ctx context.Context, events []*InputAppEvent) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpSaveAppLogParams{Events: events})
 	if err != nil {
 		return false, errors.Wrap(err, "sending HelpSaveAppLog")
 	}
@@ -4264,7 +5095,12 @@ func (*HelpSetBotUpdatesStatusParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) HelpSetBotUpdatesStatus(pendingUpdatesCount int32, message string) (bool, error) {
-	responseData, err := c.MakeRequest(&HelpSetBotUpdatesStatusParams{
+	return c.HelpSetBotUpdatesStatusContext(context.Background(), pendingUpdatesCount, message)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) HelpSetBotUpdatesStatusContext(ctx context.Context, pendingUpdatesCount int32, message string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &HelpSetBotUpdatesStatusParams{
 		Message:             message,
 		PendingUpdatesCount: pendingUpdatesCount,
 	})
@@ -4292,7 +5128,12 @@ func (*LangpackGetDifferenceParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) LangpackGetDifference(langPack, langCode string, fromVersion int32) (*LangPackDifference, error) {
-	responseData, err := c.MakeRequest(&LangpackGetDifferenceParams{
+	return c.LangpackGetDifferenceContext(context.Background(), langPack, langCode, fromVersion)
+}
+
+// Get the participants of a channel
+func (c *Client) LangpackGetDifferenceContext(ctx context.Context, langPack, langCode string, fromVersion int32) (*LangPackDifference, error) {
+	responseData, err := c.MakeRequestContext(ctx, &LangpackGetDifferenceParams{
 		FromVersion: fromVersion,
 		LangCode:    langCode,
 		LangPack:    langPack,
@@ -4320,7 +5161,12 @@ func (*LangpackGetLangPackParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) LangpackGetLangPack(langPack, langCode string) (*LangPackDifference, error) {
-	responseData, err := c.MakeRequest(&LangpackGetLangPackParams{
+	return c.LangpackGetLangPackContext(context.Background(), langPack, langCode)
+}
+
+// Get the participants of a channel
+func (c *Client) LangpackGetLangPackContext(ctx context.Context, langPack, langCode string) (*LangPackDifference, error) {
+	responseData, err := c.MakeRequestContext(ctx, &LangpackGetLangPackParams{
 		LangCode: langCode,
 		LangPack: langPack,
 	})
@@ -4347,7 +5193,12 @@ func (*LangpackGetLanguageParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) LangpackGetLanguage(langPack, langCode string) (*LangPackLanguage, error) {
-	responseData, err := c.MakeRequest(&LangpackGetLanguageParams{
+	return c.LangpackGetLanguageContext(context.Background(), langPack, langCode)
+}
+
+// Get the participants of a channel
+func (c *Client) LangpackGetLanguageContext(ctx context.Context, langPack, langCode string) (*LangPackLanguage, error) {
+	responseData, err := c.MakeRequestContext(ctx, &LangpackGetLanguageParams{
 		LangCode: langCode,
 		LangPack: langPack,
 	})
@@ -4373,7 +5224,12 @@ func (*LangpackGetLanguagesParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) LangpackGetLanguages(langPack string) ([]*LangPackLanguage, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&LangpackGetLanguagesParams{LangPack: langPack}, reflect.TypeOf([]*LangPackLanguage{}))
+	return c.LangpackGetLanguagesContext(context.Background(), langPack)
+}
+
+// Get the participants of a channel
+func (c *Client) LangpackGetLanguagesContext(ctx context.Context, langPack string) ([]*LangPackLanguage, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &LangpackGetLanguagesParams{LangPack: langPack}, reflect.TypeOf([]*LangPackLanguage{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending LangpackGetLanguages")
 	}
@@ -4398,7 +5254,12 @@ func (*LangpackGetStringsParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) LangpackGetStrings(langPack, langCode string, keys []string) ([]LangPackString, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&LangpackGetStringsParams{
+	return c.LangpackGetStringsContext(context.Background(), langPack, langCode, keys)
+}
+
+// Get the participants of a channel
+func (c *Client) LangpackGetStringsContext(ctx context.Context, langPack, langCode string, keys []string) ([]LangPackString, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &LangpackGetStringsParams{
 		Keys:     keys,
 		LangCode: langCode,
 		LangPack: langPack,
@@ -4427,7 +5288,12 @@ func (*MessagesAcceptEncryptionParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesAcceptEncryption(peer *InputEncryptedChat, gB []byte, keyFingerprint int64) (EncryptedChat, error) {
-	responseData, err := c.MakeRequest(&MessagesAcceptEncryptionParams{
+	return c.MessagesAcceptEncryptionContext(context.Background(), peer, gB, keyFingerprint)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesAcceptEncryptionContext(ctx context.Context, peer *InputEncryptedChat, gB []byte, keyFingerprint int64) (EncryptedChat, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesAcceptEncryptionParams{
 		GB:             gB,
 		KeyFingerprint: keyFingerprint,
 		Peer:           peer,
@@ -4461,7 +5327,12 @@ func (*MessagesAcceptURLAuthParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesAcceptURLAuth(writeAllowed bool, peer InputPeer, msgID, buttonID int32) (URLAuthResult, error) {
-	responseData, err := c.MakeRequest(&MessagesAcceptURLAuthParams{
+	return c.MessagesAcceptURLAuthContext(context.Background(), writeAllowed, peer, msgID, buttonID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesAcceptURLAuthContext(ctx context.Context, writeAllowed bool, peer InputPeer, msgID, buttonID int32) (URLAuthResult, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesAcceptURLAuthParams{
 		ButtonID:     buttonID,
 		MsgID:        msgID,
 		Peer:         peer,
@@ -4491,7 +5362,12 @@ func (*MessagesAddChatUserParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesAddChatUser(chatID int32, userID InputUser, fwdLimit int32) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesAddChatUserParams{
+	return c.MessagesAddChatUserContext(context.Background(), chatID, userID, fwdLimit)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesAddChatUserContext(ctx context.Context, chatID int32, userID InputUser, fwdLimit int32) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesAddChatUserParams{
 		ChatID:   chatID,
 		FwdLimit: fwdLimit,
 		UserID:   userID,
@@ -4518,7 +5394,12 @@ func (*MessagesCheckChatInviteParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesCheckChatInvite(hash string) (ChatInvite, error) {
-	responseData, err := c.MakeRequest(&MessagesCheckChatInviteParams{Hash: hash})
+	return c.MessagesCheckChatInviteContext(context.Background(), hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesCheckChatInviteContext(ctx context.Context, hash string) (ChatInvite, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesCheckChatInviteParams{Hash: hash})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesCheckChatInvite")
 	}
@@ -4539,7 +5420,12 @@ func (*MessagesClearAllDraftsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesClearAllDrafts() (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesClearAllDraftsParams{})
+	return c.MessagesClearAllDraftsContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesClearAllDraftsContext(ctx context.Context) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesClearAllDraftsParams{})
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesClearAllDrafts")
 	}
@@ -4566,7 +5452,12 @@ func (*MessagesClearRecentStickersParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesClearRecentStickers(attached bool) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesClearRecentStickersParams{Attached: attached})
+	return c.MessagesClearRecentStickersContext(context.Background(), attached)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesClearRecentStickersContext(ctx context.Context, attached bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesClearRecentStickersParams{Attached: attached})
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesClearRecentStickers")
 	}
@@ -4590,7 +5481,12 @@ func (*MessagesCreateChatParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesCreateChat(users []InputUser, title string) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesCreateChatParams{
+	return c.MessagesCreateChatContext(context.Background(), users, title)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesCreateChatContext(ctx context.Context, users []InputUser, title string) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesCreateChatParams{
 		Title: title,
 		Users: users,
 	})
@@ -4617,7 +5513,12 @@ func (*MessagesDeleteChatUserParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesDeleteChatUser(chatID int32, userID InputUser) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesDeleteChatUserParams{
+	return c.MessagesDeleteChatUserContext(context.Background(), chatID, userID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesDeleteChatUserContext(ctx context.Context, chatID int32, userID InputUser) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesDeleteChatUserParams{
 		ChatID: chatID,
 		UserID: userID,
 	})
@@ -4650,7 +5551,12 @@ func (*MessagesDeleteHistoryParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesDeleteHistory(justClear, revoke bool, peer InputPeer, maxID int32) (*MessagesAffectedHistory, error) {
-	responseData, err := c.MakeRequest(&MessagesDeleteHistoryParams{
+	return c.MessagesDeleteHistoryContext(context.Background(), justClear, revoke, peer, maxID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesDeleteHistoryContext(ctx context.Context, justClear, revoke bool, peer InputPeer, maxID int32) (*MessagesAffectedHistory, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesDeleteHistoryParams{
 		JustClear: justClear,
 		MaxID:     maxID,
 		Peer:      peer,
@@ -4683,7 +5589,12 @@ func (*MessagesDeleteMessagesParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesDeleteMessages(revoke bool, id []int32) (*MessagesAffectedMessages, error) {
-	responseData, err := c.MakeRequest(&MessagesDeleteMessagesParams{
+	return c.MessagesDeleteMessagesContext(context.Background(), revoke, id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesDeleteMessagesContext(ctx context.Context, revoke bool, id []int32) (*MessagesAffectedMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesDeleteMessagesParams{
 		ID:     id,
 		Revoke: revoke,
 	})
@@ -4710,7 +5621,12 @@ func (*MessagesDeleteScheduledMessagesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesDeleteScheduledMessages(peer InputPeer, id []int32) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesDeleteScheduledMessagesParams{
+	return c.MessagesDeleteScheduledMessagesContext(context.Background(), peer, id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesDeleteScheduledMessagesContext(ctx context.Context, peer InputPeer, id []int32) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesDeleteScheduledMessagesParams{
 		ID:   id,
 		Peer: peer,
 	})
@@ -4736,7 +5652,12 @@ func (*MessagesDiscardEncryptionParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesDiscardEncryption(chatID int32) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesDiscardEncryptionParams{ChatID: chatID})
+	return c.MessagesDiscardEncryptionContext(context.Background(), chatID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesDiscardEncryptionContext(ctx context.Context, chatID int32) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesDiscardEncryptionParams{ChatID: chatID})
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesDiscardEncryption")
 	}
@@ -4760,7 +5681,12 @@ func (*MessagesEditChatAboutParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesEditChatAbout(peer InputPeer, about string) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesEditChatAboutParams{
+	return c.MessagesEditChatAboutContext(context.Background(), peer, about)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesEditChatAboutContext(ctx context.Context, peer InputPeer, about string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesEditChatAboutParams{
 		About: about,
 		Peer:  peer,
 	})
@@ -4788,7 +5714,12 @@ func (*MessagesEditChatAdminParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesEditChatAdmin(chatID int32, userID InputUser, isAdmin bool) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesEditChatAdminParams{
+	return c.MessagesEditChatAdminContext(context.Background(), chatID, userID, isAdmin)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesEditChatAdminContext(ctx context.Context, chatID int32, userID InputUser, isAdmin bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesEditChatAdminParams{
 		ChatID:  chatID,
 		IsAdmin: isAdmin,
 		UserID:  userID,
@@ -4816,7 +5747,12 @@ func (*MessagesEditChatDefaultBannedRightsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesEditChatDefaultBannedRights(peer InputPeer, bannedRights *ChatBannedRights) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesEditChatDefaultBannedRightsParams{
+	return c.MessagesEditChatDefaultBannedRightsContext(context.Background(), peer, bannedRights)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesEditChatDefaultBannedRightsContext(ctx context.Context, peer InputPeer, bannedRights *ChatBannedRights) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesEditChatDefaultBannedRightsParams{
 		BannedRights: bannedRights,
 		Peer:         peer,
 	})
@@ -4843,7 +5779,12 @@ func (*MessagesEditChatPhotoParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesEditChatPhoto(chatID int32, photo InputChatPhoto) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesEditChatPhotoParams{
+	return c.MessagesEditChatPhotoContext(context.Background(), chatID, photo)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesEditChatPhotoContext(ctx context.Context, chatID int32, photo InputChatPhoto) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesEditChatPhotoParams{
 		ChatID: chatID,
 		Photo:  photo,
 	})
@@ -4870,7 +5811,12 @@ func (*MessagesEditChatTitleParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesEditChatTitle(chatID int32, title string) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesEditChatTitleParams{
+	return c.MessagesEditChatTitleContext(context.Background(), chatID, title)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesEditChatTitleContext(ctx context.Context, chatID int32, title string) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesEditChatTitleParams{
 		ChatID: chatID,
 		Title:  title,
 	})
@@ -4905,7 +5851,12 @@ func (*MessagesEditInlineBotMessageParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesEditInlineBotMessage(params *MessagesEditInlineBotMessageParams) (bool, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesEditInlineBotMessageContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesEditInlineBotMessageContext(ctx context.Context, params *MessagesEditInlineBotMessageParams) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesEditInlineBotMessage")
 	}
@@ -4939,7 +5890,12 @@ func (*MessagesEditMessageParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesEditMessage(params *MessagesEditMessageParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesEditMessageContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesEditMessageContext(ctx context.Context, params *MessagesEditMessageParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesEditMessage")
 	}
@@ -4962,7 +5918,12 @@ func (*MessagesExportChatInviteParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesExportChatInvite(peer InputPeer) (ExportedChatInvite, error) {
-	responseData, err := c.MakeRequest(&MessagesExportChatInviteParams{Peer: peer})
+	return c.MessagesExportChatInviteContext(context.Background(), peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesExportChatInviteContext(ctx context.Context, peer InputPeer) (ExportedChatInvite, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesExportChatInviteParams{Peer: peer})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesExportChatInvite")
 	}
@@ -4986,7 +5947,12 @@ func (*MessagesFaveStickerParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesFaveSticker(id InputDocument, unfave bool) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesFaveStickerParams{
+	return c.MessagesFaveStickerContext(context.Background(), id, unfave)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesFaveStickerContext(ctx context.Context, id InputDocument, unfave bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesFaveStickerParams{
 		ID:     id,
 		Unfave: unfave,
 	})
@@ -5023,7 +5989,12 @@ func (*MessagesForwardMessagesParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesForwardMessages(params *MessagesForwardMessagesParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesForwardMessagesContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesForwardMessagesContext(ctx context.Context, params *MessagesForwardMessagesParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesForwardMessages")
 	}
@@ -5046,7 +6017,12 @@ func (*MessagesGetAllChatsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetAllChats(exceptIds []int32) (MessagesChats, error) {
-	responseData, err := c.MakeRequest(&MessagesGetAllChatsParams{ExceptIds: exceptIds})
+	return c.MessagesGetAllChatsContext(context.Background(), exceptIds)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetAllChatsContext(ctx context.Context, exceptIds []int32) (MessagesChats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetAllChatsParams{ExceptIds: exceptIds})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetAllChats")
 	}
@@ -5067,7 +6043,12 @@ func (*MessagesGetAllDraftsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetAllDrafts() (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesGetAllDraftsParams{})
+	return c.MessagesGetAllDraftsContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetAllDraftsContext(ctx context.Context) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetAllDraftsParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetAllDrafts")
 	}
@@ -5090,7 +6071,12 @@ func (*MessagesGetAllStickersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetAllStickers(hash int32) (MessagesAllStickers, error) {
-	responseData, err := c.MakeRequest(&MessagesGetAllStickersParams{Hash: hash})
+	return c.MessagesGetAllStickersContext(context.Background(), hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetAllStickersContext(ctx context.Context, hash int32) (MessagesAllStickers, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetAllStickersParams{Hash: hash})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetAllStickers")
 	}
@@ -5119,7 +6105,12 @@ func (*MessagesGetArchivedStickersParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetArchivedStickers(masks bool, offsetID int64, limit int32) (*MessagesArchivedStickers, error) {
-	responseData, err := c.MakeRequest(&MessagesGetArchivedStickersParams{
+	return c.MessagesGetArchivedStickersContext(context.Background(), masks, offsetID, limit)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetArchivedStickersContext(ctx context.Context, masks bool, offsetID int64, limit int32) (*MessagesArchivedStickers, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetArchivedStickersParams{
 		Limit:    limit,
 		Masks:    masks,
 		OffsetID: offsetID,
@@ -5146,7 +6137,12 @@ func (*MessagesGetAttachedStickersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetAttachedStickers(media InputStickeredMedia) ([]StickerSetCovered, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&MessagesGetAttachedStickersParams{Media: media}, reflect.TypeOf([]StickerSetCovered{}))
+	return c.MessagesGetAttachedStickersContext(context.Background(), media)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetAttachedStickersContext(ctx context.Context, media InputStickeredMedia) ([]StickerSetCovered, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &MessagesGetAttachedStickersParams{Media: media}, reflect.TypeOf([]StickerSetCovered{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetAttachedStickers")
 	}
@@ -5177,7 +6173,12 @@ func (*MessagesGetBotCallbackAnswerParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetBotCallbackAnswer(params *MessagesGetBotCallbackAnswerParams) (*MessagesBotCallbackAnswer, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesGetBotCallbackAnswerContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetBotCallbackAnswerContext(ctx context.Context, params *MessagesGetBotCallbackAnswerParams) (*MessagesBotCallbackAnswer, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetBotCallbackAnswer")
 	}
@@ -5200,7 +6201,12 @@ func (*MessagesGetChatsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetChats(id []int32) (MessagesChats, error) {
-	responseData, err := c.MakeRequest(&MessagesGetChatsParams{ID: id})
+	return c.MessagesGetChatsContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetChatsContext(ctx context.Context, id []int32) (MessagesChats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetChatsParams{ID: id})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetChats")
 	}
@@ -5225,7 +6231,12 @@ func (*MessagesGetCommonChatsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetCommonChats(userID InputUser, maxID, limit int32) (MessagesChats, error) {
-	responseData, err := c.MakeRequest(&MessagesGetCommonChatsParams{
+	return c.MessagesGetCommonChatsContext(context.Background(), userID, maxID, limit)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetCommonChatsContext(ctx context.Context, userID InputUser, maxID, limit int32) (MessagesChats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetCommonChatsParams{
 		Limit:  limit,
 		MaxID:  maxID,
 		UserID: userID,
@@ -5253,7 +6264,12 @@ func (*MessagesGetDhConfigParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetDhConfig(version, randomLength int32) (MessagesDhConfig, error) {
-	responseData, err := c.MakeRequest(&MessagesGetDhConfigParams{
+	return c.MessagesGetDhConfigContext(context.Background(), version, randomLength)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetDhConfigContext(ctx context.Context, version, randomLength int32) (MessagesDhConfig, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetDhConfigParams{
 		RandomLength: randomLength,
 		Version:      version,
 	})
@@ -5277,7 +6293,12 @@ func (*MessagesGetDialogFiltersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetDialogFilters() ([]*DialogFilter, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&MessagesGetDialogFiltersParams{}, reflect.TypeOf([]*DialogFilter{}))
+	return c.MessagesGetDialogFiltersContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetDialogFiltersContext(ctx context.Context) ([]*DialogFilter, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &MessagesGetDialogFiltersParams{}, reflect.TypeOf([]*DialogFilter{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetDialogFilters")
 	}
@@ -5298,7 +6319,12 @@ func (*MessagesGetDialogUnreadMarksParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetDialogUnreadMarks() ([]DialogPeer, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&MessagesGetDialogUnreadMarksParams{}, reflect.TypeOf([]DialogPeer{}))
+	return c.MessagesGetDialogUnreadMarksContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetDialogUnreadMarksContext(ctx context.Context) ([]DialogPeer, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &MessagesGetDialogUnreadMarksParams{}, reflect.TypeOf([]DialogPeer{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetDialogUnreadMarks")
 	}
@@ -5331,7 +6357,12 @@ func (*MessagesGetDialogsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetDialogs(params *MessagesGetDialogsParams) (MessagesDialogs, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesGetDialogsContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetDialogsContext(ctx context.Context, params *MessagesGetDialogsParams) (MessagesDialogs, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetDialogs")
 	}
@@ -5355,7 +6386,12 @@ func (*MessagesGetDiscussionMessageParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetDiscussionMessage(peer InputPeer, msgID int32) (*MessagesDiscussionMessage, error) {
-	responseData, err := c.MakeRequest(&MessagesGetDiscussionMessageParams{
+	return c.MessagesGetDiscussionMessageContext(context.Background(), peer, msgID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetDiscussionMessageContext(ctx context.Context, peer InputPeer, msgID int32) (*MessagesDiscussionMessage, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetDiscussionMessageParams{
 		MsgID: msgID,
 		Peer:  peer,
 	})
@@ -5383,7 +6419,12 @@ func (*MessagesGetDocumentByHashParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetDocumentByHash(sha256 []byte, size int32, mimeType string) (Document, error) {
-	responseData, err := c.MakeRequest(&MessagesGetDocumentByHashParams{
+	return c.MessagesGetDocumentByHashContext(context.Background(), sha256, size, mimeType)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetDocumentByHashContext(ctx context.Context, sha256 []byte, size int32, mimeType string) (Document, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetDocumentByHashParams{
 		MimeType: mimeType,
 		SHA256:   sha256,
 		Size:     size,
@@ -5410,7 +6451,12 @@ func (*MessagesGetEmojiKeywordsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetEmojiKeywords(langCode string) (*EmojiKeywordsDifference, error) {
-	responseData, err := c.MakeRequest(&MessagesGetEmojiKeywordsParams{LangCode: langCode})
+	return c.MessagesGetEmojiKeywordsContext(context.Background(), langCode)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetEmojiKeywordsContext(ctx context.Context, langCode string) (*EmojiKeywordsDifference, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetEmojiKeywordsParams{LangCode: langCode})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetEmojiKeywords")
 	}
@@ -5434,7 +6480,12 @@ func (*MessagesGetEmojiKeywordsDifferenceParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetEmojiKeywordsDifference(langCode string, fromVersion int32) (*EmojiKeywordsDifference, error) {
-	responseData, err := c.MakeRequest(&MessagesGetEmojiKeywordsDifferenceParams{
+	return c.MessagesGetEmojiKeywordsDifferenceContext(context.Background(), langCode, fromVersion)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetEmojiKeywordsDifferenceContext(ctx context.Context, langCode string, fromVersion int32) (*EmojiKeywordsDifference, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetEmojiKeywordsDifferenceParams{
 		FromVersion: fromVersion,
 		LangCode:    langCode,
 	})
@@ -5460,7 +6511,12 @@ func (*MessagesGetEmojiKeywordsLanguagesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetEmojiKeywordsLanguages(langCodes []string) ([]*EmojiLanguage, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&MessagesGetEmojiKeywordsLanguagesParams{LangCodes: langCodes}, reflect.TypeOf([]*EmojiLanguage{}))
+	return c.MessagesGetEmojiKeywordsLanguagesContext(context.Background(), langCodes)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetEmojiKeywordsLanguagesContext(ctx context.Context, langCodes []string) ([]*EmojiLanguage, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &MessagesGetEmojiKeywordsLanguagesParams{LangCodes: langCodes}, reflect.TypeOf([]*EmojiLanguage{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetEmojiKeywordsLanguages")
 	}
@@ -5483,7 +6539,12 @@ func (*MessagesGetEmojiURLParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetEmojiURL(langCode string) (*EmojiURL, error) {
-	responseData, err := c.MakeRequest(&MessagesGetEmojiURLParams{LangCode: langCode})
+	return c.MessagesGetEmojiURLContext(context.Background(), langCode)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetEmojiURLContext(ctx context.Context, langCode string) (*EmojiURL, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetEmojiURLParams{LangCode: langCode})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetEmojiURL")
 	}
@@ -5506,7 +6567,12 @@ func (*MessagesGetFavedStickersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetFavedStickers(hash int32) (MessagesFavedStickers, error) {
-	responseData, err := c.MakeRequest(&MessagesGetFavedStickersParams{Hash: hash})
+	return c.MessagesGetFavedStickersContext(context.Background(), hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetFavedStickersContext(ctx context.Context, hash int32) (MessagesFavedStickers, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetFavedStickersParams{Hash: hash})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetFavedStickers")
 	}
@@ -5529,7 +6595,12 @@ func (*MessagesGetFeaturedStickersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetFeaturedStickers(hash int32) (MessagesFeaturedStickers, error) {
-	responseData, err := c.MakeRequest(&MessagesGetFeaturedStickersParams{Hash: hash})
+	return c.MessagesGetFeaturedStickersContext(context.Background(), hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetFeaturedStickersContext(ctx context.Context, hash int32) (MessagesFeaturedStickers, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetFeaturedStickersParams{Hash: hash})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetFeaturedStickers")
 	}
@@ -5552,7 +6623,12 @@ func (*MessagesGetFullChatParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetFullChat(chatID int32) (*MessagesChatFull, error) {
-	responseData, err := c.MakeRequest(&MessagesGetFullChatParams{ChatID: chatID})
+	return c.MessagesGetFullChatContext(context.Background(), chatID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetFullChatContext(ctx context.Context, chatID int32) (*MessagesChatFull, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetFullChatParams{ChatID: chatID})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetFullChat")
 	}
@@ -5577,7 +6653,12 @@ func (*MessagesGetGameHighScoresParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetGameHighScores(peer InputPeer, id int32, userID InputUser) (*MessagesHighScores, error) {
-	responseData, err := c.MakeRequest(&MessagesGetGameHighScoresParams{
+	return c.MessagesGetGameHighScoresContext(context.Background(), peer, id, userID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetGameHighScoresContext(ctx context.Context, peer InputPeer, id int32, userID InputUser) (*MessagesHighScores, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetGameHighScoresParams{
 		ID:     id,
 		Peer:   peer,
 		UserID: userID,
@@ -5611,7 +6692,12 @@ func (*MessagesGetHistoryParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetHistory(params *MessagesGetHistoryParams) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesGetHistoryContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetHistoryContext(ctx context.Context, params *MessagesGetHistoryParams) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetHistory")
 	}
@@ -5642,7 +6728,12 @@ func (*MessagesGetInlineBotResultsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetInlineBotResults(params *MessagesGetInlineBotResultsParams) (*MessagesBotResults, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesGetInlineBotResultsContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetInlineBotResultsContext(ctx context.Context, params *MessagesGetInlineBotResultsParams) (*MessagesBotResults, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetInlineBotResults")
 	}
@@ -5666,7 +6757,12 @@ func (*MessagesGetInlineGameHighScoresParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetInlineGameHighScores(id *InputBotInlineMessageID, userID InputUser) (*MessagesHighScores, error) {
-	responseData, err := c.MakeRequest(&MessagesGetInlineGameHighScoresParams{
+	return c.MessagesGetInlineGameHighScoresContext(context.Background(), id, userID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetInlineGameHighScoresContext(ctx context.Context, id *InputBotInlineMessageID, userID InputUser) (*MessagesHighScores, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetInlineGameHighScoresParams{
 		ID:     id,
 		UserID: userID,
 	})
@@ -5692,7 +6788,12 @@ func (*MessagesGetMaskStickersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetMaskStickers(hash int32) (MessagesAllStickers, error) {
-	responseData, err := c.MakeRequest(&MessagesGetMaskStickersParams{Hash: hash})
+	return c.MessagesGetMaskStickersContext(context.Background(), hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetMaskStickersContext(ctx context.Context, hash int32) (MessagesAllStickers, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetMaskStickersParams{Hash: hash})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetMaskStickers")
 	}
@@ -5716,7 +6817,12 @@ func (*MessagesGetMessageEditDataParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetMessageEditData(peer InputPeer, id int32) (*MessagesMessageEditData, error) {
-	responseData, err := c.MakeRequest(&MessagesGetMessageEditDataParams{
+	return c.MessagesGetMessageEditDataContext(context.Background(), peer, id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetMessageEditDataContext(ctx context.Context, peer InputPeer, id int32) (*MessagesMessageEditData, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetMessageEditDataParams{
 		ID:   id,
 		Peer: peer,
 	})
@@ -5742,7 +6848,12 @@ func (*MessagesGetMessagesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetMessages(id []InputMessage) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(&MessagesGetMessagesParams{ID: id})
+	return c.MessagesGetMessagesContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetMessagesContext(ctx context.Context, id []InputMessage) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetMessagesParams{ID: id})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetMessages")
 	}
@@ -5767,7 +6878,12 @@ func (*MessagesGetMessagesViewsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetMessagesViews(peer InputPeer, id []int32, increment bool) (*MessagesMessageViews, error) {
-	responseData, err := c.MakeRequest(&MessagesGetMessagesViewsParams{
+	return c.MessagesGetMessagesViewsContext(context.Background(), peer, id, increment)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetMessagesViewsContext(ctx context.Context, peer InputPeer, id []int32, increment bool) (*MessagesMessageViews, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetMessagesViewsParams{
 		ID:        id,
 		Increment: increment,
 		Peer:      peer,
@@ -5796,7 +6912,12 @@ func (*MessagesGetOldFeaturedStickersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetOldFeaturedStickers(offset, limit, hash int32) (MessagesFeaturedStickers, error) {
-	responseData, err := c.MakeRequest(&MessagesGetOldFeaturedStickersParams{
+	return c.MessagesGetOldFeaturedStickersContext(context.Background(), offset, limit, hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetOldFeaturedStickersContext(ctx context.Context, offset, limit, hash int32) (MessagesFeaturedStickers, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetOldFeaturedStickersParams{
 		Hash:   hash,
 		Limit:  limit,
 		Offset: offset,
@@ -5823,7 +6944,12 @@ func (*MessagesGetOnlinesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetOnlines(peer InputPeer) (*ChatOnlines, error) {
-	responseData, err := c.MakeRequest(&MessagesGetOnlinesParams{Peer: peer})
+	return c.MessagesGetOnlinesContext(context.Background(), peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetOnlinesContext(ctx context.Context, peer InputPeer) (*ChatOnlines, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetOnlinesParams{Peer: peer})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetOnlines")
 	}
@@ -5846,7 +6972,12 @@ func (*MessagesGetPeerDialogsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetPeerDialogs(peers []InputDialogPeer) (*MessagesPeerDialogs, error) {
-	responseData, err := c.MakeRequest(&MessagesGetPeerDialogsParams{Peers: peers})
+	return c.MessagesGetPeerDialogsContext(context.Background(), peers)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetPeerDialogsContext(ctx context.Context, peers []InputDialogPeer) (*MessagesPeerDialogs, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetPeerDialogsParams{Peers: peers})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetPeerDialogs")
 	}
@@ -5869,7 +7000,12 @@ func (*MessagesGetPeerSettingsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetPeerSettings(peer InputPeer) (*PeerSettings, error) {
-	responseData, err := c.MakeRequest(&MessagesGetPeerSettingsParams{Peer: peer})
+	return c.MessagesGetPeerSettingsContext(context.Background(), peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetPeerSettingsContext(ctx context.Context, peer InputPeer) (*PeerSettings, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetPeerSettingsParams{Peer: peer})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetPeerSettings")
 	}
@@ -5892,7 +7028,12 @@ func (*MessagesGetPinnedDialogsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetPinnedDialogs(folderID int32) (*MessagesPeerDialogs, error) {
-	responseData, err := c.MakeRequest(&MessagesGetPinnedDialogsParams{FolderID: folderID})
+	return c.MessagesGetPinnedDialogsContext(context.Background(), folderID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetPinnedDialogsContext(ctx context.Context, folderID int32) (*MessagesPeerDialogs, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetPinnedDialogsParams{FolderID: folderID})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetPinnedDialogs")
 	}
@@ -5916,7 +7057,12 @@ func (*MessagesGetPollResultsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetPollResults(peer InputPeer, msgID int32) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesGetPollResultsParams{
+	return c.MessagesGetPollResultsContext(context.Background(), peer, msgID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetPollResultsContext(ctx context.Context, peer InputPeer, msgID int32) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetPollResultsParams{
 		MsgID: msgID,
 		Peer:  peer,
 	})
@@ -5950,7 +7096,12 @@ func (*MessagesGetPollVotesParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetPollVotes(params *MessagesGetPollVotesParams) (*MessagesVotesList, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesGetPollVotesContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetPollVotesContext(ctx context.Context, params *MessagesGetPollVotesParams) (*MessagesVotesList, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetPollVotes")
 	}
@@ -5975,7 +7126,12 @@ func (*MessagesGetRecentLocationsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetRecentLocations(peer InputPeer, limit, hash int32) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(&MessagesGetRecentLocationsParams{
+	return c.MessagesGetRecentLocationsContext(context.Background(), peer, limit, hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetRecentLocationsContext(ctx context.Context, peer InputPeer, limit, hash int32) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetRecentLocationsParams{
 		Hash:  hash,
 		Limit: limit,
 		Peer:  peer,
@@ -6007,7 +7163,12 @@ func (*MessagesGetRecentStickersParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetRecentStickers(attached bool, hash int32) (MessagesRecentStickers, error) {
-	responseData, err := c.MakeRequest(&MessagesGetRecentStickersParams{
+	return c.MessagesGetRecentStickersContext(context.Background(), attached, hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetRecentStickersContext(ctx context.Context, attached bool, hash int32) (MessagesRecentStickers, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetRecentStickersParams{
 		Attached: attached,
 		Hash:     hash,
 	})
@@ -6041,7 +7202,12 @@ func (*MessagesGetRepliesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetReplies(params *MessagesGetRepliesParams) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesGetRepliesContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetRepliesContext(ctx context.Context, params *MessagesGetRepliesParams) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetReplies")
 	}
@@ -6064,7 +7230,12 @@ func (*MessagesGetSavedGifsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetSavedGifs(hash int32) (MessagesSavedGifs, error) {
-	responseData, err := c.MakeRequest(&MessagesGetSavedGifsParams{Hash: hash})
+	return c.MessagesGetSavedGifsContext(context.Background(), hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetSavedGifsContext(ctx context.Context, hash int32) (MessagesSavedGifs, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetSavedGifsParams{Hash: hash})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetSavedGifs")
 	}
@@ -6088,7 +7259,12 @@ func (*MessagesGetScheduledHistoryParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetScheduledHistory(peer InputPeer, hash int32) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(&MessagesGetScheduledHistoryParams{
+	return c.MessagesGetScheduledHistoryContext(context.Background(), peer, hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetScheduledHistoryContext(ctx context.Context, peer InputPeer, hash int32) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetScheduledHistoryParams{
 		Hash: hash,
 		Peer: peer,
 	})
@@ -6115,7 +7291,12 @@ func (*MessagesGetScheduledMessagesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetScheduledMessages(peer InputPeer, id []int32) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(&MessagesGetScheduledMessagesParams{
+	return c.MessagesGetScheduledMessagesContext(context.Background(), peer, id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetScheduledMessagesContext(ctx context.Context, peer InputPeer, id []int32) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetScheduledMessagesParams{
 		ID:   id,
 		Peer: peer,
 	})
@@ -6142,7 +7323,12 @@ func (*MessagesGetSearchCountersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetSearchCounters(peer InputPeer, filters []MessagesFilter) ([]*MessagesSearchCounter, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&MessagesGetSearchCountersParams{
+	return c.MessagesGetSearchCountersContext(context.Background(), peer, filters)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetSearchCountersContext(ctx context.Context, peer InputPeer, filters []MessagesFilter) ([]*MessagesSearchCounter, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &MessagesGetSearchCountersParams{
 		Filters: filters,
 		Peer:    peer,
 	}, reflect.TypeOf([]*MessagesSearchCounter{}))
@@ -6166,7 +7352,12 @@ func (*MessagesGetSplitRangesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetSplitRanges() ([]*MessageRange, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&MessagesGetSplitRangesParams{}, reflect.TypeOf([]*MessageRange{}))
+	return c.MessagesGetSplitRangesContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetSplitRangesContext(ctx context.Context) ([]*MessageRange, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &MessagesGetSplitRangesParams{}, reflect.TypeOf([]*MessageRange{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetSplitRanges")
 	}
@@ -6195,7 +7386,12 @@ func (*MessagesGetStatsURLParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetStatsURL(dark bool, peer InputPeer, params string) (*StatsURL, error) {
-	responseData, err := c.MakeRequest(&MessagesGetStatsURLParams{
+	return c.MessagesGetStatsURLContext(context.Background(), dark, peer, params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetStatsURLContext(ctx context.Context, dark bool, peer InputPeer, params string) (*StatsURL, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetStatsURLParams{
 		Dark:   dark,
 		Params: params,
 		Peer:   peer,
@@ -6222,7 +7418,12 @@ func (*MessagesGetStickerSetParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetStickerSet(stickerset InputStickerSet) (*MessagesStickerSet, error) {
-	responseData, err := c.MakeRequest(&MessagesGetStickerSetParams{Stickerset: stickerset})
+	return c.MessagesGetStickerSetContext(context.Background(), stickerset)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetStickerSetContext(ctx context.Context, stickerset InputStickerSet) (*MessagesStickerSet, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetStickerSetParams{Stickerset: stickerset})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetStickerSet")
 	}
@@ -6246,7 +7447,12 @@ func (*MessagesGetStickersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetStickers(emoticon string, hash int32) (MessagesStickers, error) {
-	responseData, err := c.MakeRequest(&MessagesGetStickersParams{
+	return c.MessagesGetStickersContext(context.Background(), emoticon, hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetStickersContext(ctx context.Context, emoticon string, hash int32) (MessagesStickers, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetStickersParams{
 		Emoticon: emoticon,
 		Hash:     hash,
 	})
@@ -6270,7 +7476,12 @@ func (*MessagesGetSuggestedDialogFiltersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetSuggestedDialogFilters() ([]*DialogFilterSuggested, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&MessagesGetSuggestedDialogFiltersParams{}, reflect.TypeOf([]*DialogFilterSuggested{}))
+	return c.MessagesGetSuggestedDialogFiltersContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetSuggestedDialogFiltersContext(ctx context.Context) ([]*DialogFilterSuggested, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &MessagesGetSuggestedDialogFiltersParams{}, reflect.TypeOf([]*DialogFilterSuggested{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetSuggestedDialogFilters")
 	}
@@ -6298,7 +7509,12 @@ func (*MessagesGetUnreadMentionsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetUnreadMentions(params *MessagesGetUnreadMentionsParams) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesGetUnreadMentionsContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetUnreadMentionsContext(ctx context.Context, params *MessagesGetUnreadMentionsParams) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesGetUnreadMentions")
 	}
@@ -6322,7 +7538,12 @@ func (*MessagesGetWebPageParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetWebPage(url string, hash int32) (WebPage, error) {
-	responseData, err := c.MakeRequest(&MessagesGetWebPageParams{
+	return c.MessagesGetWebPageContext(context.Background(), url, hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetWebPageContext(ctx context.Context, url string, hash int32) (WebPage, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetWebPageParams{
 		Hash: hash,
 		URL:  url,
 	})
@@ -6353,7 +7574,12 @@ func (*MessagesGetWebPagePreviewParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesGetWebPagePreview(message string, entities []MessageEntity) (MessageMedia, error) {
-	responseData, err := c.MakeRequest(&MessagesGetWebPagePreviewParams{
+	return c.MessagesGetWebPagePreviewContext(context.Background(), message, entities)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesGetWebPagePreviewContext(ctx context.Context, message string, entities []MessageEntity) (MessageMedia, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesGetWebPagePreviewParams{
 		Entities: entities,
 		Message:  message,
 	})
@@ -6379,7 +7605,12 @@ func (*MessagesHidePeerSettingsBarParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesHidePeerSettingsBar(peer InputPeer) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesHidePeerSettingsBarParams{Peer: peer})
+	return c.MessagesHidePeerSettingsBarContext(context.Background(), peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesHidePeerSettingsBarContext(ctx context.Context, peer InputPeer) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesHidePeerSettingsBarParams{Peer: peer})
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesHidePeerSettingsBar")
 	}
@@ -6402,7 +7633,12 @@ func (*MessagesImportChatInviteParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesImportChatInvite(hash string) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesImportChatInviteParams{Hash: hash})
+	return c.MessagesImportChatInviteContext(context.Background(), hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesImportChatInviteContext(ctx context.Context, hash string) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesImportChatInviteParams{Hash: hash})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesImportChatInvite")
 	}
@@ -6426,7 +7662,12 @@ func (*MessagesInstallStickerSetParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesInstallStickerSet(stickerset InputStickerSet, archived bool) (MessagesStickerSetInstallResult, error) {
-	responseData, err := c.MakeRequest(&MessagesInstallStickerSetParams{
+	return c.MessagesInstallStickerSetContext(context.Background(), stickerset, archived)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesInstallStickerSetContext(ctx context.Context, stickerset InputStickerSet, archived bool) (MessagesStickerSetInstallResult, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesInstallStickerSetParams{
 		Archived:   archived,
 		Stickerset: stickerset,
 	})
@@ -6457,7 +7698,12 @@ func (*MessagesMarkDialogUnreadParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesMarkDialogUnread(unread bool, peer InputDialogPeer) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesMarkDialogUnreadParams{
+	return c.MessagesMarkDialogUnreadContext(context.Background(), unread, peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesMarkDialogUnreadContext(ctx context.Context, unread bool, peer InputDialogPeer) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesMarkDialogUnreadParams{
 		Peer:   peer,
 		Unread: unread,
 	})
@@ -6483,7 +7729,12 @@ func (*MessagesMigrateChatParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesMigrateChat(chatID int32) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesMigrateChatParams{ChatID: chatID})
+	return c.MessagesMigrateChatContext(context.Background(), chatID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesMigrateChatContext(ctx context.Context, chatID int32) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesMigrateChatParams{ChatID: chatID})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesMigrateChat")
 	}
@@ -6508,7 +7759,12 @@ func (*MessagesReadDiscussionParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReadDiscussion(peer InputPeer, msgID, readMaxID int32) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesReadDiscussionParams{
+	return c.MessagesReadDiscussionContext(context.Background(), peer, msgID, readMaxID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReadDiscussionContext(ctx context.Context, peer InputPeer, msgID, readMaxID int32) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReadDiscussionParams{
 		MsgID:     msgID,
 		Peer:      peer,
 		ReadMaxID: readMaxID,
@@ -6536,7 +7792,12 @@ func (*MessagesReadEncryptedHistoryParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReadEncryptedHistory(peer *InputEncryptedChat, maxDate int32) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesReadEncryptedHistoryParams{
+	return c.MessagesReadEncryptedHistoryContext(context.Background(), peer, maxDate)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReadEncryptedHistoryContext(ctx context.Context, peer *InputEncryptedChat, maxDate int32) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReadEncryptedHistoryParams{
 		MaxDate: maxDate,
 		Peer:    peer,
 	})
@@ -6562,7 +7823,12 @@ func (*MessagesReadFeaturedStickersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReadFeaturedStickers(id []int64) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesReadFeaturedStickersParams{ID: id})
+	return c.MessagesReadFeaturedStickersContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReadFeaturedStickersContext(ctx context.Context, id []int64) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReadFeaturedStickersParams{ID: id})
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesReadFeaturedStickers")
 	}
@@ -6586,7 +7852,12 @@ func (*MessagesReadHistoryParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReadHistory(peer InputPeer, maxID int32) (*MessagesAffectedMessages, error) {
-	responseData, err := c.MakeRequest(&MessagesReadHistoryParams{
+	return c.MessagesReadHistoryContext(context.Background(), peer, maxID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReadHistoryContext(ctx context.Context, peer InputPeer, maxID int32) (*MessagesAffectedMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReadHistoryParams{
 		MaxID: maxID,
 		Peer:  peer,
 	})
@@ -6612,7 +7883,12 @@ func (*MessagesReadMentionsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReadMentions(peer InputPeer) (*MessagesAffectedHistory, error) {
-	responseData, err := c.MakeRequest(&MessagesReadMentionsParams{Peer: peer})
+	return c.MessagesReadMentionsContext(context.Background(), peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReadMentionsContext(ctx context.Context, peer InputPeer) (*MessagesAffectedHistory, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReadMentionsParams{Peer: peer})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesReadMentions")
 	}
@@ -6635,7 +7911,12 @@ func (*MessagesReadMessageContentsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReadMessageContents(id []int32) (*MessagesAffectedMessages, error) {
-	responseData, err := c.MakeRequest(&MessagesReadMessageContentsParams{ID: id})
+	return c.MessagesReadMessageContentsContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReadMessageContentsContext(ctx context.Context, id []int32) (*MessagesAffectedMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReadMessageContentsParams{ID: id})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesReadMessageContents")
 	}
@@ -6658,7 +7939,12 @@ func (*MessagesReceivedMessagesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReceivedMessages(maxID int32) ([]*ReceivedNotifyMessage, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&MessagesReceivedMessagesParams{MaxID: maxID}, reflect.TypeOf([]*ReceivedNotifyMessage{}))
+	return c.MessagesReceivedMessagesContext(context.Background(), maxID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReceivedMessagesContext(ctx context.Context, maxID int32) ([]*ReceivedNotifyMessage, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &MessagesReceivedMessagesParams{MaxID: maxID}, reflect.TypeOf([]*ReceivedNotifyMessage{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesReceivedMessages")
 	}
@@ -6680,8 +7966,13 @@ func (*MessagesReceivedQueueParams) CRC() uint32 {
 }
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
-func (c *Client) MessagesReceivedQueue(maxQts int32) ([]int64, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&MessagesReceivedQueueParams{MaxQts: maxQts}, reflect.TypeOf([]int64{}))
+func (c *Client) MessagesReceivedQueue(maxQts int32) ([]int64, error) {
+	return c.MessagesReceivedQueueContext(context.Background(), maxQts)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReceivedQueueContext(ctx context.Context, maxQts int32) ([]int64, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &MessagesReceivedQueueParams{MaxQts: maxQts}, reflect.TypeOf([]int64{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesReceivedQueue")
 	}
@@ -6710,7 +8001,12 @@ func (*MessagesReorderPinnedDialogsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReorderPinnedDialogs(force bool, folderID int32, order []InputDialogPeer) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesReorderPinnedDialogsParams{
+	return c.MessagesReorderPinnedDialogsContext(context.Background(), force, folderID, order)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReorderPinnedDialogsContext(ctx context.Context, force bool, folderID int32, order []InputDialogPeer) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReorderPinnedDialogsParams{
 		FolderID: folderID,
 		Force:    force,
 		Order:    order,
@@ -6742,7 +8038,12 @@ func (*MessagesReorderStickerSetsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReorderStickerSets(masks bool, order []int64) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesReorderStickerSetsParams{
+	return c.MessagesReorderStickerSetsContext(context.Background(), masks, order)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReorderStickerSetsContext(ctx context.Context, masks bool, order []int64) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReorderStickerSetsParams{
 		Masks: masks,
 		Order: order,
 	})
@@ -6770,7 +8071,12 @@ func (*MessagesReportParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReport(peer InputPeer, id []int32, reason ReportReason) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesReportParams{
+	return c.MessagesReportContext(context.Background(), peer, id, reason)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReportContext(ctx context.Context, peer InputPeer, id []int32, reason ReportReason) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReportParams{
 		ID:     id,
 		Peer:   peer,
 		Reason: reason,
@@ -6797,7 +8103,12 @@ func (*MessagesReportEncryptedSpamParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReportEncryptedSpam(peer *InputEncryptedChat) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesReportEncryptedSpamParams{Peer: peer})
+	return c.MessagesReportEncryptedSpamContext(context.Background(), peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReportEncryptedSpamContext(ctx context.Context, peer *InputEncryptedChat) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReportEncryptedSpamParams{Peer: peer})
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesReportEncryptedSpam")
 	}
@@ -6820,7 +8131,12 @@ func (*MessagesReportSpamParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesReportSpam(peer InputPeer) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesReportSpamParams{Peer: peer})
+	return c.MessagesReportSpamContext(context.Background(), peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesReportSpamContext(ctx context.Context, peer InputPeer) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesReportSpamParams{Peer: peer})
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesReportSpam")
 	}
@@ -6845,7 +8161,12 @@ func (*MessagesRequestEncryptionParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesRequestEncryption(userID InputUser, randomID int32, gA []byte) (EncryptedChat, error) {
-	responseData, err := c.MakeRequest(&MessagesRequestEncryptionParams{
+	return c.MessagesRequestEncryptionContext(context.Background(), userID, randomID, gA)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesRequestEncryptionContext(ctx context.Context, userID InputUser, randomID int32, gA []byte) (EncryptedChat, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesRequestEncryptionParams{
 		GA:       gA,
 		RandomID: randomID,
 		UserID:   userID,
@@ -6874,7 +8195,12 @@ func (*MessagesRequestURLAuthParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesRequestURLAuth(peer InputPeer, msgID, buttonID int32) (URLAuthResult, error) {
-	responseData, err := c.MakeRequest(&MessagesRequestURLAuthParams{
+	return c.MessagesRequestURLAuthContext(context.Background(), peer, msgID, buttonID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesRequestURLAuthContext(ctx context.Context, peer InputPeer, msgID, buttonID int32) (URLAuthResult, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesRequestURLAuthParams{
 		ButtonID: buttonID,
 		MsgID:    msgID,
 		Peer:     peer,
@@ -6909,7 +8235,12 @@ func (*MessagesSaveDraftParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSaveDraft(params *MessagesSaveDraftParams) (bool, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSaveDraftContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSaveDraftContext(ctx context.Context, params *MessagesSaveDraftParams) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesSaveDraft")
 	}
@@ -6933,7 +8264,12 @@ func (*MessagesSaveGifParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSaveGif(id InputDocument, unsave bool) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesSaveGifParams{
+	return c.MessagesSaveGifContext(context.Background(), id, unsave)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSaveGifContext(ctx context.Context, id InputDocument, unsave bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSaveGifParams{
 		ID:     id,
 		Unsave: unsave,
 	})
@@ -6965,7 +8301,12 @@ func (*MessagesSaveRecentStickerParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSaveRecentSticker(attached bool, id InputDocument, unsave bool) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesSaveRecentStickerParams{
+	return c.MessagesSaveRecentStickerContext(context.Background(), attached, id, unsave)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSaveRecentStickerContext(ctx context.Context, attached bool, id InputDocument, unsave bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSaveRecentStickerParams{
 		Attached: attached,
 		ID:       id,
 		Unsave:   unsave,
@@ -7008,7 +8349,12 @@ func (*MessagesSearchParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSearch(params *MessagesSearchParams) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSearchContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSearchContext(ctx context.Context, params *MessagesSearchParams) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesSearch")
 	}
@@ -7043,7 +8389,12 @@ func (*MessagesSearchGlobalParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSearchGlobal(params *MessagesSearchGlobalParams) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSearchGlobalContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSearchGlobalContext(ctx context.Context, params *MessagesSearchGlobalParams) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesSearchGlobal")
 	}
@@ -7072,7 +8423,12 @@ func (*MessagesSearchStickerSetsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSearchStickerSets(excludeFeatured bool, q string, hash int32) (MessagesFoundStickerSets, error) {
-	responseData, err := c.MakeRequest(&MessagesSearchStickerSetsParams{
+	return c.MessagesSearchStickerSetsContext(context.Background(), excludeFeatured, q, hash)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSearchStickerSetsContext(ctx context.Context, excludeFeatured bool, q string, hash int32) (MessagesFoundStickerSets, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSearchStickerSetsParams{
 		ExcludeFeatured: excludeFeatured,
 		Hash:            hash,
 		Q:               q,
@@ -7106,7 +8462,12 @@ func (*MessagesSendEncryptedParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSendEncrypted(silent bool, peer *InputEncryptedChat, randomID int64, data []byte) (MessagesSentEncryptedMessage, error) {
-	responseData, err := c.MakeRequest(&MessagesSendEncryptedParams{
+	return c.MessagesSendEncryptedContext(context.Background(), silent, peer, randomID, data)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSendEncryptedContext(ctx context.Context, silent bool, peer *InputEncryptedChat, randomID int64, data []byte) (MessagesSentEncryptedMessage, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSendEncryptedParams{
 		Data:     data,
 		Peer:     peer,
 		RandomID: randomID,
@@ -7142,7 +8503,12 @@ func (*MessagesSendEncryptedFileParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSendEncryptedFile(params *MessagesSendEncryptedFileParams) (MessagesSentEncryptedMessage, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSendEncryptedFileContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSendEncryptedFileContext(ctx context.Context, params *MessagesSendEncryptedFileParams) (MessagesSentEncryptedMessage, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesSendEncryptedFile")
 	}
@@ -7167,7 +8533,12 @@ func (*MessagesSendEncryptedServiceParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSendEncryptedService(peer *InputEncryptedChat, randomID int64, data []byte) (MessagesSentEncryptedMessage, error) {
-	responseData, err := c.MakeRequest(&MessagesSendEncryptedServiceParams{
+	return c.MessagesSendEncryptedServiceContext(context.Background(), peer, randomID, data)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSendEncryptedServiceContext(ctx context.Context, peer *InputEncryptedChat, randomID int64, data []byte) (MessagesSentEncryptedMessage, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSendEncryptedServiceParams{
 		Data:     data,
 		Peer:     peer,
 		RandomID: randomID,
@@ -7207,7 +8578,12 @@ func (*MessagesSendInlineBotResultParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSendInlineBotResult(params *MessagesSendInlineBotResultParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSendInlineBotResultContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSendInlineBotResultContext(ctx context.Context, params *MessagesSendInlineBotResultParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesSendInlineBotResult")
 	}
@@ -7244,7 +8620,12 @@ func (*MessagesSendMediaParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSendMedia(params *MessagesSendMediaParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSendMediaContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSendMediaContext(ctx context.Context, params *MessagesSendMediaParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesSendMedia")
 	}
@@ -7281,7 +8662,12 @@ func (*MessagesSendMessageParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSendMessage(params *MessagesSendMessageParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSendMessageContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSendMessageContext(ctx context.Context, params *MessagesSendMessageParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesSendMessage")
 	}
@@ -7314,7 +8700,12 @@ func (*MessagesSendMultiMediaParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSendMultiMedia(params *MessagesSendMultiMediaParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSendMultiMediaContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSendMultiMediaContext(ctx context.Context, params *MessagesSendMultiMediaParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesSendMultiMedia")
 	}
@@ -7338,7 +8729,12 @@ func (*MessagesSendScheduledMessagesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSendScheduledMessages(peer InputPeer, id []int32) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesSendScheduledMessagesParams{
+	return c.MessagesSendScheduledMessagesContext(context.Background(), peer, id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSendScheduledMessagesContext(ctx context.Context, peer InputPeer, id []int32) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSendScheduledMessagesParams{
 		ID:   id,
 		Peer: peer,
 	})
@@ -7366,7 +8762,12 @@ func (*MessagesSendScreenshotNotificationParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSendScreenshotNotification(peer InputPeer, replyToMsgID int32, randomID int64) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesSendScreenshotNotificationParams{
+	return c.MessagesSendScreenshotNotificationContext(context.Background(), peer, replyToMsgID, randomID)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSendScreenshotNotificationContext(ctx context.Context, peer InputPeer, replyToMsgID int32, randomID int64) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSendScreenshotNotificationParams{
 		Peer:         peer,
 		RandomID:     randomID,
 		ReplyToMsgID: replyToMsgID,
@@ -7395,7 +8796,12 @@ func (*MessagesSendVoteParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSendVote(peer InputPeer, msgID int32, options [][]byte) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesSendVoteParams{
+	return c.MessagesSendVoteContext(context.Background(), peer, msgID, options)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSendVoteContext(ctx context.Context, peer InputPeer, msgID int32, options [][]byte) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSendVoteParams{
 		MsgID:   msgID,
 		Options: options,
 		Peer:    peer,
@@ -7430,7 +8836,12 @@ func (*MessagesSetBotCallbackAnswerParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSetBotCallbackAnswer(params *MessagesSetBotCallbackAnswerParams) (bool, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSetBotCallbackAnswerContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSetBotCallbackAnswerContext(ctx context.Context, params *MessagesSetBotCallbackAnswerParams) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesSetBotCallbackAnswer")
 	}
@@ -7459,7 +8870,12 @@ func (*MessagesSetBotPrecheckoutResultsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSetBotPrecheckoutResults(success bool, queryID int64, error string) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesSetBotPrecheckoutResultsParams{
+	return c.MessagesSetBotPrecheckoutResultsContext(context.Background(), success, queryID, error)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSetBotPrecheckoutResultsContext(ctx context.Context, success bool, queryID int64, error string) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSetBotPrecheckoutResultsParams{
 		Error:   error,
 		QueryID: queryID,
 		Success: success,
@@ -7492,7 +8908,12 @@ func (*MessagesSetBotShippingResultsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSetBotShippingResults(queryID int64, error string, shippingOptions []*ShippingOption) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesSetBotShippingResultsParams{
+	return c.MessagesSetBotShippingResultsContext(context.Background(), queryID, error, shippingOptions)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSetBotShippingResultsContext(ctx context.Context, queryID int64, error string, shippingOptions []*ShippingOption) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSetBotShippingResultsParams{
 		Error:           error,
 		QueryID:         queryID,
 		ShippingOptions: shippingOptions,
@@ -7520,7 +8941,12 @@ func (*MessagesSetEncryptedTypingParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSetEncryptedTyping(peer *InputEncryptedChat, typing bool) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesSetEncryptedTypingParams{
+	return c.MessagesSetEncryptedTypingContext(context.Background(), peer, typing)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSetEncryptedTypingContext(ctx context.Context, peer *InputEncryptedChat, typing bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSetEncryptedTypingParams{
 		Peer:   peer,
 		Typing: typing,
 	})
@@ -7555,7 +8981,12 @@ func (*MessagesSetGameScoreParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSetGameScore(params *MessagesSetGameScoreParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSetGameScoreContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSetGameScoreContext(ctx context.Context, params *MessagesSetGameScoreParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesSetGameScore")
 	}
@@ -7588,7 +9019,12 @@ func (*MessagesSetInlineBotResultsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSetInlineBotResults(params *MessagesSetInlineBotResultsParams) (bool, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSetInlineBotResultsContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSetInlineBotResultsContext(ctx context.Context, params *MessagesSetInlineBotResultsParams) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesSetInlineBotResults")
 	}
@@ -7619,7 +9055,12 @@ func (*MessagesSetInlineGameScoreParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSetInlineGameScore(params *MessagesSetInlineGameScoreParams) (bool, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesSetInlineGameScoreContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSetInlineGameScoreContext(ctx context.Context, params *MessagesSetInlineGameScoreParams) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesSetInlineGameScore")
 	}
@@ -7648,7 +9089,12 @@ func (*MessagesSetTypingParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesSetTyping(peer InputPeer, topMsgID int32, action SendMessageAction) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesSetTypingParams{
+	return c.MessagesSetTypingContext(context.Background(), peer, topMsgID, action)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesSetTypingContext(ctx context.Context, peer InputPeer, topMsgID int32, action SendMessageAction) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesSetTypingParams{
 		Action:   action,
 		Peer:     peer,
 		TopMsgID: topMsgID,
@@ -7678,7 +9124,12 @@ func (*MessagesStartBotParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesStartBot(bot InputUser, peer InputPeer, randomID int64, startParam string) (Updates, error) {
-	responseData, err := c.MakeRequest(&MessagesStartBotParams{
+	return c.MessagesStartBotContext(context.Background(), bot, peer, randomID, startParam)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesStartBotContext(ctx context.Context, bot InputUser, peer InputPeer, randomID int64, startParam string) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesStartBotParams{
 		Bot:        bot,
 		Peer:       peer,
 		RandomID:   randomID,
@@ -7711,7 +9162,12 @@ func (*MessagesToggleDialogPinParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesToggleDialogPin(pinned bool, peer InputDialogPeer) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesToggleDialogPinParams{
+	return c.MessagesToggleDialogPinContext(context.Background(), pinned, peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesToggleDialogPinContext(ctx context.Context, pinned bool, peer InputDialogPeer) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesToggleDialogPinParams{
 		Peer:   peer,
 		Pinned: pinned,
 	})
@@ -7744,7 +9200,12 @@ func (*MessagesToggleStickerSetsParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesToggleStickerSets(uninstall, archive, unarchive bool, stickersets []InputStickerSet) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesToggleStickerSetsParams{
+	return c.MessagesToggleStickerSetsContext(context.Background(), uninstall, archive, unarchive, stickersets)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesToggleStickerSetsContext(ctx context.Context, uninstall, archive, unarchive bool, stickersets []InputStickerSet) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesToggleStickerSetsParams{
 		Archive:     archive,
 		Stickersets: stickersets,
 		Unarchive:   unarchive,
@@ -7772,7 +9233,12 @@ func (*MessagesUninstallStickerSetParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesUninstallStickerSet(stickerset InputStickerSet) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesUninstallStickerSetParams{Stickerset: stickerset})
+	return c.MessagesUninstallStickerSetContext(context.Background(), stickerset)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesUninstallStickerSetContext(ctx context.Context, stickerset InputStickerSet) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesUninstallStickerSetParams{Stickerset: stickerset})
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesUninstallStickerSet")
 	}
@@ -7795,7 +9261,12 @@ func (*MessagesUnpinAllMessagesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesUnpinAllMessages(peer InputPeer) (*MessagesAffectedHistory, error) {
-	responseData, err := c.MakeRequest(&MessagesUnpinAllMessagesParams{Peer: peer})
+	return c.MessagesUnpinAllMessagesContext(context.Background(), peer)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesUnpinAllMessagesContext(ctx context.Context, peer InputPeer) (*MessagesAffectedHistory, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesUnpinAllMessagesParams{Peer: peer})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesUnpinAllMessages")
 	}
@@ -7823,7 +9294,12 @@ func (*MessagesUpdateDialogFilterParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesUpdateDialogFilter(id int32, filter *DialogFilter) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesUpdateDialogFilterParams{
+	return c.MessagesUpdateDialogFilterContext(context.Background(), id, filter)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesUpdateDialogFilterContext(ctx context.Context, id int32, filter *DialogFilter) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesUpdateDialogFilterParams{
 		Filter: filter,
 		ID:     id,
 	})
@@ -7849,7 +9325,12 @@ func (*MessagesUpdateDialogFiltersOrderParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesUpdateDialogFiltersOrder(order []int32) (bool, error) {
-	responseData, err := c.MakeRequest(&MessagesUpdateDialogFiltersOrderParams{Order: order})
+	return c.MessagesUpdateDialogFiltersOrderContext(context.Background(), order)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesUpdateDialogFiltersOrderContext(ctx context.Context, order []int32) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesUpdateDialogFiltersOrderParams{Order: order})
 	if err != nil {
 		return false, errors.Wrap(err, "sending MessagesUpdateDialogFiltersOrder")
 	}
@@ -7880,7 +9361,12 @@ func (*MessagesUpdatePinnedMessageParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesUpdatePinnedMessage(params *MessagesUpdatePinnedMessageParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.MessagesUpdatePinnedMessageContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesUpdatePinnedMessageContext(ctx context.Context, params *MessagesUpdatePinnedMessageParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending MessagesUpdatePinnedMessage")
 	}
@@ -7904,7 +9390,12 @@ func (*MessagesUploadEncryptedFileParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesUploadEncryptedFile(peer *InputEncryptedChat, file InputEncryptedFile) (EncryptedFile, error) {
-	responseData, err := c.MakeRequest(&MessagesUploadEncryptedFileParams{
+	return c.MessagesUploadEncryptedFileContext(context.Background(), peer, file)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesUploadEncryptedFileContext(ctx context.Context, peer *InputEncryptedChat, file InputEncryptedFile) (EncryptedFile, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesUploadEncryptedFileParams{
 		File: file,
 		Peer: peer,
 	})
@@ -7931,7 +9422,12 @@ func (*MessagesUploadMediaParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) MessagesUploadMedia(peer InputPeer, media InputMedia) (MessageMedia, error) {
-	responseData, err := c.MakeRequest(&MessagesUploadMediaParams{
+	return c.MessagesUploadMediaContext(context.Background(), peer, media)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) MessagesUploadMediaContext(ctx context.Context, peer InputPeer, media InputMedia) (MessageMedia, error) {
+	responseData, err := c.MakeRequestContext(ctx, &MessagesUploadMediaParams{
 		Media: media,
 		Peer:  peer,
 	})
@@ -7962,7 +9458,12 @@ func (*PaymentsClearSavedInfoParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) PaymentsClearSavedInfo(credentials, info bool) (bool, error) {
-	responseData, err := c.MakeRequest(&PaymentsClearSavedInfoParams{
+	return c.PaymentsClearSavedInfoContext(context.Background(), credentials, info)
+}
+
+// Get the participants of a channel
+func (c *Client) PaymentsClearSavedInfoContext(ctx context.Context, credentials, info bool) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PaymentsClearSavedInfoParams{
 		Credentials: credentials,
 		Info:        info,
 	})
@@ -7988,7 +9489,12 @@ func (*PaymentsGetBankCardDataParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) PaymentsGetBankCardData(number string) (*PaymentsBankCardData, error) {
-	responseData, err := c.MakeRequest(&PaymentsGetBankCardDataParams{Number: number})
+	return c.PaymentsGetBankCardDataContext(context.Background(), number)
+}
+
+// Get the participants of a channel
+func (c *Client) PaymentsGetBankCardDataContext(ctx context.Context, number string) (*PaymentsBankCardData, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PaymentsGetBankCardDataParams{Number: number})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending PaymentsGetBankCardData")
 	}
@@ -8011,7 +9517,12 @@ func (*PaymentsGetPaymentFormParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) PaymentsGetPaymentForm(msgID int32) (*PaymentsPaymentForm, error) {
-	responseData, err := c.MakeRequest(&PaymentsGetPaymentFormParams{MsgID: msgID})
+	return c.PaymentsGetPaymentFormContext(context.Background(), msgID)
+}
+
+// Get the participants of a channel
+func (c *Client) PaymentsGetPaymentFormContext(ctx context.Context, msgID int32) (*PaymentsPaymentForm, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PaymentsGetPaymentFormParams{MsgID: msgID})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending PaymentsGetPaymentForm")
 	}
@@ -8034,7 +9545,12 @@ func (*PaymentsGetPaymentReceiptParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) PaymentsGetPaymentReceipt(msgID int32) (*PaymentsPaymentReceipt, error) {
-	responseData, err := c.MakeRequest(&PaymentsGetPaymentReceiptParams{MsgID: msgID})
+	return c.PaymentsGetPaymentReceiptContext(context.Background(), msgID)
+}
+
+// Get the participants of a channel
+func (c *Client) PaymentsGetPaymentReceiptContext(ctx context.Context, msgID int32) (*PaymentsPaymentReceipt, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PaymentsGetPaymentReceiptParams{MsgID: msgID})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending PaymentsGetPaymentReceipt")
 	}
@@ -8055,7 +9571,12 @@ func (*PaymentsGetSavedInfoParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) PaymentsGetSavedInfo() (*PaymentsSavedInfo, error) {
-	responseData, err := c.MakeRequest(&PaymentsGetSavedInfoParams{})
+	return c.PaymentsGetSavedInfoContext(context.Background())
+}
+
+// Get the participants of a channel
+func (c *Client) PaymentsGetSavedInfoContext(ctx context.Context) (*PaymentsSavedInfo, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PaymentsGetSavedInfoParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending PaymentsGetSavedInfo")
 	}
@@ -8085,7 +9606,12 @@ func (*PaymentsSendPaymentFormParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) PaymentsSendPaymentForm(msgID int32, requestedInfoID, shippingOptionID string, credentials InputPaymentCredentials) (PaymentsPaymentResult, error) {
-	responseData, err := c.MakeRequest(&PaymentsSendPaymentFormParams{
+	return c.PaymentsSendPaymentFormContext(context.Background(), msgID, requestedInfoID, shippingOptionID, credentials)
+}
+
+// Get the participants of a channel
+func (c *Client) PaymentsSendPaymentFormContext(ctx context.Context, msgID int32, requestedInfoID, shippingOptionID string, credentials InputPaymentCredentials) (PaymentsPaymentResult, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PaymentsSendPaymentFormParams{
 		Credentials:      credentials,
 		MsgID:            msgID,
 		RequestedInfoID:  requestedInfoID,
@@ -8119,7 +9645,12 @@ func (*PaymentsValidateRequestedInfoParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) PaymentsValidateRequestedInfo(save bool, msgID int32, info *PaymentRequestedInfo) (*PaymentsValidatedRequestedInfo, error) {
-	responseData, err := c.MakeRequest(&PaymentsValidateRequestedInfoParams{
+	return c.PaymentsValidateRequestedInfoContext(context.Background(), save, msgID, info)
+}
+
+// Get the participants of a channel
+func (c *Client) PaymentsValidateRequestedInfoContext(ctx context.Context, save bool, msgID int32, info *PaymentRequestedInfo) (*PaymentsValidatedRequestedInfo, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PaymentsValidateRequestedInfoParams{
 		Info:  info,
 		MsgID: msgID,
 		Save:  save,
@@ -8148,7 +9679,12 @@ func (*PhoneAcceptCallParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) PhoneAcceptCall(peer *InputPhoneCall, gB []byte, protocol *PhoneCallProtocol) (*PhonePhoneCall, error) {
-	responseData, err := c.MakeRequest(&PhoneAcceptCallParams{
+	return c.PhoneAcceptCallContext(context.Background(), peer, gB, protocol)
+}
+
+// Get the participants of a channel
+func (c *Client) PhoneAcceptCallContext(ctx context.Context, peer *InputPhoneCall, gB []byte, protocol *PhoneCallProtocol) (*PhonePhoneCall, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PhoneAcceptCallParams{
 		GB:       gB,
 		Peer:     peer,
 		Protocol: protocol,
@@ -8178,7 +9714,12 @@ func (*PhoneConfirmCallParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) PhoneConfirmCall(peer *InputPhoneCall, gA []byte, keyFingerprint int64, protocol *PhoneCallProtocol) (*PhonePhoneCall, error) {
-	responseData, err := c.MakeRequest(&PhoneConfirmCallParams{
+	return c.PhoneConfirmCallContext(context.Background(), peer, gA, keyFingerprint, protocol)
+}
+
+// Get the participants of a channel
+func (c *Client) PhoneConfirmCallContext(ctx context.Context, peer *InputPhoneCall, gA []byte, keyFingerprint int64, protocol *PhoneCallProtocol) (*PhonePhoneCall, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PhoneConfirmCallParams{
 		GA:             gA,
 		KeyFingerprint: keyFingerprint,
 		Peer:           peer,
@@ -8214,7 +9755,12 @@ func (*PhoneDiscardCallParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) PhoneDiscardCall(params *PhoneDiscardCallParams) (Updates, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.PhoneDiscardCallContext(context.Background(), params)
+}
+
+// Get the participants of a channel
+func (c *Client) PhoneDiscardCallContext(ctx context.Context, params *PhoneDiscardCallParams) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending PhoneDiscardCall")
 	}
@@ -8235,7 +9781,12 @@ func (*PhoneGetCallConfigParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) PhoneGetCallConfig() (*DataJson, error) {
-	responseData, err := c.MakeRequest(&PhoneGetCallConfigParams{})
+	return c.PhoneGetCallConfigContext(context.Background())
+}
+
+// Get the participants of a channel
+func (c *Client) PhoneGetCallConfigContext(ctx context.Context) (*DataJson, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PhoneGetCallConfigParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending PhoneGetCallConfig")
 	}
@@ -8258,7 +9809,12 @@ func (*PhoneReceivedCallParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) PhoneReceivedCall(peer *InputPhoneCall) (bool, error) {
-	responseData, err := c.MakeRequest(&PhoneReceivedCallParams{Peer: peer})
+	return c.PhoneReceivedCallContext(context.Background(), peer)
+}
+
+// Get the participants of a channel
+func (c *Client) PhoneReceivedCallContext(ctx context.Context, peer *InputPhoneCall) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PhoneReceivedCallParams{Peer: peer})
 	if err != nil {
 		return false, errors.Wrap(err, "sending PhoneReceivedCall")
 	}
@@ -8289,7 +9845,12 @@ func (*PhoneRequestCallParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) PhoneRequestCall(params *PhoneRequestCallParams) (*PhonePhoneCall, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.PhoneRequestCallContext(context.Background(), params)
+}
+
+// Get the participants of a channel
+func (c *Client) PhoneRequestCallContext(ctx context.Context, params *PhoneRequestCallParams) (*PhonePhoneCall, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending PhoneRequestCall")
 	}
@@ -8313,7 +9874,12 @@ func (*PhoneSaveCallDebugParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) PhoneSaveCallDebug(peer *InputPhoneCall, debug *DataJson) (bool, error) {
-	responseData, err := c.MakeRequest(&PhoneSaveCallDebugParams{
+	return c.PhoneSaveCallDebugContext(context.Background(), peer, debug)
+}
+
+// Get the participants of a channel
+func (c *Client) PhoneSaveCallDebugContext(ctx context.Context, peer *InputPhoneCall, debug *DataJson) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PhoneSaveCallDebugParams{
 		Debug: debug,
 		Peer:  peer,
 	})
@@ -8340,7 +9906,12 @@ func (*PhoneSendSignalingDataParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) PhoneSendSignalingData(peer *InputPhoneCall, data []byte) (bool, error) {
-	responseData, err := c.MakeRequest(&PhoneSendSignalingDataParams{
+	return c.PhoneSendSignalingDataContext(context.Background(), peer, data)
+}
+
+// Get the participants of a channel
+func (c *Client) PhoneSendSignalingDataContext(ctx context.Context, peer *InputPhoneCall, data []byte) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PhoneSendSignalingDataParams{
 		Data: data,
 		Peer: peer,
 	})
@@ -8373,7 +9944,12 @@ func (*PhoneSetCallRatingParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) PhoneSetCallRating(userInitiative bool, peer *InputPhoneCall, rating int32, comment string) (Updates, error) {
-	responseData, err := c.MakeRequest(&PhoneSetCallRatingParams{
+	return c.PhoneSetCallRatingContext(context.Background(), userInitiative, peer, rating, comment)
+}
+
+// Get the participants of a channel
+func (c *Client) PhoneSetCallRatingContext(ctx context.Context, userInitiative bool, peer *InputPhoneCall, rating int32, comment string) (Updates, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PhoneSetCallRatingParams{
 		Comment:        comment,
 		Peer:           peer,
 		Rating:         rating,
@@ -8401,7 +9977,12 @@ func (*PhotosDeletePhotosParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) PhotosDeletePhotos(id []InputPhoto) ([]int64, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&PhotosDeletePhotosParams{ID: id}, reflect.TypeOf([]int64{}))
+	return c.PhotosDeletePhotosContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) PhotosDeletePhotosContext(ctx context.Context, id []InputPhoto) ([]int64, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &PhotosDeletePhotosParams{ID: id}, reflect.TypeOf([]int64{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending PhotosDeletePhotos")
 	}
@@ -8427,7 +10008,12 @@ func (*PhotosGetUserPhotosParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) PhotosGetUserPhotos(userID InputUser, offset int32, maxID int64, limit int32) (PhotosPhotos, error) {
-	responseData, err := c.MakeRequest(&PhotosGetUserPhotosParams{
+	return c.PhotosGetUserPhotosContext(context.Background(), userID, offset, maxID, limit)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) PhotosGetUserPhotosContext(ctx context.Context, userID InputUser, offset int32, maxID int64, limit int32) (PhotosPhotos, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PhotosGetUserPhotosParams{
 		Limit:  limit,
 		MaxID:  maxID,
 		Offset: offset,
@@ -8455,7 +10041,12 @@ func (*PhotosUpdateProfilePhotoParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) PhotosUpdateProfilePhoto(id InputPhoto) (*PhotosPhoto, error) {
-	responseData, err := c.MakeRequest(&PhotosUpdateProfilePhotoParams{ID: id})
+	return c.PhotosUpdateProfilePhotoContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) PhotosUpdateProfilePhotoContext(ctx context.Context, id InputPhoto) (*PhotosPhoto, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PhotosUpdateProfilePhotoParams{ID: id})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending PhotosUpdateProfilePhoto")
 	}
@@ -8484,7 +10075,12 @@ func (*PhotosUploadProfilePhotoParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) PhotosUploadProfilePhoto(file, video InputFile, videoStartTs float64) (*PhotosPhoto, error) {
-	responseData, err := c.MakeRequest(&PhotosUploadProfilePhotoParams{
+	return c.PhotosUploadProfilePhotoContext(context.Background(), file, video, videoStartTs)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) PhotosUploadProfilePhotoContext(ctx context.Context, file, video InputFile, videoStartTs float64) (*PhotosPhoto, error) {
+	responseData, err := c.MakeRequestContext(ctx, &PhotosUploadProfilePhotoParams{
 		File:         file,
 		Video:        video,
 		VideoStartTs: videoStartTs,
@@ -8516,7 +10112,12 @@ func (*StatsGetBroadcastStatsParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) StatsGetBroadcastStats(dark bool, channel InputChannel) (*StatsBroadcastStats, error) {
-	responseData, err := c.MakeRequest(&StatsGetBroadcastStatsParams{
+	return c.StatsGetBroadcastStatsContext(context.Background(), dark, channel)
+}
+
+// Get the participants of a channel
+func (c *Client) StatsGetBroadcastStatsContext(ctx context.Context, dark bool, channel InputChannel) (*StatsBroadcastStats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &StatsGetBroadcastStatsParams{
 		Channel: channel,
 		Dark:    dark,
 	})
@@ -8547,7 +10148,12 @@ func (*StatsGetMegagroupStatsParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) StatsGetMegagroupStats(dark bool, channel InputChannel) (*StatsMegagroupStats, error) {
-	responseData, err := c.MakeRequest(&StatsGetMegagroupStatsParams{
+	return c.StatsGetMegagroupStatsContext(context.Background(), dark, channel)
+}
+
+// Get the participants of a channel
+func (c *Client) StatsGetMegagroupStatsContext(ctx context.Context, dark bool, channel InputChannel) (*StatsMegagroupStats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &StatsGetMegagroupStatsParams{
 		Channel: channel,
 		Dark:    dark,
 	})
@@ -8578,7 +10184,12 @@ func (*StatsGetMessagePublicForwardsParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) StatsGetMessagePublicForwards(params *StatsGetMessagePublicForwardsParams) (MessagesMessages, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.StatsGetMessagePublicForwardsContext(context.Background(), params)
+}
+
+// Get the participants of a channel
+func (c *Client) StatsGetMessagePublicForwardsContext(ctx context.Context, params *StatsGetMessagePublicForwardsParams) (MessagesMessages, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending StatsGetMessagePublicForwards")
 	}
@@ -8607,7 +10218,12 @@ func (*StatsGetMessageStatsParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) StatsGetMessageStats(dark bool, channel InputChannel, msgID int32) (*StatsMessageStats, error) {
-	responseData, err := c.MakeRequest(&StatsGetMessageStatsParams{
+	return c.StatsGetMessageStatsContext(context.Background(), dark, channel, msgID)
+}
+
+// Get the participants of a channel
+func (c *Client) StatsGetMessageStatsContext(ctx context.Context, dark bool, channel InputChannel, msgID int32) (*StatsMessageStats, error) {
+	responseData, err := c.MakeRequestContext(ctx, &StatsGetMessageStatsParams{
 		Channel: channel,
 		Dark:    dark,
 		MsgID:   msgID,
@@ -8639,7 +10255,12 @@ func (*StatsLoadAsyncGraphParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) StatsLoadAsyncGraph(token string, x int64) (StatsGraph, error) {
-	responseData, err := c.MakeRequest(&StatsLoadAsyncGraphParams{
+	return c.StatsLoadAsyncGraphContext(context.Background(), token, x)
+}
+
+// Get the participants of a channel
+func (c *Client) StatsLoadAsyncGraphContext(ctx context.Context, token string, x int64) (StatsGraph, error) {
+	responseData, err := c.MakeRequestContext(ctx, &StatsLoadAsyncGraphParams{
 		Token: token,
 		X:     x,
 	})
@@ -8666,7 +10287,12 @@ func (*StickersAddStickerToSetParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) StickersAddStickerToSet(stickerset InputStickerSet, sticker *InputStickerSetItem) (*MessagesStickerSet, error) {
-	responseData, err := c.MakeRequest(&StickersAddStickerToSetParams{
+	return c.StickersAddStickerToSetContext(context.Background(), stickerset, sticker)
+}
+
+// Get the participants of a channel
+func (c *Client) StickersAddStickerToSetContext(ctx context.Context, stickerset InputStickerSet, sticker *InputStickerSetItem) (*MessagesStickerSet, error) {
+	responseData, err := c.MakeRequestContext(ctx, &StickersAddStickerToSetParams{
 		Sticker:    sticker,
 		Stickerset: stickerset,
 	})
@@ -8693,7 +10319,12 @@ func (*StickersChangeStickerPositionParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) StickersChangeStickerPosition(sticker InputDocument, position int32) (*MessagesStickerSet, error) {
-	responseData, err := c.MakeRequest(&StickersChangeStickerPositionParams{
+	return c.StickersChangeStickerPositionContext(context.Background(), sticker, position)
+}
+
+// Get the participants of a channel
+func (c *Client) StickersChangeStickerPositionContext(ctx context.Context, sticker InputDocument, position int32) (*MessagesStickerSet, error) {
+	responseData, err := c.MakeRequestContext(ctx, &StickersChangeStickerPositionParams{
 		Position: position,
 		Sticker:  sticker,
 	})
@@ -8729,7 +10360,12 @@ func (*StickersCreateStickerSetParams) FlagIndex() int {
 
 // Get the participants of a channel
 func (c *Client) StickersCreateStickerSet(params *StickersCreateStickerSetParams) (*MessagesStickerSet, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.StickersCreateStickerSetContext(context.Background(), params)
+}
+
+// Get the participants of a channel
+func (c *Client) StickersCreateStickerSetContext(ctx context.Context, params *StickersCreateStickerSetParams) (*MessagesStickerSet, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending StickersCreateStickerSet")
 	}
@@ -8752,7 +10388,12 @@ func (*StickersRemoveStickerFromSetParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) StickersRemoveStickerFromSet(sticker InputDocument) (*MessagesStickerSet, error) {
-	responseData, err := c.MakeRequest(&StickersRemoveStickerFromSetParams{Sticker: sticker})
+	return c.StickersRemoveStickerFromSetContext(context.Background(), sticker)
+}
+
+// Get the participants of a channel
+func (c *Client) StickersRemoveStickerFromSetContext(ctx context.Context, sticker InputDocument) (*MessagesStickerSet, error) {
+	responseData, err := c.MakeRequestContext(ctx, &StickersRemoveStickerFromSetParams{Sticker: sticker})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending StickersRemoveStickerFromSet")
 	}
@@ -8776,7 +10417,12 @@ func (*StickersSetStickerSetThumbParams) CRC() uint32 {
 
 // Get the participants of a channel
 func (c *Client) StickersSetStickerSetThumb(stickerset InputStickerSet, thumb InputDocument) (*MessagesStickerSet, error) {
-	responseData, err := c.MakeRequest(&StickersSetStickerSetThumbParams{
+	return c.StickersSetStickerSetThumbContext(context.Background(), stickerset, thumb)
+}
+
+// Get the participants of a channel
+func (c *Client) StickersSetStickerSetThumbContext(ctx context.Context, stickerset InputStickerSet, thumb InputDocument) (*MessagesStickerSet, error) {
+	responseData, err := c.MakeRequestContext(ctx, &StickersSetStickerSetThumbParams{
 		Stickerset: stickerset,
 		Thumb:      thumb,
 	})
@@ -8810,7 +10456,12 @@ func (*UpdatesGetChannelDifferenceParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UpdatesGetChannelDifference(params *UpdatesGetChannelDifferenceParams) (UpdatesChannelDifference, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.UpdatesGetChannelDifferenceContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UpdatesGetChannelDifferenceContext(ctx context.Context, params *UpdatesGetChannelDifferenceParams) (UpdatesChannelDifference, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending UpdatesGetChannelDifference")
 	}
@@ -8840,7 +10491,12 @@ func (*UpdatesGetDifferenceParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UpdatesGetDifference(pts, ptsTotalLimit, date, qts int32) (UpdatesDifference, error) {
-	responseData, err := c.MakeRequest(&UpdatesGetDifferenceParams{
+	return c.UpdatesGetDifferenceContext(context.Background(), pts, ptsTotalLimit, date, qts)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UpdatesGetDifferenceContext(ctx context.Context, pts, ptsTotalLimit, date, qts int32) (UpdatesDifference, error) {
+	responseData, err := c.MakeRequestContext(ctx, &UpdatesGetDifferenceParams{
 		Date:          date,
 		Pts:           pts,
 		PtsTotalLimit: ptsTotalLimit,
@@ -8866,7 +10522,12 @@ func (*UpdatesGetStateParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UpdatesGetState() (*UpdatesState, error) {
-	responseData, err := c.MakeRequest(&UpdatesGetStateParams{})
+	return c.UpdatesGetStateContext(context.Background())
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UpdatesGetStateContext(ctx context.Context) (*UpdatesState, error) {
+	responseData, err := c.MakeRequestContext(ctx, &UpdatesGetStateParams{})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending UpdatesGetState")
 	}
@@ -8891,7 +10552,12 @@ func (*UploadGetCdnFileParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UploadGetCdnFile(fileToken []byte, offset, limit int32) (UploadCdnFile, error) {
-	responseData, err := c.MakeRequest(&UploadGetCdnFileParams{
+	return c.UploadGetCdnFileContext(context.Background(), fileToken, offset, limit)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UploadGetCdnFileContext(ctx context.Context, fileToken []byte, offset, limit int32) (UploadCdnFile, error) {
+	responseData, err := c.MakeRequestContext(ctx, &UploadGetCdnFileParams{
 		FileToken: fileToken,
 		Limit:     limit,
 		Offset:    offset,
@@ -8919,7 +10585,12 @@ func (*UploadGetCdnFileHashesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UploadGetCdnFileHashes(fileToken []byte, offset int32) ([]*FileHash, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&UploadGetCdnFileHashesParams{
+	return c.UploadGetCdnFileHashesContext(context.Background(), fileToken, offset)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UploadGetCdnFileHashesContext(ctx context.Context, fileToken []byte, offset int32) ([]*FileHash, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &UploadGetCdnFileHashesParams{
 		FileToken: fileToken,
 		Offset:    offset,
 	}, reflect.TypeOf([]*FileHash{}))
@@ -8953,7 +10624,12 @@ func (*UploadGetFileParams) FlagIndex() int {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UploadGetFile(params *UploadGetFileParams) (UploadFile, error) {
-	responseData, err := c.MakeRequest(params)
+	return c.UploadGetFileContext(context.Background(), params)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UploadGetFileContext(ctx context.Context, params *UploadGetFileParams) (UploadFile, error) {
+	responseData, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending UploadGetFile")
 	}
@@ -8977,7 +10653,12 @@ func (*UploadGetFileHashesParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UploadGetFileHashes(location InputFileLocation, offset int32) ([]*FileHash, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&UploadGetFileHashesParams{
+	return c.UploadGetFileHashesContext(context.Background(), location, offset)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UploadGetFileHashesContext(ctx context.Context, location InputFileLocation, offset int32) ([]*FileHash, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &UploadGetFileHashesParams{
 		Location: location,
 		Offset:   offset,
 	}, reflect.TypeOf([]*FileHash{}))
@@ -9005,7 +10686,12 @@ func (*UploadGetWebFileParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UploadGetWebFile(location InputWebFileLocation, offset, limit int32) (*UploadWebFile, error) {
-	responseData, err := c.MakeRequest(&UploadGetWebFileParams{
+	return c.UploadGetWebFileContext(context.Background(), location, offset, limit)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UploadGetWebFileContext(ctx context.Context, location InputWebFileLocation, offset, limit int32) (*UploadWebFile, error) {
+	responseData, err := c.MakeRequestContext(ctx, &UploadGetWebFileParams{
 		Limit:    limit,
 		Location: location,
 		Offset:   offset,
@@ -9033,7 +10719,12 @@ func (*UploadReuploadCdnFileParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UploadReuploadCdnFile(fileToken, requestToken []byte) ([]*FileHash, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&UploadReuploadCdnFileParams{
+	return c.UploadReuploadCdnFileContext(context.Background(), fileToken, requestToken)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UploadReuploadCdnFileContext(ctx context.Context, fileToken, requestToken []byte) ([]*FileHash, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &UploadReuploadCdnFileParams{
 		FileToken:    fileToken,
 		RequestToken: requestToken,
 	}, reflect.TypeOf([]*FileHash{}))
@@ -9062,7 +10753,12 @@ func (*UploadSaveBigFilePartParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UploadSaveBigFilePart(fileID int64, filePart, fileTotalParts int32, bytes []byte) (bool, error) {
-	responseData, err := c.MakeRequest(&UploadSaveBigFilePartParams{
+	return c.UploadSaveBigFilePartContext(context.Background(), fileID, filePart, fileTotalParts, bytes)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UploadSaveBigFilePartContext(ctx context.Context, fileID int64, filePart, fileTotalParts int32, bytes []byte) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &UploadSaveBigFilePartParams{
 		Bytes:          bytes,
 		FileID:         fileID,
 		FilePart:       filePart,
@@ -9092,7 +10788,12 @@ func (*UploadSaveFilePartParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UploadSaveFilePart(fileID int64, filePart int32, bytes []byte) (bool, error) {
-	responseData, err := c.MakeRequest(&UploadSaveFilePartParams{
+	return c.UploadSaveFilePartContext(context.Background(), fileID, filePart, bytes)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UploadSaveFilePartContext(ctx context.Context, fileID int64, filePart int32, bytes []byte) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &UploadSaveFilePartParams{
 		Bytes:    bytes,
 		FileID:   fileID,
 		FilePart: filePart,
@@ -9119,7 +10820,12 @@ func (*UsersGetFullUserParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UsersGetFullUser(id InputUser) (*UserFull, error) {
-	responseData, err := c.MakeRequest(&UsersGetFullUserParams{ID: id})
+	return c.UsersGetFullUserContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UsersGetFullUserContext(ctx context.Context, id InputUser) (*UserFull, error) {
+	responseData, err := c.MakeRequestContext(ctx, &UsersGetFullUserParams{ID: id})
 	if err != nil {
 		return nil, errors.Wrap(err, "sending UsersGetFullUser")
 	}
@@ -9142,7 +10848,12 @@ func (*UsersGetUsersParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UsersGetUsers(id []InputUser) ([]User, error) {
-	responseData, err := c.MakeRequestWithHintToDecoder(&UsersGetUsersParams{ID: id}, reflect.TypeOf([]User{}))
+	return c.UsersGetUsersContext(context.Background(), id)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UsersGetUsersContext(ctx context.Context, id []InputUser) ([]User, error) {
+	responseData, err := c.MakeRequestWithHintToDecoderContext(ctx, &UsersGetUsersParams{ID: id}, reflect.TypeOf([]User{}))
 	if err != nil {
 		return nil, errors.Wrap(err, "sending UsersGetUsers")
 	}
@@ -9166,7 +10877,12 @@ func (*UsersSetSecureValueErrorsParams) CRC() uint32 {
 
 // Sends a Telegram Passport authorization form, effectively sharing data with the service
 func (c *Client) UsersSetSecureValueErrors(id InputUser, errs []SecureValueError) (bool, error) {
-	responseData, err := c.MakeRequest(&UsersSetSecureValueErrorsParams{
+	return c.UsersSetSecureValueErrorsContext(context.Background(), id, errs)
+}
+
+// Sends a Telegram Passport authorization form, effectively sharing data with the service
+func (c *Client) UsersSetSecureValueErrorsContext(ctx context.Context, id InputUser, errs []SecureValueError) (bool, error) {
+	responseData, err := c.MakeRequestContext(ctx, &UsersSetSecureValueErrorsParams{
 		Errors: errs,
 		ID:     id,
 	})
@@ -9,6 +9,8 @@ package telegram
 // генератор не понимает что такое !X (и не должен понимать 100%)
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"github.com/umesproject/mtproto/internal/encoding/tl"
@@ -39,7 +41,11 @@ func (*InitConnectionParams) FlagIndex() int {
 }
 
 func (c *Client) InitConnection(params *InitConnectionParams) (tl.Object, error) {
-	data, err := c.MakeRequest(params)
+	return c.InitConnectionContext(context.Background(), params)
+}
+
+func (c *Client) InitConnectionContext(ctx context.Context, params *InitConnectionParams) (tl.Object, error) {
+	data, err := c.MakeRequestContext(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "sending InitConnection")
 	}
@@ -57,7 +63,11 @@ func (*InvokeWithLayerParams) CRC() uint32 {
 }
 
 func (m *Client) InvokeWithLayer(layer int, query tl.Object) (tl.Object, error) {
-	data, err := m.MakeRequest(&InvokeWithLayerParams{
+	return m.InvokeWithLayerContext(context.Background(), layer, query)
+}
+
+func (m *Client) InvokeWithLayerContext(ctx context.Context, layer int, query tl.Object) (tl.Object, error) {
+	data, err := m.MakeRequestContext(ctx, &InvokeWithLayerParams{
 		Layer: int32(layer),
 		Query: query,
 	})
@@ -81,7 +91,11 @@ func (*InvokeWithTakeoutParams) CRC() uint32 {
 }
 
 func (m *Client) InvokeWithTakeout(takeoutID int, query tl.Object) (tl.Object, error) {
-	data, err := m.MakeRequest(&InvokeWithTakeoutParams{
+	return m.InvokeWithTakeoutContext(context.Background(), takeoutID, query)
+}
+
+func (m *Client) InvokeWithTakeoutContext(ctx context.Context, takeoutID int, query tl.Object) (tl.Object, error) {
+	data, err := m.MakeRequestContext(ctx, &InvokeWithTakeoutParams{
 		TakeoutID: int64(takeoutID),
 		Query:     query,
 	})
@@ -16,10 +16,10 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/k0kubun/pp"
 	"github.com/pkg/errors"
 	"github.com/xelaj/errs"
 
+	"github.com/umesproject/mtproto"
 	"github.com/umesproject/mtproto/telegram/internal/calls"
 )
 
@@ -161,7 +161,8 @@ func (c *Client) GetPossibleAllParticipantsOfGroup(ch InputChannel) ([]int, erro
 		case *ChannelParticipantCreator:
 			idsStore[int(user.UserID)] = struct{}{}
 		default:
-			pp.Println(user)
+			c.Logger().Log(mtproto.LevelError, "unexpected participant",
+				mtproto.Field{Key: "participant", Value: user})
 			panic("что?")
 		}
 	}
@@ -225,7 +226,8 @@ func getParticipants(c *Client, ch InputChannel, lastQuery string) (map[int]stru
 			case *ChannelParticipantCreator:
 				idsStore[int(user.UserID)] = struct{}{}
 			default:
-				pp.Println(user)
+				c.Logger().Log(mtproto.LevelError, "unexpected participant",
+					mtproto.Field{Key: "participant", Value: user})
 				panic("что?")
 			}
 		}
@@ -302,17 +304,17 @@ func (c *Client) GetChatByID(chatID int) (Chat, error) {
 	}
 	chats := resp.(*MessagesChatsObj)
 	for _, chat := range chats.Chats {
-		switch c := chat.(type) {
+		switch v := chat.(type) {
 		case *ChatObj:
-			if int(c.ID) == chatID {
-				return c, nil
+			if int(v.ID) == chatID {
+				return v, nil
 			}
 		case *Channel:
-			if -1*(int(c.ID)+(1000000000000)) == chatID { // -100<channelID, specific for bots>
-				return c, nil
+			if -1*(int(v.ID)+(1000000000000)) == chatID { // -100<channelID, specific for bots>
+				return v, nil
 			}
 		default:
-			pp.Println(c)
+			c.Logger().Log(mtproto.LevelError, "unexpected chat", mtproto.Field{Key: "chat", Value: v})
 			panic("???")
 		}
 	}
@@ -366,7 +368,8 @@ func (c *Client) AllUsersInChat(chatID int) ([]int, error) {
 			case *ChannelParticipantCreator:
 				res[int(user.UserID)] = struct{}{}
 			default:
-				pp.Println(user)
+				c.Logger().Log(mtproto.LevelError, "unexpected participant",
+					mtproto.Field{Key: "participant", Value: user})
 				return nil, errors.New("found too specific object")
 			}
 		}
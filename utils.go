@@ -8,6 +8,7 @@ package mtproto
 import (
 	"context"
 	"io"
+	"sync/atomic"
 
 	"github.com/umesproject/mtproto/internal/encoding/tl"
 	"github.com/umesproject/mtproto/internal/mtproto/objects"
@@ -19,6 +20,9 @@ type null = struct{}
 // это неофициальная информация, но есть подозрение, что список датацентров АБСОЛЮТНО идентичный для всех
 // приложений. Несмотря на это, любой клиент ОБЯЗАН явно указывать список датацентров, ради надежности.
 // данный список лишь эксперементальный и не является частью протокола.
+// defaultDC is used, when address of server is not found in dc list
+const defaultDC = 2
+
 func defaultDCList() map[int]string {
 	return map[int]string{
 		1: "149.154.175.58:443",
@@ -31,7 +35,7 @@ func defaultDCList() map[int]string {
 
 func MessageRequireToAck(msg tl.Object) bool {
 	switch msg.(type) {
-	case /**objects.Ping,*/ *objects.MsgsAck:
+	case /**objects.Ping,*/ *objects.MsgsAck, *objects.HttpWaitParams, *objects.MsgsStateInfo, *objects.MsgResendReq:
 		return false
 	default:
 		return true
@@ -45,6 +49,21 @@ func CloseOnCancel(ctx context.Context, c io.Closer) {
 	}()
 }
 
+// atomicFlag is a bool, which could be read and changed by different goroutines.
+type atomicFlag int32
+
+func (f *atomicFlag) Get() bool {
+	return atomic.LoadInt32((*int32)(f)) != 0
+}
+
+func (f *atomicFlag) Set(v bool) {
+	var i int32
+	if v {
+		i = 1
+	}
+	atomic.StoreInt32((*int32)(f), i)
+}
+
 func check(err error) {
 	if err != nil {
 		panic(err)